@@ -0,0 +1,169 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRecord represents a request modifying a drive which has been sent by a client, see
+// WithAuditSink.
+type AuditRecord struct {
+	// Sequence numbers the records of the client from 1, without gaps.
+	Sequence uint64    `json:"sequence"`
+	Time     time.Time `json:"time"`
+	// Actor is the actor set by ContextWithAuditActor, if any.
+	Actor  string `json:"actor,omitempty"`
+	Method string `json:"method"`
+	// URL is the URL of the request, without its query which may hold credentials, e.g.
+	// in the URLs of the upload sessions.
+	URL string `json:"url"`
+	// Target is the ID of the item, or its path from the root prefixed with "/", which the
+	// request is about, if any.
+	Target   string        `json:"target,omitempty"`
+	Duration time.Duration `json:"duration"`
+	// StatusCode is the HTTP status code of the API error, if any.
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// PreviousHash is the Hash of the previous record of the client, empty for the first
+	// record.
+	PreviousHash string `json:"previousHash,omitempty"`
+	// Hash is the hex-encoded SHA-256 of the JSON encoding of the record without Hash. As
+	// every record includes the hash of the previous one, a record of the log cannot be
+	// altered, removed or inserted without breaking the chain, see VerifyAuditChain.
+	Hash string `json:"hash"`
+}
+
+// AuditSink receives the records of the requests modifying a drive, e.g. to ship them
+// along with the logs of the application. Record is called once the request is over, one
+// call at a time in the order of the records, so it should not block for long.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord)
+}
+
+// AuditSinkFunc adapts a function to an AuditSink.
+type AuditSinkFunc func(ctx context.Context, record AuditRecord)
+
+// Record calls f(ctx, record).
+func (f AuditSinkFunc) Record(ctx context.Context, record AuditRecord) {
+	f(ctx, record)
+}
+
+// auditLog chains the records of a client.
+type auditLog struct {
+	sink AuditSink
+
+	mu           sync.Mutex
+	sequence     uint64
+	previousHash string
+}
+
+// WithAuditSink makes the client report every request which may modify a drive, such as
+// uploading, deleting, moving or sharing items, to sink once it is over, whether it has
+// succeeded or not. The requests refused by WithReadOnly are reported too, but not those
+// recorded by WithDryRun, which are not sent. Each request of a JSON batch which may modify
+// a drive is reported on its own, with the status of its response within the batch.
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(c *Client) {
+		c.auditLog = &auditLog{sink: sink}
+	}
+}
+
+type auditActorKey struct{}
+
+// ContextWithAuditActor returns a copy of ctx making the requests sent with it be reported
+// to the AuditSink of the client with the given actor, e.g. the name of the user on behalf
+// of whom a server is acting.
+func ContextWithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// audit reports a request which may modify a drive to the AuditSink of the client, if any.
+func (c *Client) audit(ctx context.Context, req *http.Request, start time.Time, err error) {
+	if c.auditLog == nil || !isMutation(req) {
+		return
+	}
+
+	u := *req.URL
+	u.RawQuery, u.Fragment, u.User = "", "", nil
+	record := AuditRecord{
+		Time:     start,
+		Method:   req.Method,
+		URL:      u.String(),
+		Target:   auditTarget(req.URL.Path),
+		Duration: c.now().Sub(start),
+	}
+	record.Actor, _ = ctx.Value(auditActorKey{}).(string)
+	if err != nil {
+		record.Error = err.Error()
+		if oneDriveError, ok := err.(*Error); ok {
+			record.StatusCode = oneDriveError.StatusCode
+		}
+	}
+
+	l := c.auditLog
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sequence++
+	record.Sequence = l.sequence
+	record.PreviousHash = l.previousHash
+	record.Hash = auditHash(record)
+	l.previousHash = record.Hash
+
+	l.sink.Record(ctx, record)
+}
+
+// VerifyAuditChain reports whether the records, in the order they have been received by an
+// AuditSink, form an unbroken chain. The records must start with the first record of the
+// client or with the record following a record known to be genuine.
+func VerifyAuditChain(records []AuditRecord) bool {
+	for i, record := range records {
+		if auditHash(record) != record.Hash {
+			return false
+		}
+		if i > 0 && (record.PreviousHash != records[i-1].Hash || record.Sequence != records[i-1].Sequence+1) {
+			return false
+		}
+	}
+	return true
+}
+
+// auditHash returns the hash of a record, see AuditRecord.Hash.
+func auditHash(record AuditRecord) string {
+	record.Hash = ""
+	data, _ := json.Marshal(record)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// auditTarget returns the ID of the item, or its path from the root prefixed with "/",
+// addressed by the path of a request URL.
+func auditTarget(urlPath string) string {
+	if i := strings.Index(urlPath, "/items/"); i >= 0 {
+		target := urlPath[i+len("/items/"):]
+		if j := strings.IndexAny(target, "/:"); j >= 0 {
+			target = target[:j]
+		}
+		return target
+	}
+
+	if i := strings.Index(urlPath, "/root:"); i >= 0 {
+		target := urlPath[i+len("/root:"):]
+		if j := strings.Index(target, ":"); j >= 0 {
+			target = target[:j]
+		}
+		return target
+	}
+
+	return ""
+}
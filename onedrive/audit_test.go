@@ -0,0 +1,152 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithAuditSink(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	var records []AuditRecord
+	WithAuditSink(AuditSinkFunc(func(ctx context.Context, record AuditRecord) {
+		records = append(records, record)
+	}))(client)
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"1","name":"a.txt"}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/me/drive/items/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":"itemNotFound","message":"The resource could not be found."}}`)
+	})
+
+	ctx := ContextWithAuditActor(context.Background(), "alice@contoso.com")
+	if _, err := client.DriveItems.Get(ctx, "1"); err != nil {
+		t.Fatalf("DriveItems.Get returned error: %v", err)
+	}
+	if err := client.DriveItems.Delete(ctx, "", "1"); err != nil {
+		t.Fatalf("DriveItems.Delete returned error: %v", err)
+	}
+	if err := client.DriveItems.Delete(ctx, "", "2"); err == nil {
+		t.Fatal("DriveItems.Delete returned no error for a missing item")
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("The audit sink received %d records, want 2: %+v", len(records), records)
+	}
+
+	first, second := records[0], records[1]
+	if first.Sequence != 1 || first.Actor != "alice@contoso.com" || first.Method != "DELETE" || first.Target != "1" || first.Error != "" || first.PreviousHash != "" {
+		t.Errorf("The first record is %+v", first)
+	}
+	if second.Sequence != 2 || second.Target != "2" || second.StatusCode != http.StatusNotFound || second.Error == "" {
+		t.Errorf("The second record is %+v", second)
+	}
+
+	if !VerifyAuditChain(records) {
+		t.Error("VerifyAuditChain returned false for the genuine records")
+	}
+	records[0].Target = "3"
+	if VerifyAuditChain(records) {
+		t.Error("VerifyAuditChain returned true for an altered record")
+	}
+	if VerifyAuditChain([]AuditRecord{second, first}) {
+		t.Error("VerifyAuditChain returned true for reordered records")
+	}
+}
+
+func TestWithAuditSink_readOnly(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	var records []AuditRecord
+	WithReadOnly()(client)
+	WithAuditSink(AuditSinkFunc(func(ctx context.Context, record AuditRecord) {
+		records = append(records, record)
+	}))(client)
+
+	if err := client.DriveItems.Delete(context.Background(), "", "1"); err != ErrReadOnlyClient {
+		t.Fatalf("DriveItems.Delete returned %v, want %v", err, ErrReadOnlyClient)
+	}
+
+	if len(records) != 1 || records[0].Error != ErrReadOnlyClient.Error() {
+		t.Errorf("The audit sink received %+v, want the refused request", records)
+	}
+}
+
+func TestAuditTarget(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"/v1.0/me/drive/items/1", "1"},
+		{"/v1.0/me/drives/d/items/1/children", "1"},
+		{"/v1.0/me/drive/items/1:/a.txt:/content", "1"},
+		{"/v1.0/me/drive/root:/Documents/a.txt:/content", "/Documents/a.txt"},
+		{"/v1.0/$batch", ""},
+	}
+	for _, test := range tests {
+		if got := auditTarget(test.path); got != test.want {
+			t.Errorf("auditTarget(%q) = %q, want %q", test.path, got, test.want)
+		}
+	}
+}
+
+func TestWithAuditSink_batch(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	var records []AuditRecord
+	WithAuditSink(AuditSinkFunc(func(ctx context.Context, record AuditRecord) {
+		records = append(records, record)
+	}))(client)
+
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"responses":[
+			{"id":"0","status":200,"body":{}},
+			{"id":"1","status":404,"body":{"error":{"code":"itemNotFound","message":"Item not found"}}}
+		]}`)
+	})
+
+	if _, err := client.batch(context.Background(), []batchRequest{{Method: "GET", URL: "me/drive/items/1"}}); err != nil {
+		t.Fatalf("batch returned error: %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("The audit sink received %+v for a batch of reads", records)
+	}
+
+	if _, err := client.batch(context.Background(), []batchRequest{
+		{Method: "DELETE", URL: "me/drive/items/1"},
+		{Method: "PATCH", URL: "me/drive/items/2", Body: map[string]string{"name": "b.txt"}},
+	}); err != nil {
+		t.Fatalf("batch returned error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("The audit sink received %+v, want the requests of the batch", records)
+	}
+	if got := records[0]; got.Method != "DELETE" || got.Target != "1" || !strings.HasSuffix(got.URL, "/me/drive/items/1") || got.StatusCode != 0 {
+		t.Errorf("The first record is %+v, want the deletion of 1", got)
+	}
+	if got := records[1]; got.Method != "PATCH" || got.Target != "2" || got.StatusCode != http.StatusNotFound || got.Error == "" {
+		t.Errorf("The second record is %+v, want the failed update of 2", got)
+	}
+	if !VerifyAuditChain(records) {
+		t.Errorf("The records of the batch do not form a chain")
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package backup snapshots a local directory to a folder of the default drive of the
+// authenticated user, along with a manifest recording the path, hash, item ID and version
+// of every file:
+//
+//	result, err := backup.Run(ctx, client, "/home/user/documents", backup.Options{
+//		RemotePath:   "Backups/documents",
+//		ManifestPath: "/var/lib/backup/documents.json",
+//	})
+//
+// The manifest is saved locally after every uploaded file, so an interrupted backup resumes
+// where it stopped when it is run again with the same manifest.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// Options represents the options of a backup run.
+type Options struct {
+	// RemotePath is the path of the backup folder from the root of the default drive,
+	// e.g. "Backups/documents". Missing folders are created.
+	RemotePath string
+	// ManifestPath is the local file the manifest is checkpointed to. If it exists, the
+	// backup resumes from it.
+	ManifestPath string
+	// VerifyOnly makes the run upload nothing, but report the files which differ between
+	// the local directory, the manifest and OneDrive.
+	VerifyOnly bool
+	// Prune deletes from OneDrive, and from the manifest, the backed up files which no longer
+	// exist in the local directory. By default, they are kept in the backup.
+	Prune bool
+	// Hooks are called around the uploads and deletions of the run.
+	Hooks Hooks
+}
+
+// Result represents the outcome of a backup run.
+type Result struct {
+	Manifest *Manifest
+	// Uploaded are the paths of the files uploaded by the run.
+	Uploaded []string
+	// Skipped are the paths of the files which were already backed up.
+	Skipped []string
+	// Deleted are the paths of the files deleted from the backup by a run with Prune.
+	Deleted []string
+	// Mismatches are the differences found by a verify-only run.
+	Mismatches []Mismatch
+}
+
+// Mismatch represents a file which differs between the local directory, the manifest and
+// OneDrive.
+type Mismatch struct {
+	Path   string
+	Reason string
+}
+
+// Run backs up the files of a local directory to OneDrive. Files whose size and modification
+// time are unchanged since the manifest recorded them are skipped; the others are uploaded,
+// replacing their previous version.
+func Run(ctx context.Context, client *onedrive.Client, localDir string, opts Options) (*Result, error) {
+	if localDir == "" {
+		return nil, errors.New("Please provide the local directory to back up.")
+	}
+
+	if opts.RemotePath == "" || opts.ManifestPath == "" {
+		return nil, errors.New("Please provide the remote path of the backup and the local path of its manifest.")
+	}
+
+	manifest, err := LoadManifest(opts.ManifestPath)
+	if os.IsNotExist(err) {
+		manifest, err = &Manifest{RemotePath: opts.RemotePath}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.RemotePath != opts.RemotePath {
+		return nil, errors.New("The manifest belongs to a backup with another remote path.")
+	}
+
+	manifestPath, _ := filepath.Abs(opts.ManifestPath)
+	result := &Result{Manifest: manifest}
+	localPaths := make(map[string]bool)
+	err = filepath.Walk(localDir, func(localPath string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(localDir, localPath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		if absolutePath, _ := filepath.Abs(localPath); absolutePath == manifestPath || relativePath == ManifestName {
+			return nil
+		}
+		localPaths[relativePath] = true
+
+		file := manifest.File(relativePath)
+		if opts.VerifyOnly {
+			if mismatch := verifyLocal(localPath, relativePath, fileInfo, file); mismatch != nil {
+				result.Mismatches = append(result.Mismatches, *mismatch)
+			}
+			return nil
+		}
+
+		if file != nil && file.Size == fileInfo.Size() && file.LastModifiedDateTime.Equal(fileInfo.ModTime()) {
+			result.Skipped = append(result.Skipped, relativePath)
+			return nil
+		}
+
+		if err := opts.Hooks.beforeUpload(relativePath); err == ErrSkip {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		file, err = upload(ctx, client, localPath, relativePath, fileInfo, opts.RemotePath)
+		if err != nil {
+			return opts.Hooks.onError(relativePath, err)
+		}
+
+		manifest.setFile(file)
+		result.Uploaded = append(result.Uploaded, relativePath)
+		opts.Hooks.onItemUploaded(file)
+
+		return manifest.Save(opts.ManifestPath)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if opts.VerifyOnly {
+		mismatches, err := verifyRemote(ctx, client, manifest)
+		result.Mismatches = append(result.Mismatches, mismatches...)
+		return result, err
+	}
+
+	if opts.Prune {
+		if err := prune(ctx, client, manifest, opts.ManifestPath, localPaths, opts.Hooks, result); err != nil {
+			return result, err
+		}
+	}
+
+	manifest.CompletedDateTime = time.Now().UTC()
+	if err := manifest.Save(opts.ManifestPath); err != nil {
+		return result, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return result, err
+	}
+
+	_, err = client.DriveItems.UploadNewFileToPath(ctx, path.Join(opts.RemotePath, ManifestName), bytes.NewReader(data), onedrive.UploadNewFileToPathOpts{
+		ContentType:      "application/json",
+		ConflictBehavior: "replace",
+		CreateParents:    true,
+	})
+
+	return result, err
+}
+
+// upload uploads a local file to the backup folder, and returns its manifest entry.
+func upload(ctx context.Context, client *onedrive.Client, localPath string, relativePath string, fileInfo os.FileInfo, remotePath string) (*File, error) {
+	hash, err := onedrive.QuickXorHashFile(localPath)
+	if err != nil {
+		return nil, err
+	}
+
+	localFile, err := os.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer localFile.Close()
+
+	item, err := client.DriveItems.UploadNewFileToPath(ctx, path.Join(remotePath, relativePath), localFile, onedrive.UploadNewFileToPathOpts{
+		ConflictBehavior: "replace",
+		CreateParents:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if item.File != nil && item.File.Hashes != nil && item.File.Hashes.QuickXorHash != "" && item.File.Hashes.QuickXorHash != hash {
+		return nil, errors.New("The uploaded content of " + relativePath + " does not match the local file.")
+	}
+
+	return &File{
+		Path:                 relativePath,
+		Size:                 fileInfo.Size(),
+		LastModifiedDateTime: fileInfo.ModTime(),
+		QuickXorHash:         hash,
+		ItemId:               item.Id,
+		ETag:                 item.ETag,
+	}, nil
+}
+
+// prune deletes the files of the manifest which are not in localPaths from OneDrive and from
+// the manifest, which is saved after every deleted file.
+func prune(ctx context.Context, client *onedrive.Client, manifest *Manifest, manifestPath string, localPaths map[string]bool, hooks Hooks, result *Result) error {
+	var deleted []*File
+	for _, file := range manifest.Files {
+		if !localPaths[file.Path] {
+			deleted = append(deleted, file)
+		}
+	}
+
+	for _, file := range deleted {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := hooks.beforeDelete(file); err == ErrSkip {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// A file already missing on OneDrive only has to be removed from the manifest.
+		err := client.DriveItems.Delete(ctx, "", file.ItemId)
+		if oneDriveError, ok := err.(*onedrive.Error); ok && oneDriveError.StatusCode == 404 {
+			err = nil
+		}
+		if err != nil {
+			if err := hooks.onError(file.Path, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		manifest.removeFile(file.Path)
+		result.Deleted = append(result.Deleted, file.Path)
+		hooks.onItemDeleted(file)
+
+		if err := manifest.Save(manifestPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyLocal compares a local file with its manifest entry.
+func verifyLocal(localPath string, relativePath string, fileInfo os.FileInfo, file *File) *Mismatch {
+	if file == nil {
+		return &Mismatch{Path: relativePath, Reason: "not backed up"}
+	}
+
+	if file.Size != fileInfo.Size() {
+		return &Mismatch{Path: relativePath, Reason: "local size differs from the manifest"}
+	}
+
+	hash, err := onedrive.QuickXorHashFile(localPath)
+	if err != nil {
+		return &Mismatch{Path: relativePath, Reason: err.Error()}
+	}
+
+	if hash != file.QuickXorHash {
+		return &Mismatch{Path: relativePath, Reason: "local content differs from the manifest"}
+	}
+
+	return nil
+}
+
+// verifyRemote compares the items on OneDrive with their manifest entries.
+func verifyRemote(ctx context.Context, client *onedrive.Client, manifest *Manifest) ([]Mismatch, error) {
+	var mismatches []Mismatch
+	for _, file := range manifest.Files {
+		item, err := client.DriveItems.Get(ctx, file.ItemId)
+		if oneDriveError, ok := err.(*onedrive.Error); ok && oneDriveError.StatusCode == 404 {
+			mismatches = append(mismatches, Mismatch{Path: file.Path, Reason: "missing on OneDrive"})
+			continue
+		}
+		if err != nil {
+			return mismatches, err
+		}
+
+		if item.Size != file.Size || item.File == nil || item.File.Hashes == nil || item.File.Hashes.QuickXorHash != file.QuickXorHash {
+			mismatches = append(mismatches, Mismatch{Path: file.Path, Reason: "OneDrive content differs from the manifest"})
+		}
+	}
+
+	return mismatches, nil
+}
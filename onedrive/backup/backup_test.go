@@ -0,0 +1,203 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// fakeDrive is a test server storing the files uploaded by path to the default drive.
+type fakeDrive struct {
+	mu      sync.Mutex
+	items   map[string]*onedrive.DriveItem // By ID.
+	content map[string][]byte              // By ID.
+	uploads []string
+
+	serverURL string
+}
+
+func (d *fakeDrive) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/me/drive/root:/") && strings.HasSuffix(r.URL.Path, ":/content"):
+		itemPath := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/me/drive/root:/"), ":/content")
+		data, _ := ioutil.ReadAll(r.Body)
+
+		h := onedrive.NewQuickXorHash()
+		h.Write(data)
+		item := &onedrive.DriveItem{
+			Id:   "id-" + itemPath,
+			Name: filepath.Base(itemPath),
+			Size: int64(len(data)),
+			File: &onedrive.DriveItemFile{Hashes: &onedrive.DriveItemHashes{QuickXorHash: base64.StdEncoding.EncodeToString(h.Sum(nil))}},
+		}
+		d.items[item.Id] = item
+		d.content[item.Id] = data
+		d.uploads = append(d.uploads, itemPath)
+
+		json.NewEncoder(w).Encode(item)
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/me/drive/items/"):
+		item, ok := d.items[strings.TrimPrefix(r.URL.Path, "/me/drive/items/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": "itemNotFound", "message": "Item not found"}}`))
+			return
+		}
+
+		withDownloadURL := *item
+		withDownloadURL.DownloadURL = d.serverURL + "/download/" + url.PathEscape(item.Id)
+		json.NewEncoder(w).Encode(withDownloadURL)
+	case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/me/drive/items/"):
+		itemId := strings.TrimPrefix(r.URL.Path, "/me/drive/items/")
+		if _, ok := d.items[itemId]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": "itemNotFound", "message": "Item not found"}}`))
+			return
+		}
+
+		delete(d.items, itemId)
+		delete(d.content, itemId)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/download/"):
+		w.Write(d.content[strings.TrimPrefix(r.URL.Path, "/download/")])
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusBadRequest)
+	}
+}
+
+func setup(t *testing.T) (*onedrive.Client, *fakeDrive, string, func()) {
+	drive := &fakeDrive{items: map[string]*onedrive.DriveItem{}, content: map[string][]byte{}}
+	server := httptest.NewServer(drive)
+	drive.serverURL = server.URL
+
+	client := onedrive.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	localDir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatalf("Cannot create the local directory: %v", err)
+	}
+
+	return client, drive, localDir, func() {
+		server.Close()
+		os.RemoveAll(localDir)
+	}
+}
+
+func writeFile(t *testing.T, filePath string, content string) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		t.Fatalf("Cannot create the directory of %v: %v", filePath, err)
+	}
+	if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Cannot write %v: %v", filePath, err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	client, drive, localDir, teardown := setup(t)
+
+	defer teardown()
+
+	sourceDir := filepath.Join(localDir, "source")
+	writeFile(t, filepath.Join(sourceDir, "a.txt"), "first file")
+	writeFile(t, filepath.Join(sourceDir, "sub", "b.txt"), "second file")
+
+	ctx := context.Background()
+	opts := Options{RemotePath: "Backups/source", ManifestPath: filepath.Join(localDir, "manifest.json")}
+	result, err := Run(ctx, client, sourceDir, opts)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if want := []string{"a.txt", "sub/b.txt"}; !reflect.DeepEqual(result.Uploaded, want) {
+		t.Errorf("Run uploaded %v, want %v", result.Uploaded, want)
+	}
+
+	manifest, err := LoadManifest(opts.ManifestPath)
+	if err != nil {
+		t.Fatalf("LoadManifest returned error: %v", err)
+	}
+	if len(manifest.Files) != 2 || manifest.Files[1].ItemId != "id-Backups/source/sub/b.txt" || manifest.CompletedDateTime.IsZero() {
+		t.Errorf("Manifest: %+v, want two files and a completion time", manifest)
+	}
+	if !bytes.Contains(drive.content["id-Backups/source/"+ManifestName], []byte(`"sub/b.txt"`)) {
+		t.Errorf("Manifest uploaded to OneDrive does not list the files")
+	}
+
+	// A second run only uploads the changed file.
+	writeFile(t, filepath.Join(sourceDir, "a.txt"), "first file, changed")
+	drive.uploads = nil
+	result, err = Run(ctx, client, sourceDir, opts)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if want := []string{"a.txt"}; !reflect.DeepEqual(result.Uploaded, want) {
+		t.Errorf("Run uploaded %v, want %v", result.Uploaded, want)
+	}
+	if want := []string{"sub/b.txt"}; !reflect.DeepEqual(result.Skipped, want) {
+		t.Errorf("Run skipped %v, want %v", result.Skipped, want)
+	}
+	if want := []string{"Backups/source/a.txt", "Backups/source/" + ManifestName}; !reflect.DeepEqual(drive.uploads, want) {
+		t.Errorf("OneDrive received %v, want %v", drive.uploads, want)
+	}
+}
+
+func TestRun_verifyOnly(t *testing.T) {
+	client, drive, localDir, teardown := setup(t)
+
+	defer teardown()
+
+	sourceDir := filepath.Join(localDir, "source")
+	writeFile(t, filepath.Join(sourceDir, "a.txt"), "first file")
+	writeFile(t, filepath.Join(sourceDir, "b.txt"), "second file")
+	writeFile(t, filepath.Join(sourceDir, "c.txt"), "third file")
+
+	ctx := context.Background()
+	opts := Options{RemotePath: "Backups", ManifestPath: filepath.Join(localDir, "manifest.json")}
+	if _, err := Run(ctx, client, sourceDir, opts); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	writeFile(t, filepath.Join(sourceDir, "a.txt"), "first file, changed")
+	writeFile(t, filepath.Join(sourceDir, "d.txt"), "fourth file")
+	delete(drive.items, "id-Backups/c.txt")
+	drive.uploads = nil
+
+	opts.VerifyOnly = true
+	result, err := Run(ctx, client, sourceDir, opts)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	want := []Mismatch{
+		{Path: "a.txt", Reason: "local size differs from the manifest"},
+		{Path: "d.txt", Reason: "not backed up"},
+		{Path: "c.txt", Reason: "missing on OneDrive"},
+	}
+	if !reflect.DeepEqual(result.Mismatches, want) {
+		t.Errorf("Run found mismatches %+v, want %+v", result.Mismatches, want)
+	}
+	if len(drive.uploads) != 0 {
+		t.Errorf("Verify-only run uploaded %v", drive.uploads)
+	}
+}
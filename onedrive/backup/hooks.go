@@ -0,0 +1,103 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package backup
+
+import "errors"
+
+// ErrSkip is returned by BeforeUpload, BeforeDownload and BeforeDelete hooks to veto a
+// single action, without aborting the run.
+var ErrSkip = errors.New("skip")
+
+// ConflictAction represents what Restore does with a local file which already exists with
+// a content different from the backup.
+type ConflictAction int
+
+const (
+	// Overwrite replaces the local file by the backed up one.
+	Overwrite ConflictAction = iota
+	// KeepLocal leaves the local file untouched.
+	KeepLocal
+)
+
+// Hooks lets applications log, meter or veto the actions of backup and restore runs.
+// Every hook is optional.
+type Hooks struct {
+	// BeforeUpload is called before a file is uploaded by Run. Returning ErrSkip skips the
+	// file; any other error aborts the run.
+	BeforeUpload func(path string) error
+	// OnItemUploaded is called after a file has been uploaded by Run.
+	OnItemUploaded func(file *File)
+	// BeforeDelete is called before a file removed from the local directory is deleted from
+	// the backup by Run, see Options.Prune. Returning ErrSkip keeps the file; any other error
+	// aborts the run.
+	BeforeDelete func(file *File) error
+	// OnItemDeleted is called after a file has been deleted from the backup by Run.
+	OnItemDeleted func(file *File)
+	// BeforeDownload is called before a file is downloaded by Restore. Returning ErrSkip
+	// skips the file; any other error aborts the restore.
+	BeforeDownload func(file *File) error
+	// OnItemDownloaded is called after a file has been restored by Restore.
+	OnItemDownloaded func(file *File, localPath string)
+	// OnConflict is called by Restore when the local file exists with a content different
+	// from the backup. By default, the local file is overwritten.
+	OnConflict func(file *File, localPath string) ConflictAction
+	// OnError is called when uploading, deleting or restoring a file fails. Returning nil skips the
+	// file and goes on with the run; returning an error aborts the run. By default, the
+	// run is aborted with the error of the file.
+	OnError func(path string, err error) error
+}
+
+func (h *Hooks) beforeUpload(path string) error {
+	if h.BeforeUpload == nil {
+		return nil
+	}
+	return h.BeforeUpload(path)
+}
+
+func (h *Hooks) onItemUploaded(file *File) {
+	if h.OnItemUploaded != nil {
+		h.OnItemUploaded(file)
+	}
+}
+
+func (h *Hooks) beforeDelete(file *File) error {
+	if h.BeforeDelete == nil {
+		return nil
+	}
+	return h.BeforeDelete(file)
+}
+
+func (h *Hooks) onItemDeleted(file *File) {
+	if h.OnItemDeleted != nil {
+		h.OnItemDeleted(file)
+	}
+}
+
+func (h *Hooks) beforeDownload(file *File) error {
+	if h.BeforeDownload == nil {
+		return nil
+	}
+	return h.BeforeDownload(file)
+}
+
+func (h *Hooks) onItemDownloaded(file *File, localPath string) {
+	if h.OnItemDownloaded != nil {
+		h.OnItemDownloaded(file, localPath)
+	}
+}
+
+func (h *Hooks) onConflict(file *File, localPath string) ConflictAction {
+	if h.OnConflict == nil {
+		return Overwrite
+	}
+	return h.OnConflict(file, localPath)
+}
+
+func (h *Hooks) onError(path string, err error) error {
+	if h.OnError == nil {
+		return err
+	}
+	return h.OnError(path, err)
+}
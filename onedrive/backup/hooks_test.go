@@ -0,0 +1,117 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	client, _, localDir, teardown := setup(t)
+
+	defer teardown()
+
+	sourceDir := filepath.Join(localDir, "source")
+	writeFile(t, filepath.Join(sourceDir, "a.txt"), "first file")
+	writeFile(t, filepath.Join(sourceDir, "b.txt"), "second file")
+	writeFile(t, filepath.Join(sourceDir, "secret.txt"), "vetoed file")
+
+	var events []string
+	hooks := Hooks{
+		BeforeUpload: func(path string) error {
+			if path == "secret.txt" {
+				return ErrSkip
+			}
+			return nil
+		},
+		OnItemUploaded: func(file *File) {
+			events = append(events, "uploaded "+file.Path)
+		},
+		OnItemDownloaded: func(file *File, localPath string) {
+			events = append(events, "downloaded "+file.Path)
+		},
+		OnConflict: func(file *File, localPath string) ConflictAction {
+			events = append(events, "conflict "+file.Path)
+			return KeepLocal
+		},
+	}
+
+	ctx := context.Background()
+	result, err := Run(ctx, client, sourceDir, Options{RemotePath: "Backups", ManifestPath: filepath.Join(localDir, "manifest.json"), Hooks: hooks})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	restoreDir := filepath.Join(localDir, "restore")
+	writeFile(t, filepath.Join(restoreDir, "a.txt"), "local change")
+	if _, err := Restore(ctx, client, result.Manifest, restoreDir, RestoreOptions{Hooks: hooks}); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+
+	want := []string{"uploaded a.txt", "uploaded b.txt", "conflict a.txt", "downloaded b.txt"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Hooks received %v, want %v", events, want)
+	}
+
+	if data, _ := ioutil.ReadFile(filepath.Join(restoreDir, "a.txt")); string(data) != "local change" {
+		t.Errorf("Conflicting local file was overwritten with %q", data)
+	}
+}
+
+func TestHooks_prune(t *testing.T) {
+	client, drive, localDir, teardown := setup(t)
+
+	defer teardown()
+
+	sourceDir := filepath.Join(localDir, "source")
+	writeFile(t, filepath.Join(sourceDir, "a.txt"), "first file")
+	writeFile(t, filepath.Join(sourceDir, "b.txt"), "second file")
+	writeFile(t, filepath.Join(sourceDir, "kept.txt"), "vetoed deletion")
+
+	ctx := context.Background()
+	opts := Options{RemotePath: "Backups", ManifestPath: filepath.Join(localDir, "manifest.json")}
+	if _, err := Run(ctx, client, sourceDir, opts); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	os.Remove(filepath.Join(sourceDir, "b.txt"))
+	os.Remove(filepath.Join(sourceDir, "kept.txt"))
+
+	var events []string
+	opts.Prune = true
+	opts.Hooks = Hooks{
+		BeforeDelete: func(file *File) error {
+			if file.Path == "kept.txt" {
+				return ErrSkip
+			}
+			return nil
+		},
+		OnItemDeleted: func(file *File) {
+			events = append(events, "deleted "+file.Path)
+		},
+	}
+	result, err := Run(ctx, client, sourceDir, opts)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if want := []string{"deleted b.txt"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("Hooks received %v, want %v", events, want)
+	}
+	if want := []string{"b.txt"}; !reflect.DeepEqual(result.Deleted, want) {
+		t.Errorf("Run deleted %v, want %v", result.Deleted, want)
+	}
+	if _, ok := drive.items["id-Backups/b.txt"]; ok {
+		t.Errorf("The deleted file is still on OneDrive")
+	}
+	if result.Manifest.File("b.txt") != nil || result.Manifest.File("kept.txt") == nil {
+		t.Errorf("Manifest has files %+v, want the deleted file removed and the vetoed one kept", result.Manifest.Files)
+	}
+}
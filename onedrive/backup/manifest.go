@@ -0,0 +1,157 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ManifestName is the name of the manifest uploaded next to the backed up files.
+const ManifestName = "backup-manifest.json"
+
+// Manifest describes the files of a backup. It is saved locally after every file as a
+// checkpoint, and uploaded with the backup once it completes.
+type Manifest struct {
+	// RemotePath is the path of the backup folder from the root of the default drive.
+	RemotePath string `json:"remotePath"`
+	// CompletedDateTime is the time the last backup run completed. It is zero while the
+	// first run has not completed.
+	CompletedDateTime time.Time `json:"completedDateTime"`
+	// Files are sorted by path when the manifest is saved.
+	Files []*File `json:"files"`
+
+	index    map[string]int // Positions of Files by path, see indexOf.
+	unsorted bool           // Whether Files has to be sorted before it is saved.
+}
+
+// File describes a file of a backup.
+type File struct {
+	// Path is the slash-separated path of the file relative to the backed up directory.
+	Path                 string    `json:"path"`
+	Size                 int64     `json:"size"`
+	LastModifiedDateTime time.Time `json:"lastModifiedDateTime"`
+	QuickXorHash         string    `json:"quickXorHash"`
+	ItemId               string    `json:"itemId"`
+	// ETag identifies the version of the item on OneDrive.
+	ETag string `json:"eTag"`
+}
+
+// LoadManifest reads a manifest saved by Save.
+func LoadManifest(manifestPath string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	if manifest.RemotePath == "" {
+		return nil, errors.New("The manifest has no remote path.")
+	}
+	for _, file := range manifest.Files {
+		if file == nil || file.Path == "" {
+			return nil, errors.New("The manifest has a file without path.")
+		}
+	}
+
+	return &manifest, nil
+}
+
+// Save writes the manifest to a local file. The file is replaced atomically, so an
+// interrupted save never leaves a corrupted manifest behind.
+func (m *Manifest) Save(manifestPath string) error {
+	if m.unsorted {
+		sort.Slice(m.Files, func(i, j int) bool {
+			return m.Files[i].Path < m.Files[j].Path
+		})
+		m.index, m.unsorted = nil, false
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(manifestPath), filepath.Base(manifestPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), manifestPath)
+}
+
+// File returns the file of the manifest with the given path, or nil if there is none.
+func (m *Manifest) File(filePath string) *File {
+	if i := m.indexOf(filePath); i >= 0 {
+		return m.Files[i]
+	}
+
+	return nil
+}
+
+// setFile adds a file to the manifest, or replaces the one with the same path.
+func (m *Manifest) setFile(file *File) {
+	if i := m.indexOf(file.Path); i >= 0 {
+		m.Files[i] = file
+		return
+	}
+
+	if n := len(m.Files); n > 0 && m.Files[n-1].Path > file.Path {
+		m.unsorted = true
+	}
+	m.index[file.Path] = len(m.Files)
+	m.Files = append(m.Files, file)
+}
+
+// removeFile removes the file with the given path from the manifest, if any.
+func (m *Manifest) removeFile(filePath string) {
+	i := m.indexOf(filePath)
+	if i < 0 {
+		return
+	}
+
+	m.Files = append(m.Files[:i], m.Files[i+1:]...)
+	m.index = nil
+}
+
+// indexOf returns the position of the file with the given path in Files, or -1 if there is
+// none. The index is rebuilt when Files has been changed without setFile.
+func (m *Manifest) indexOf(filePath string) int {
+	i, ok := m.index[filePath]
+	if ok && i < len(m.Files) && m.Files[i].Path == filePath {
+		return i
+	}
+	if !ok && m.index != nil && len(m.index) == len(m.Files) {
+		return -1
+	}
+
+	m.index = make(map[string]int, len(m.Files))
+	for i, file := range m.Files {
+		m.index[file.Path] = i
+	}
+
+	if i, ok := m.index[filePath]; ok {
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadManifest_invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, content := range []string{
+		`null`,
+		`{}`,
+		`{"remotePath": "Backups", "files": [null]}`,
+		`{"remotePath": "Backups", "files": [{"size": 1}]}`,
+	} {
+		manifestPath := filepath.Join(dir, "manifest.json")
+		writeFile(t, manifestPath, content)
+
+		if manifest, err := LoadManifest(manifestPath); err == nil {
+			t.Errorf("LoadManifest(%s) returned %+v, want an error", content, manifest)
+		}
+	}
+}
+
+func TestManifest_setFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := &Manifest{RemotePath: "Backups"}
+	for _, filePath := range []string{"b.txt", "a/c.txt", "a.txt", "b.txt"} {
+		manifest.setFile(&File{Path: filePath, ItemId: "id-" + filePath})
+	}
+
+	if file := manifest.File("a/c.txt"); file == nil || file.ItemId != "id-a/c.txt" {
+		t.Errorf("Manifest.File returned %+v", file)
+	}
+	if file := manifest.File("missing.txt"); file != nil {
+		t.Errorf("Manifest.File returned %+v for a missing file", file)
+	}
+
+	manifestPath := filepath.Join(dir, "manifest.json")
+	if err := manifest.Save(manifestPath); err != nil {
+		t.Fatalf("Manifest.Save returned error: %v", err)
+	}
+
+	loaded, err := LoadManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("LoadManifest returned error: %v", err)
+	}
+
+	var got []string
+	for _, file := range loaded.Files {
+		got = append(got, file.Path)
+	}
+	if want := []string{"a.txt", "a/c.txt", "b.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Saved manifest has files %v, want %v", got, want)
+	}
+	if file := manifest.File("b.txt"); file == nil || file.Path != "b.txt" {
+		t.Errorf("Manifest.File returned %+v after the files were sorted", file)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// RestoreOptions represents the options of a restore.
+type RestoreOptions struct {
+	// PathPrefix restricts the restore to the files under a slash-separated path of the
+	// backed up directory, e.g. "photos/2020". By default, every file is restored.
+	PathPrefix string
+	// Hooks are called around the downloads of the restore.
+	Hooks Hooks
+}
+
+// RestoreResult represents the outcome of a restore.
+type RestoreResult struct {
+	// Restored are the paths of the files restored.
+	Restored []string
+	// Mismatches are the files which could not be restored because their content on
+	// OneDrive differs from the manifest.
+	Mismatches []Mismatch
+}
+
+// Restore downloads the files of a backup described by manifest into a local directory,
+// recreating the directory structure and the modification times. Every file is checked
+// against the hash recorded in the manifest before it is written in place.
+func Restore(ctx context.Context, client *onedrive.Client, manifest *Manifest, localDir string, opts RestoreOptions) (*RestoreResult, error) {
+	if manifest == nil {
+		return nil, errors.New("Please provide the manifest of the backup.")
+	}
+
+	if localDir == "" {
+		return nil, errors.New("Please provide the local directory to restore to.")
+	}
+
+	prefix := strings.Trim(opts.PathPrefix, "/")
+	result := &RestoreResult{}
+	for _, file := range manifest.Files {
+		if prefix != "" && file.Path != prefix && !strings.HasPrefix(file.Path, prefix+"/") {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// The path is checked before anything is done with the local file, as the manifest
+		// may come from an untrusted backup.
+		localPath, err := restorePath(localDir, file.Path)
+		if err != nil {
+			if err := opts.Hooks.onError(file.Path, err); err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		if err := opts.Hooks.beforeDownload(file); err == ErrSkip {
+			continue
+		} else if err != nil {
+			return result, err
+		}
+
+		if conflict, err := isConflict(file, localPath); err != nil {
+			return result, err
+		} else if conflict && opts.Hooks.onConflict(file, localPath) == KeepLocal {
+			continue
+		}
+
+		mismatch, err := restoreFile(ctx, client, file, localPath)
+		if err != nil {
+			if err := opts.Hooks.onError(file.Path, err); err != nil {
+				return result, err
+			}
+			continue
+		}
+		if mismatch != nil {
+			result.Mismatches = append(result.Mismatches, *mismatch)
+			continue
+		}
+
+		result.Restored = append(result.Restored, file.Path)
+		opts.Hooks.onItemDownloaded(file, localPath)
+	}
+
+	return result, nil
+}
+
+// isConflict reports whether the local file exists with a content different from the backup.
+func isConflict(file *File, localPath string) (bool, error) {
+	fileInfo, err := os.Stat(localPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if fileInfo.Size() != file.Size {
+		return true, nil
+	}
+
+	hash, err := onedrive.QuickXorHashFile(localPath)
+	if err != nil {
+		return false, err
+	}
+
+	return hash != file.QuickXorHash, nil
+}
+
+// restorePath returns the local path a file of a backup is restored to, or an error if it
+// is not inside the local directory, e.g. "../.bashrc".
+func restorePath(localDir string, filePath string) (string, error) {
+	localPath := filepath.Join(localDir, filepath.FromSlash(filePath))
+
+	relativePath, err := filepath.Rel(localDir, localPath)
+	if err != nil || relativePath == "." || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", errors.New("The manifest path " + filePath + " is outside of the local directory.")
+	}
+
+	return localPath, nil
+}
+
+// restoreFile downloads a file of a backup to its local path.
+func restoreFile(ctx context.Context, client *onedrive.Client, file *File, localPath string) (*Mismatch, error) {
+	data, err := client.DriveItems.DownloadItem(ctx, &onedrive.DriveItem{Id: file.ItemId})
+	if oneDriveError, ok := err.(*onedrive.Error); ok && oneDriveError.StatusCode == 404 {
+		return &Mismatch{Path: file.Path, Reason: "missing on OneDrive"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h := onedrive.NewQuickXorHash()
+	h.Write(data)
+	if int64(len(data)) != file.Size || base64.StdEncoding.EncodeToString(h.Sum(nil)) != file.QuickXorHash {
+		return &Mismatch{Path: file.Path, Reason: "OneDrive content differs from the manifest"}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return nil, err
+	}
+
+	// Write to a temporary file first, so that an existing file is never left half-written.
+	tempFile, err := ioutil.TempFile(filepath.Dir(localPath), filepath.Base(localPath)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return nil, err
+	}
+	if err := tempFile.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(tempFile.Name(), 0644); err != nil {
+		return nil, err
+	}
+
+	if err := os.Chtimes(tempFile.Name(), file.LastModifiedDateTime, file.LastModifiedDateTime); err != nil {
+		return nil, err
+	}
+
+	return nil, os.Rename(tempFile.Name(), localPath)
+}
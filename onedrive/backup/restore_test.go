@@ -0,0 +1,138 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRestore(t *testing.T) {
+	client, drive, localDir, teardown := setup(t)
+
+	defer teardown()
+
+	sourceDir := filepath.Join(localDir, "source")
+	writeFile(t, filepath.Join(sourceDir, "a.txt"), "first file")
+	writeFile(t, filepath.Join(sourceDir, "photos", "b.jpg"), "second file")
+	writeFile(t, filepath.Join(sourceDir, "photos", "c.jpg"), "third file")
+
+	modTime := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	os.Chtimes(filepath.Join(sourceDir, "photos", "b.jpg"), modTime, modTime)
+
+	ctx := context.Background()
+	result, err := Run(ctx, client, sourceDir, Options{RemotePath: "Backups", ManifestPath: filepath.Join(localDir, "manifest.json")})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	drive.content["id-Backups/photos/c.jpg"] = []byte("corrupted!")
+
+	restoreDir := filepath.Join(localDir, "restore")
+	restoreResult, err := Restore(ctx, client, result.Manifest, restoreDir, RestoreOptions{PathPrefix: "photos/"})
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+
+	if want := []string{"photos/b.jpg"}; !reflect.DeepEqual(restoreResult.Restored, want) {
+		t.Errorf("Restore restored %v, want %v", restoreResult.Restored, want)
+	}
+	if want := []Mismatch{{Path: "photos/c.jpg", Reason: "OneDrive content differs from the manifest"}}; !reflect.DeepEqual(restoreResult.Mismatches, want) {
+		t.Errorf("Restore found mismatches %+v, want %+v", restoreResult.Mismatches, want)
+	}
+
+	restoredPath := filepath.Join(restoreDir, "photos", "b.jpg")
+	if data, err := ioutil.ReadFile(restoredPath); err != nil || string(data) != "second file" {
+		t.Errorf("Restored content: %q (%v), want %q", data, err, "second file")
+	}
+	if fileInfo, err := os.Stat(restoredPath); err != nil || !fileInfo.ModTime().Equal(modTime) {
+		t.Errorf("Restored modification time: %v (%v), want %v", fileInfo.ModTime(), err, modTime)
+	}
+	for _, notRestored := range []string{"a.txt", filepath.Join("photos", "c.jpg")} {
+		if _, err := os.Stat(filepath.Join(restoreDir, notRestored)); !os.IsNotExist(err) {
+			t.Errorf("%v was restored, want it skipped", notRestored)
+		}
+	}
+}
+
+func TestRestore_currentDirectory(t *testing.T) {
+	client, _, localDir, teardown := setup(t)
+
+	defer teardown()
+
+	sourceDir := filepath.Join(localDir, "source")
+	writeFile(t, filepath.Join(sourceDir, "photos", "a.jpg"), "photo")
+
+	ctx := context.Background()
+	result, err := Run(ctx, client, sourceDir, Options{RemotePath: "Backups", ManifestPath: filepath.Join(localDir, "manifest.json")})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	restoreDir := filepath.Join(localDir, "restore")
+	if err := os.Mkdir(restoreDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(restoreDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(workingDir)
+
+	restoreResult, err := Restore(ctx, client, result.Manifest, ".", RestoreOptions{})
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if want := []string{"photos/a.jpg"}; !reflect.DeepEqual(restoreResult.Restored, want) {
+		t.Errorf("Restore restored %v, want %v", restoreResult.Restored, want)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(restoreDir, "photos", "a.jpg")); err != nil || string(data) != "photo" {
+		t.Errorf("Restored content: %q (%v), want %q", data, err, "photo")
+	}
+}
+
+func TestRestore_pathOutsideDirectory(t *testing.T) {
+	client, _, localDir, teardown := setup(t)
+
+	defer teardown()
+
+	restoreDir := filepath.Join(localDir, "restore")
+	outsidePath := filepath.Join(localDir, "outside.txt")
+	writeFile(t, outsidePath, "not to be touched")
+
+	manifest := &Manifest{RemotePath: "Backups", Files: []*File{{Path: "../outside.txt", Size: 1, ItemId: "evil"}}}
+	var errs []error
+	_, err := Restore(context.Background(), client, manifest, restoreDir, RestoreOptions{Hooks: Hooks{
+		BeforeDownload: func(file *File) error {
+			t.Errorf("BeforeDownload was called for %v", file.Path)
+			return nil
+		},
+		OnConflict: func(file *File, localPath string) ConflictAction {
+			t.Errorf("OnConflict was called for %v", localPath)
+			return KeepLocal
+		},
+		OnError: func(path string, err error) error {
+			errs = append(errs, err)
+			return nil
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("OnError was called with %v, want an error for the path outside of the directory", errs)
+	}
+	if data, _ := ioutil.ReadFile(outsidePath); string(data) != "not to be touched" {
+		t.Errorf("The file outside of the directory was changed to %q", data)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxBatchSize is the maximum number of requests combined into a single JSON batch.
+const maxBatchSize = 20
+
+// maxBatchRetries is the number of times a request of a JSON batch throttled by OneDrive is
+// sent again in another batch.
+const maxBatchRetries = 3
+
+// batchRequest represents one of the requests combined into a JSON batch. URL is relative
+// to the BaseURL of the Client, like the relative URLs given to NewRequest.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/json-batching
+type batchRequest struct {
+	Id      string            `json:"id"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Body    interface{}       `json:"body,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// batchResponse represents the response to one of the requests combined into a JSON batch.
+type batchResponse struct {
+	Id      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// decode stores the body of the response in the value pointed to by target, or returns
+// the error returned by the API for this request.
+func (r *batchResponse) decode(target interface{}) error {
+	if r.Status == 0 {
+		return errors.New("The batch has no response to the request.")
+	}
+
+	if r.Status >= 400 {
+		var oneDriveError *ErrorResponse
+		json.Unmarshal(r.Body, &oneDriveError)
+		if oneDriveError == nil || oneDriveError.Error == nil {
+			return &Error{StatusCode: r.Status, Code: http.StatusText(r.Status)}
+		}
+		oneDriveError.Error.StatusCode = r.Status
+		return oneDriveError.Error
+	}
+
+	if target == nil || len(r.Body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(r.Body, target)
+}
+
+// batch sends the requests combined into as few JSON batches as possible, and returns the
+// responses in the same order as the requests. The IDs of the requests are assigned here.
+//
+// Like Do, requests which would modify a drive are refused by a read-only client and only
+// recorded by a client in dry-run mode.
+//
+// The requests throttled by OneDrive (429 Too Many Requests and 503 Service Unavailable)
+// are sent again in another batch, up to maxBatchRetries times, once the longest of their
+// Retry-After delays has passed. The throttling is also recorded for their drives, see
+// WithThrottlingBackoff.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/json-batching
+func (c *Client) batch(ctx context.Context, requests []batchRequest) ([]batchResponse, error) {
+	responses := make([]batchResponse, len(requests))
+
+	var pending []int
+	for i := range requests {
+		requests[i].Id = strconv.Itoa(i)
+
+		if requests[i].Method == "GET" {
+			pending = append(pending, i)
+			continue
+		}
+
+		if c.readOnly {
+			if req, err := c.NewRequest(requests[i].Method, requests[i].URL, nil); err == nil {
+				c.audit(ctx, req, c.now(), ErrReadOnlyClient)
+			}
+			return nil, ErrReadOnlyClient
+		}
+
+		if c.dryRunJournal != nil {
+			req, err := c.NewRequest(requests[i].Method, requests[i].URL, requests[i].Body)
+			if err != nil {
+				return nil, err
+			}
+			if err := c.dryRunJournal.record(req); err != nil {
+				return nil, err
+			}
+			responses[i] = batchResponse{Id: requests[i].Id, Status: http.StatusOK, Body: json.RawMessage("{}")}
+			continue
+		}
+
+		pending = append(pending, i)
+	}
+
+	for retries := 0; ; retries++ {
+		if err := c.sendBatches(ctx, requests, pending, responses); err != nil {
+			return nil, err
+		}
+
+		var throttled []int
+		var delay time.Duration
+		now := c.now()
+		for _, i := range pending {
+			response := responses[i]
+			if response.Status != http.StatusTooManyRequests && response.Status != http.StatusServiceUnavailable {
+				continue
+			}
+
+			throttled = append(throttled, i)
+			until := now.Add(retryAfter(response.Headers["Retry-After"], now))
+			if requestURL, err := c.BaseURL.Parse(requests[i].URL); err == nil {
+				c.throttle(throttlingKey(&http.Request{URL: requestURL}), until)
+			}
+			if until.Sub(now) > delay {
+				delay = until.Sub(now)
+			}
+		}
+
+		if len(throttled) == 0 || retries == maxBatchRetries {
+			return responses, nil
+		}
+
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		pending = throttled
+	}
+}
+
+// sendBatches sends the pending requests combined into JSON batches, and stores their
+// responses at the same positions.
+func (c *Client) sendBatches(ctx context.Context, requests []batchRequest, pending []int, responses []batchResponse) error {
+	for start := 0; start < len(pending); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		var payload struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		for _, i := range pending[start:end] {
+			request := requests[i]
+			request.URL = "/" + request.URL
+			if request.Body != nil && request.Headers == nil {
+				request.Headers = map[string]string{"Content-Type": "application/json"}
+			}
+			payload.Requests = append(payload.Requests, request)
+		}
+
+		jsonBody, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		apiUrl, err := c.BaseURL.Parse("$batch")
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", apiUrl.String(), bytes.NewReader(jsonBody))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		var result struct {
+			Responses []batchResponse `json:"responses"`
+		}
+		sent := c.now()
+		err = c.send(ctx, req, false, &result)
+		if err == nil {
+			for _, response := range result.Responses {
+				i, err := strconv.Atoi(response.Id)
+				if err != nil || i < 0 || i >= len(responses) {
+					continue
+				}
+				responses[i] = response
+			}
+		}
+
+		for _, i := range pending[start:end] {
+			c.auditBatchRequest(ctx, requests[i], responses[i], sent, err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// auditBatchRequest reports one of the requests of a JSON batch, with its own response or
+// the error of the whole batch, like audit.
+func (c *Client) auditBatchRequest(ctx context.Context, request batchRequest, response batchResponse, start time.Time, err error) {
+	if c.auditLog == nil || request.Method == "GET" {
+		return
+	}
+
+	req, reqErr := c.NewRequest(request.Method, request.URL, nil)
+	if reqErr != nil {
+		return
+	}
+
+	if err == nil {
+		err = response.decode(nil)
+	}
+	c.audit(ctx, req, start, err)
+}
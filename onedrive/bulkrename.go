@@ -0,0 +1,214 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RenamePattern represents how BulkRename renames the items of a folder.
+type RenamePattern struct {
+	// Match, if any, selects the items whose name matches it; the other items are left as
+	// they are. Its capture groups can be referenced in Template as $1 or ${group}, like
+	// in regexp.Regexp.Expand.
+	Match *regexp.Regexp
+	// Template is the new name of the items, e.g. "2024-{seq}_{base}{ext}", in which:
+	//	{name} is the current name of the item,
+	//	{base} is the current name without its extension,
+	//	{ext} is the extension of the current name, including the dot,
+	//	{seq} is the sequence number of the item, in the order of the current names.
+	Template string
+	// Start is the first sequence number. Default is 1.
+	Start int
+	// Digits is the minimal number of digits of the sequence numbers, which are padded with
+	// zeros, e.g. 3 for "001".
+	Digits int
+	// DryRun makes BulkRename compute the new names without renaming anything, so they can
+	// be previewed.
+	DryRun bool
+}
+
+// BulkRenameEntry represents the renaming of one of the items by BulkRename.
+type BulkRenameEntry struct {
+	ItemId  string
+	OldName string
+	NewName string
+	// Item is the renamed item, unless the rename is a dry run or has failed before the item
+	// was given a temporary name, see BulkRename.
+	Item *DriveItem
+	Err  error
+}
+
+// BulkRename renames the items of a folder in the default drive of the authenticated user
+// according to a pattern, e.g. to number the photos of an event. The renames are combined
+// into JSON batches.
+//
+// The items whose name does not change are not reported. A rename which would give an item
+// the name of another item of the folder is not sent, and reported with an error, like the
+// renames which have failed. With pattern.DryRun, nothing is sent and the entries only
+// preview the new names.
+//
+// As the requests of a batch are not run in order, the items whose current name is the new
+// name of another item, e.g. when items are renamed in a chain or swap their names, are
+// first given a temporary name in a batch of their own. Such an item whose final rename has
+// failed keeps its temporary name.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_update?view=odsp-graph-online
+func (s *DriveItemsService) BulkRename(ctx context.Context, folderId string, pattern RenamePattern) ([]BulkRenameEntry, error) {
+	if folderId == "" {
+		return nil, errors.New("Please provide the Item ID of the folder.")
+	}
+
+	if pattern.Template == "" {
+		return nil, errors.New("Please provide the template of the new names.")
+	}
+
+	children, err := s.listChildren(ctx, "", folderId)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Name < children[j].Name
+	})
+
+	sequence := pattern.Start
+	if sequence == 0 {
+		sequence = 1
+	}
+
+	// The names are compared case-insensitively, like OneDrive does.
+	names := make(map[string]int)
+	var entries []BulkRenameEntry
+	for _, child := range children {
+		names[strings.ToLower(child.Name)]++
+
+		newName, ok := pattern.rename(child.Name, sequence)
+		if !ok {
+			continue
+		}
+		sequence++
+
+		if newName == child.Name {
+			continue
+		}
+		entries = append(entries, BulkRenameEntry{ItemId: child.Id, OldName: child.Name, NewName: newName})
+	}
+
+	for _, entry := range entries {
+		names[strings.ToLower(entry.OldName)]--
+		names[strings.ToLower(entry.NewName)]++
+	}
+
+	var pending []int
+	for i := range entries {
+		entry := &entries[i]
+		switch {
+		case entry.NewName == "" || strings.ContainsAny(entry.NewName, `"*:<>?/\|`):
+			entry.Err = fmt.Errorf("The new name %q of %q is not a valid name.", entry.NewName, entry.OldName)
+		case names[strings.ToLower(entry.NewName)] > 1:
+			entry.Err = fmt.Errorf("The new name %q of %q is taken by another item of the folder.", entry.NewName, entry.OldName)
+		case !pattern.DryRun:
+			pending = append(pending, i)
+		}
+	}
+
+	newNames := make(map[string]bool)
+	for _, i := range pending {
+		newNames[strings.ToLower(entries[i].NewName)] = true
+	}
+
+	var blocking []int
+	for _, i := range pending {
+		if newNames[strings.ToLower(entries[i].OldName)] {
+			blocking = append(blocking, i)
+		}
+	}
+	err = s.renameItems(ctx, entries, blocking, func(entry *BulkRenameEntry) string {
+		// The IDs of the items are unique, so are these names.
+		return ".rename-" + entry.ItemId
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var renames []int
+	for _, i := range pending {
+		if entries[i].Err == nil {
+			renames = append(renames, i)
+		}
+	}
+	err = s.renameItems(ctx, entries, renames, func(entry *BulkRenameEntry) string {
+		return entry.NewName
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// renameItems renames the items of the entries at the given indexes, to the names returned
+// by name, in JSON batches. The renamed items, or the errors, are stored in the entries.
+func (s *DriveItemsService) renameItems(ctx context.Context, entries []BulkRenameEntry, indexes []int, name func(entry *BulkRenameEntry) string) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	requests := make([]batchRequest, len(indexes))
+	for j, i := range indexes {
+		requests[j] = batchRequest{
+			Method: "PATCH",
+			URL:    "me/drive/items/" + url.PathEscape(entries[i].ItemId),
+			Body:   &RenameItemRequest{Name: name(&entries[i])},
+		}
+	}
+
+	responses, err := s.client.batch(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	for j, response := range responses {
+		entry := &entries[indexes[j]]
+		var item *DriveItem
+		if err := response.decode(&item); err != nil {
+			entry.Err = err
+			continue
+		}
+		entry.Item = item
+		s.client.forgetPath(entry.ItemId)
+	}
+
+	return nil
+}
+
+// rename returns the new name of an item according to the pattern, or false if the pattern
+// does not apply to the item.
+func (p RenamePattern) rename(name string, sequence int) (string, bool) {
+	template := p.Template
+	if p.Match != nil {
+		match := p.Match.FindStringSubmatchIndex(name)
+		if match == nil {
+			return "", false
+		}
+		template = string(p.Match.ExpandString(nil, p.Template, name, match))
+	}
+
+	ext := path.Ext(name)
+	replacer := strings.NewReplacer(
+		"{name}", name,
+		"{base}", strings.TrimSuffix(name, ext),
+		"{ext}", ext,
+		"{seq}", fmt.Sprintf("%0*d", p.Digits, sequence),
+	)
+	return replacer.Replace(template), true
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDriveItemsService_BulkRename(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/f/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value":[
+			{"id":"2","name":"IMG_0002.jpg"},
+			{"id":"1","name":"IMG_0001.jpg"},
+			{"id":"3","name":"notes.txt"},
+			{"id":"4","name":"Trip-001_0001.jpg"}
+		]}`)
+	})
+	var batched []string
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var payload struct {
+			Requests []struct {
+				Id     string
+				Method string
+				URL    string
+				Body   RenameItemRequest
+			}
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		var responses []string
+		for _, request := range payload.Requests {
+			batched = append(batched, request.Method+" "+request.URL+" "+request.Body.Name)
+			responses = append(responses, fmt.Sprintf(`{"id":%q,"status":200,"body":{"id":"x","name":%q}}`, request.Id, request.Body.Name))
+		}
+		fmt.Fprintf(w, `{"responses":[%s]}`, strings.Join(responses, ","))
+	})
+
+	pattern := RenamePattern{
+		Match:    regexp.MustCompile(`^IMG_(\d+)\.jpg$`),
+		Template: "Trip-{seq}_${1}{ext}",
+		Digits:   3,
+	}
+	entries, err := client.DriveItems.BulkRename(context.Background(), "f", pattern)
+	if err != nil {
+		t.Fatalf("DriveItems.BulkRename returned error: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("DriveItems.BulkRename returned %+v, want 2 entries", entries)
+	}
+	if entries[0].ItemId != "1" || entries[0].NewName != "Trip-001_0001.jpg" || entries[0].Err == nil {
+		t.Errorf("entries[0] = %+v, want a conflict with Trip-001_0001.jpg", entries[0])
+	}
+	if entries[1].ItemId != "2" || entries[1].NewName != "Trip-002_0002.jpg" || entries[1].Err != nil || entries[1].Item == nil {
+		t.Errorf("entries[1] = %+v, want Trip-002_0002.jpg", entries[1])
+	}
+	if want := "PATCH /me/drive/items/2 Trip-002_0002.jpg"; len(batched) != 1 || batched[0] != want {
+		t.Errorf("The batch contains %q, want %q", batched, want)
+	}
+}
+
+func TestDriveItemsService_BulkRename_dryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/f/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[{"id":"1","name":"a.txt"},{"id":"2","name":"b"}]}`)
+	})
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("A dry run sent a batch")
+	})
+
+	entries, err := client.DriveItems.BulkRename(context.Background(), "f", RenamePattern{Template: "old_{base}{ext}", DryRun: true})
+	if err != nil {
+		t.Fatalf("DriveItems.BulkRename returned error: %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].NewName != "old_a.txt" || entries[1].NewName != "old_b" || entries[0].Item != nil {
+		t.Errorf("DriveItems.BulkRename returned %+v", entries)
+	}
+}
+
+func TestDriveItemsService_BulkRename_chain(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/f/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[
+			{"id":"1","name":"1.jpg"},
+			{"id":"2","name":"2.jpg"},
+			{"id":"3","name":"3.jpg"}
+		]}`)
+	})
+	names := map[string]string{"1": "1.jpg", "2": "2.jpg", "3": "3.jpg"}
+	var batches [][]string
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Requests []struct {
+				Id   string
+				URL  string
+				Body RenameItemRequest
+			}
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		// The requests of a batch may run in any order, so a name is only free if no item
+		// has it before the batch.
+		taken := make(map[string]bool)
+		for _, name := range names {
+			taken[name] = true
+		}
+		var batch, responses []string
+		for _, request := range payload.Requests {
+			itemId := strings.TrimPrefix(request.URL, "/me/drive/items/")
+			batch = append(batch, itemId+" "+request.Body.Name)
+			if taken[request.Body.Name] {
+				responses = append(responses, fmt.Sprintf(`{"id":%q,"status":409,"body":{"error":{"code":"nameAlreadyExists"}}}`, request.Id))
+				continue
+			}
+			names[itemId] = request.Body.Name
+			responses = append(responses, fmt.Sprintf(`{"id":%q,"status":200,"body":{"id":%q,"name":%q}}`, request.Id, itemId, request.Body.Name))
+		}
+		batches = append(batches, batch)
+		fmt.Fprintf(w, `{"responses":[%s]}`, strings.Join(responses, ","))
+	})
+
+	entries, err := client.DriveItems.BulkRename(context.Background(), "f", RenamePattern{Template: "{seq}.jpg", Start: 2})
+	if err != nil {
+		t.Fatalf("DriveItems.BulkRename returned error: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Err != nil || entry.Item == nil || entry.Item.Name != entry.NewName {
+			t.Errorf("Entry %+v, want %s renamed", entry, entry.OldName)
+		}
+	}
+	if want := map[string]string{"1": "2.jpg", "2": "3.jpg", "3": "4.jpg"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("The items are named %v, want %v", names, want)
+	}
+	if want := [][]string{{"2 .rename-2", "3 .rename-3"}, {"1 2.jpg", "2 3.jpg", "3 4.jpg"}}; !reflect.DeepEqual(batches, want) {
+		t.Errorf("The batches contain %q, want %q", batches, want)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// BackupCameraRollOpts represents the options for backing up photos by BackupCameraRoll.
+type BackupCameraRollOpts struct {
+	// TakenTime returns when a photo was taken, which decides the folder it is uploaded to.
+	// By default, the photo is uploaded according to the modification time of the file, then
+	// moved to the folder of the time OneDrive reads from its metadata, if it reports one in
+	// the photo facet of the uploaded item. A function reading the EXIF metadata of the
+	// photos can be provided instead.
+	TakenTime func(localFilePath string, fileInfo os.FileInfo) (time.Time, error)
+}
+
+// BackupCameraRollResult represents the outcome of backing up one of the photos by
+// BackupCameraRoll.
+type BackupCameraRollResult struct {
+	LocalPath string
+	// Item is the uploaded item, or the existing item with the same content when the photo
+	// has been skipped as a duplicate.
+	Item      *DriveItem
+	Duplicate bool
+	Err       error
+}
+
+// BackupCameraRollError is returned by BackupCameraRoll when some of the photos could not
+// be backed up.
+type BackupCameraRollError struct {
+	Failed []BackupCameraRollResult
+}
+
+func (e *BackupCameraRollError) Error() string {
+	if len(e.Failed) == 1 {
+		return fmt.Sprintf("1 photo could not be backed up: %s: %v", e.Failed[0].LocalPath, e.Failed[0].Err)
+	}
+	return fmt.Sprintf("%d photos could not be backed up, first %s: %v", len(e.Failed), e.Failed[0].LocalPath, e.Failed[0].Err)
+}
+
+// BackupCameraRoll uploads the files of a local photo directory, e.g. the DCIM folder of a
+// device, and of its subdirectories into the camera roll special folder of the default drive
+// of the authenticated user. Each photo is laid out in a YYYY/MM folder according to the
+// time it was taken; the missing folders are created.
+//
+// The photos whose content is already anywhere in the camera roll, compared by size and
+// QuickXorHash or the hash of WithHasher, are skipped, so the backup can run again and
+// again. A photo is given a new name when another photo of the same name exists in its
+// folder.
+//
+// The outcome of every photo is reported in the results, sorted by local path. When some of
+// the photos could not be backed up, a *BackupCameraRollError listing them is returned along
+// with the results.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get_specialfolder?view=odsp-graph-online
+func (s *DriveItemsService) BackupCameraRoll(ctx context.Context, localDirectory string, opts BackupCameraRollOpts) ([]BackupCameraRollResult, error) {
+	if localDirectory == "" {
+		return nil, errors.New("Please provide the path to the photo directory on local.")
+	}
+
+	var results []BackupCameraRollResult
+	err := filepath.Walk(localDirectory, func(localFilePath string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fileInfo.Mode().IsRegular() {
+			results = append(results, BackupCameraRollResult{LocalPath: localFilePath})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cameraRoll, err := s.GetSpecial(ctx, CameraRoll)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.indexCameraRoll(ctx, cameraRoll.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []BackupCameraRollResult
+	for i := range results {
+		result := &results[i]
+		result.Item, result.Duplicate, result.Err = s.backupPhoto(ctx, index, result.LocalPath, opts.TakenTime)
+		if result.Err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			failed = append(failed, *result)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, &BackupCameraRollError{Failed: failed}
+	}
+
+	return results, nil
+}
+
+// cameraRollIndex represents the content of the camera roll, as far as BackupCameraRoll is
+// concerned.
+type cameraRollIndex struct {
+	hasher    Hasher
+	folderIds map[string]string      // IDs of the folders by path from the camera roll, e.g. "2020/03".
+	files     map[int64][]*DriveItem // Files by size.
+}
+
+// indexCameraRoll lists the whole subtree of the camera roll.
+func (s *DriveItemsService) indexCameraRoll(ctx context.Context, cameraRollId string) (*cameraRollIndex, error) {
+	index := &cameraRollIndex{
+		hasher:    s.client.fileHasher(),
+		folderIds: map[string]string{"": cameraRollId},
+		files:     make(map[int64][]*DriveItem),
+	}
+
+	folderPaths := []string{""}
+	for len(folderPaths) > 0 {
+		folderPath := folderPaths[0]
+		folderPaths = folderPaths[1:]
+
+		children, err := s.listChildren(ctx, "", index.folderIds[folderPath])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			switch {
+			case child.Folder != nil:
+				childPath := child.Name
+				if folderPath != "" {
+					childPath = folderPath + "/" + child.Name
+				}
+				index.folderIds[childPath] = child.Id
+				folderPaths = append(folderPaths, childPath)
+			case child.File != nil && child.File.Hashes != nil:
+				index.files[child.Size] = append(index.files[child.Size], child)
+			}
+		}
+	}
+
+	return index, nil
+}
+
+// backupPhoto uploads a photo into its YYYY/MM folder of the camera roll, unless it is a
+// duplicate. Without takenTime, the folder is the one of the photo facet of the uploaded
+// item, if any.
+func (s *DriveItemsService) backupPhoto(ctx context.Context, index *cameraRollIndex, localFilePath string, takenTime func(string, os.FileInfo) (time.Time, error)) (*DriveItem, bool, error) {
+	fileInfo, err := os.Stat(localFilePath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if candidates := index.files[fileInfo.Size()]; len(candidates) > 0 {
+		localHash, err := index.hasher.HashFile(localFilePath)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, candidate := range candidates {
+			if remoteHash := index.hasher.RemoteHash(candidate.File.Hashes); remoteHash != "" && remoteHash == localHash {
+				return candidate, true, nil
+			}
+		}
+	}
+
+	taken := fileInfo.ModTime()
+	if takenTime != nil {
+		taken, err = takenTime(localFilePath, fileInfo)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	folderId, err := s.cameraRollFolder(ctx, index, taken)
+	if err != nil {
+		return nil, false, err
+	}
+
+	item, err := s.uploadLocalFile(ctx, "", folderId, localFilePath, "rename")
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The modification time is only a guess of when the photo was taken, which OneDrive
+	// reads from its metadata.
+	if takenTime == nil && item.Photo != nil && !item.Photo.TakenDateTime.IsZero() {
+		takenFolderId, err := s.cameraRollFolder(ctx, index, item.Photo.TakenDateTime)
+		if err != nil {
+			return item, false, err
+		}
+		if takenFolderId != folderId {
+			moved, err := s.move(ctx, "", item.Id, takenFolderId, "rename")
+			if err != nil {
+				return item, false, err
+			}
+			item.Name = moved.Name
+			item.ParentReference = &moved.ParentFolder
+		}
+	}
+
+	// The duplicates within the local directory are skipped too.
+	if item.File != nil && item.File.Hashes != nil {
+		index.files[item.Size] = append(index.files[item.Size], item)
+	}
+
+	return item, false, nil
+}
+
+// cameraRollFolder returns the ID of the YYYY/MM folder of the camera roll for a photo taken
+// at the given time, creating it if needed.
+func (s *DriveItemsService) cameraRollFolder(ctx context.Context, index *cameraRollIndex, taken time.Time) (string, error) {
+	year := strconv.Itoa(taken.Year())
+	month := fmt.Sprintf("%02d", int(taken.Month()))
+
+	folderPath := ""
+	for _, name := range []string{year, month} {
+		parentId := index.folderIds[folderPath]
+		if folderPath != "" {
+			folderPath += "/"
+		}
+		folderPath += name
+
+		if _, ok := index.folderIds[folderPath]; ok {
+			continue
+		}
+
+		folder, err := s.CreateNewFolderWithOpts(ctx, "", parentId, name, CreateNewFolderOpts{ConflictBehavior: "fail"})
+		if err != nil {
+			return "", err
+		}
+		index.folderIds[folderPath] = folder.Id
+	}
+
+	return index.folderIds[folderPath], nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_BackupCameraRoll(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	quickXorHash := func(data string) string {
+		h := NewQuickXorHash()
+		h.Write([]byte(data))
+		return base64.StdEncoding.EncodeToString(h.Sum(nil))
+	}
+
+	dir, err := ioutil.TempDir("", "cameraroll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	taken := time.Date(2020, 3, 5, 10, 0, 0, 0, time.UTC)
+	for name, content := range map[string]string{"a.jpg": "backed up", "b.jpg": "new photo", "sub/c.jpg": "new photo"} {
+		localPath := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(localPath), 0755)
+		if err := ioutil.WriteFile(localPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(localPath, taken, taken)
+	}
+
+	mux.HandleFunc("/me/drive/special/cameraroll", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"cr","name":"Camera Roll","folder":{}}`)
+	})
+	mux.HandleFunc("/me/drive/items/cr/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value":[{"id":"y2020","name":"2020","folder":{}}]}`)
+	})
+	mux.HandleFunc("/me/drive/items/y2020/children", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"id":"m03","name":"03","folder":{}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"value":[{"id":"old","name":"IMG_0001.jpg","size":9,"file":{"hashes":{"quickXorHash":%q}}}]}`, quickXorHash("backed up"))
+	})
+	var uploads []string
+	mux.HandleFunc("/me/drive/items/m03:/b.jpg:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		if got, want := r.URL.Query().Get("@microsoft.graph.conflictBehavior"), "rename"; got != want {
+			t.Errorf("conflictBehavior = %q, want %q", got, want)
+		}
+		uploads = append(uploads, "b.jpg")
+		fmt.Fprintf(w, `{"id":"b","name":"b.jpg","size":9,"file":{"hashes":{"quickXorHash":%q}}}`, quickXorHash("new photo"))
+	})
+
+	results, err := client.DriveItems.BackupCameraRoll(context.Background(), dir, BackupCameraRollOpts{})
+	if err != nil {
+		t.Fatalf("DriveItems.BackupCameraRoll returned error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("DriveItems.BackupCameraRoll returned %d results, want 3", len(results))
+	}
+	for i, want := range []struct {
+		itemId    string
+		duplicate bool
+	}{{"old", true}, {"b", false}, {"b", true}} {
+		if results[i].Item == nil || results[i].Item.Id != want.itemId || results[i].Duplicate != want.duplicate {
+			t.Errorf("results[%d] = %+v, want item %q, duplicate %v", i, results[i], want.itemId, want.duplicate)
+		}
+	}
+	if len(uploads) != 1 {
+		t.Errorf("DriveItems.BackupCameraRoll uploaded %v, want only b.jpg", uploads)
+	}
+}
+
+func TestDriveItemsService_BackupCameraRoll_takenTime(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "cameraroll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "a.jpg"), []byte("photo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/me/drive/special/cameraroll", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"cr","name":"Camera Roll","folder":{}}`)
+	})
+	mux.HandleFunc("/me/drive/items/cr/children", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"id":"y2019","name":"2019","folder":{}}`)
+			return
+		}
+		fmt.Fprint(w, `{"value":[]}`)
+	})
+	mux.HandleFunc("/me/drive/items/y2019/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":"m12","name":"12","folder":{}}`)
+	})
+	mux.HandleFunc("/me/drive/items/m12:/a.jpg:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id":"a","name":"a.jpg"}`)
+	})
+
+	results, err := client.DriveItems.BackupCameraRoll(context.Background(), dir, BackupCameraRollOpts{
+		TakenTime: func(localFilePath string, fileInfo os.FileInfo) (time.Time, error) {
+			return time.Date(2019, 12, 31, 23, 0, 0, 0, time.UTC), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.BackupCameraRoll returned error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Item == nil || results[0].Item.Id != "a" {
+		t.Errorf("DriveItems.BackupCameraRoll returned %+v, want item a", results)
+	}
+}
+
+func TestDriveItemsService_BackupCameraRoll_photoTakenDateTime(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "cameraroll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "a.jpg")
+	if err := ioutil.WriteFile(localPath, []byte("photo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The photo was copied long after it was taken.
+	modified := time.Date(2021, 6, 15, 12, 0, 0, 0, time.Local)
+	os.Chtimes(localPath, modified, modified)
+
+	mux.HandleFunc("/me/drive/special/cameraroll", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"cr","name":"Camera Roll","folder":{}}`)
+	})
+	mux.HandleFunc("/me/drive/items/cr/children", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"id":"y2019","name":"2019","folder":{}}`)
+			return
+		}
+		fmt.Fprint(w, `{"value":[{"id":"y2021","name":"2021","folder":{}}]}`)
+	})
+	mux.HandleFunc("/me/drive/items/y2021/children", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"id":"m06","name":"06","folder":{}}`)
+			return
+		}
+		fmt.Fprint(w, `{"value":[]}`)
+	})
+	mux.HandleFunc("/me/drive/items/y2019/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":"m12","name":"12","folder":{}}`)
+	})
+	mux.HandleFunc("/me/drive/items/m06:/a.jpg:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id":"a","name":"a.jpg","photo":{"takenDateTime":"2019-12-24T18:00:00Z"}}`)
+	})
+	moved := false
+	mux.HandleFunc("/me/drive/items/a", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		var request MoveItemRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.ParentFolder.Id != "m12" {
+			t.Errorf("The photo is moved to %q, want m12", request.ParentFolder.Id)
+		}
+		if got, want := r.URL.Query().Get("@microsoft.graph.conflictBehavior"), "rename"; got != want {
+			t.Errorf("conflictBehavior = %q, want %q", got, want)
+		}
+		moved = true
+		fmt.Fprint(w, `{"id":"a","name":"a 1.jpg","parentReference":{"id":"m12"}}`)
+	})
+
+	results, err := client.DriveItems.BackupCameraRoll(context.Background(), dir, BackupCameraRollOpts{})
+	if err != nil {
+		t.Fatalf("DriveItems.BackupCameraRoll returned error: %v", err)
+	}
+
+	if !moved {
+		t.Errorf("DriveItems.BackupCameraRoll did not move the photo to 2019/12")
+	}
+	if item := results[0].Item; item == nil || item.Name != "a 1.jpg" || item.ParentReference == nil || item.ParentReference.Id != "m12" {
+		t.Errorf("DriveItems.BackupCameraRoll returned %+v, want the moved item", results)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// HasContentChanged reports whether the content of a drive item has changed between
+// two snapshots of the same item.
+//
+// The cTag of an item only changes when its content changes, while renaming or moving
+// the item leaves it untouched. OneDrive for Business does not return cTag for folders,
+// so when either snapshot has no cTag, the eTag is compared instead.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/driveitem?view=odsp-graph-online#properties
+func HasContentChanged(old *DriveItem, new *DriveItem) bool {
+	if old == nil || new == nil {
+		return old != new
+	}
+
+	if old.CTag == "" || new.CTag == "" {
+		return old.ETag != new.ETag
+	}
+
+	return old.CTag != new.CTag
+}
+
+// HasMetadataChanged reports whether anything about a drive item has changed between
+// two snapshots of the same item.
+//
+// The eTag of an item changes whenever the item is modified, which covers metadata
+// updates such as renaming or moving as well as content updates. Use HasContentChanged
+// to tell the two apart.
+func HasMetadataChanged(old *DriveItem, new *DriveItem) bool {
+	if old == nil || new == nil {
+		return old != new
+	}
+
+	return old.ETag != new.ETag
+}
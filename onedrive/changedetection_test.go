@@ -0,0 +1,47 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import "testing"
+
+func TestHasContentChanged(t *testing.T) {
+	tests := []struct {
+		name string
+		old  *DriveItem
+		new  *DriveItem
+		want bool
+	}{
+		{"unchanged", &DriveItem{ETag: "e1", CTag: "c1"}, &DriveItem{ETag: "e1", CTag: "c1"}, false},
+		{"renamed", &DriveItem{ETag: "e1", CTag: "c1"}, &DriveItem{ETag: "e2", CTag: "c1"}, false},
+		{"content updated", &DriveItem{ETag: "e1", CTag: "c1"}, &DriveItem{ETag: "e2", CTag: "c2"}, true},
+		{"folder without cTag", &DriveItem{ETag: "e1"}, &DriveItem{ETag: "e2"}, true},
+		{"nil snapshot", nil, &DriveItem{ETag: "e1"}, true},
+	}
+
+	for _, test := range tests {
+		if got := HasContentChanged(test.old, test.new); got != test.want {
+			t.Errorf("HasContentChanged(%s) returned %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestHasMetadataChanged(t *testing.T) {
+	tests := []struct {
+		name string
+		old  *DriveItem
+		new  *DriveItem
+		want bool
+	}{
+		{"unchanged", &DriveItem{ETag: "e1", CTag: "c1"}, &DriveItem{ETag: "e1", CTag: "c1"}, false},
+		{"renamed", &DriveItem{ETag: "e1", CTag: "c1"}, &DriveItem{ETag: "e2", CTag: "c1"}, true},
+		{"content updated", &DriveItem{ETag: "e1", CTag: "c1"}, &DriveItem{ETag: "e2", CTag: "c2"}, true},
+	}
+
+	for _, test := range tests {
+		if got := HasMetadataChanged(test.old, test.new); got != test.want {
+			t.Errorf("HasMetadataChanged(%s) returned %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package chaos provides an http.RoundTripper injecting the failures OneDrive is known to
+// return, so that the retry and resume handling of an application can be tested:
+//
+//	transport := &chaos.Transport{
+//		Rules: []*chaos.Rule{
+//			{Path: "/children", Fault: chaos.TooManyRequests, Probability: 0.2},
+//			{Method: "PUT", Fault: chaos.ExpiredSession, Limit: 1},
+//		},
+//	}
+//	client := onedrive.NewClient(&http.Client{Transport: transport})
+//
+// Responses are injected without reaching OneDrive, except for TruncatedBody.
+package chaos
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Fault represents a failure injected by a Transport.
+type Fault int
+
+const (
+	// TooManyRequests responds 429 Too Many Requests with a Retry-After header.
+	TooManyRequests Fault = iota
+	// ServiceUnavailable responds 503 Service Unavailable with a Retry-After header.
+	ServiceUnavailable
+	// InternalServerError responds 500 Internal Server Error.
+	InternalServerError
+	// Timeout fails the request with a net.Error whose Timeout method reports true.
+	Timeout
+	// TruncatedBody sends the request, then cuts the body of the response halfway with
+	// io.ErrUnexpectedEOF.
+	TruncatedBody
+	// ExpiredSession responds 404 Not Found, as OneDrive does for the requests to an upload
+	// session which has expired.
+	ExpiredSession
+)
+
+// Rule represents when a Transport injects a fault.
+type Rule struct {
+	// injected is first to be 64-bit aligned for its atomic operations on 32-bit platforms.
+	injected int64
+
+	// Method is the HTTP method of the requests the rule applies to. Empty means any method.
+	Method string
+	// Path is a substring of the URL path of the requests the rule applies to. Empty means
+	// any path.
+	Path string
+	// Fault is the failure to inject.
+	Fault Fault
+	// Probability is the chance, between 0 and 1, of injecting the fault into a matching
+	// request. Zero means every matching request.
+	Probability float64
+	// Limit is the maximum number of faults injected by the rule. Zero means unlimited.
+	Limit int
+	// RetryAfter is the delay sent in the Retry-After header of TooManyRequests and
+	// ServiceUnavailable. Zero means one second.
+	RetryAfter time.Duration
+}
+
+// Injected returns the number of faults injected by the rule so far. It may be called while
+// requests are in flight.
+func (r *Rule) Injected() int {
+	return int(atomic.LoadInt64(&r.injected))
+}
+
+// Transport is an http.RoundTripper injecting faults into the requests matching its rules.
+// The first matching rule which decides to inject a fault wins; the other requests are sent
+// with Base.
+type Transport struct {
+	// Base is the transport sending the requests. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+	// Rules are the rules checked, in order, for every request.
+	Rules []*Rule
+	// Rand is the source of randomness of Rule.Probability. If nil, a source seeded with the
+	// current time is used.
+	Rand *rand.Rand
+
+	mu sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rule := t.match(req)
+	if rule == nil {
+		return t.base().RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	switch rule.Fault {
+	case TooManyRequests:
+		resp := newErrorResponse(req, http.StatusTooManyRequests, "activityLimitReached", "The app or user has been throttled.")
+		resp.Header.Set("Retry-After", retryAfter(rule))
+		return resp, nil
+	case ServiceUnavailable:
+		resp := newErrorResponse(req, http.StatusServiceUnavailable, "serviceNotAvailable", "The service is not available. Try the request again after a delay.")
+		resp.Header.Set("Retry-After", retryAfter(rule))
+		return resp, nil
+	case InternalServerError:
+		return newErrorResponse(req, http.StatusInternalServerError, "generalException", "An unspecified error has occurred."), nil
+	case Timeout:
+		return nil, timeoutError{}
+	case ExpiredSession:
+		return newErrorResponse(req, http.StatusNotFound, "itemNotFound", "The upload session was not found."), nil
+	case TruncatedBody:
+		resp, err := t.base().RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = truncate(resp.Body)
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("chaos: unknown fault %d", rule.Fault)
+}
+
+// match returns the rule injecting a fault into the request, if any.
+func (t *Transport) match(req *http.Request) *Rule {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, rule := range t.Rules {
+		if rule.Method != "" && rule.Method != req.Method {
+			continue
+		}
+		if rule.Path != "" && !strings.Contains(req.URL.Path, rule.Path) {
+			continue
+		}
+		if rule.Limit > 0 && rule.Injected() >= rule.Limit {
+			continue
+		}
+		if rule.Probability > 0 && t.random() >= rule.Probability {
+			continue
+		}
+
+		atomic.AddInt64(&rule.injected, 1)
+		return rule
+	}
+
+	return nil
+}
+
+func (t *Transport) random() float64 {
+	if t.Rand == nil {
+		t.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return t.Rand.Float64()
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func retryAfter(rule *Rule) string {
+	if rule.RetryAfter <= 0 {
+		return "1"
+	}
+	return strconv.Itoa(int((rule.RetryAfter + time.Second - 1) / time.Second))
+}
+
+func newErrorResponse(req *http.Request, statusCode int, code string, message string) *http.Response {
+	body := fmt.Sprintf(`{"error": {"code": %q, "message": %q}}`, code, message)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// truncate returns a body returning the first half of the content of body, then failing
+// with io.ErrUnexpectedEOF.
+func truncate(body io.ReadCloser) io.ReadCloser {
+	content, err := ioutil.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return ioutil.NopCloser(&failingReader{err: err})
+	}
+
+	return ioutil.NopCloser(io.MultiReader(bytes.NewReader(content[:len(content)/2]), &failingReader{err: io.ErrUnexpectedEOF}))
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+// timeoutError implements net.Error.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "chaos: request timed out" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,119 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+func setup(t *testing.T, rules ...*Rule) (*onedrive.Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "1", "name": "Item"}`)
+	}))
+
+	client := onedrive.NewClient(&http.Client{Transport: &Transport{Rules: rules}})
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, server.Close
+}
+
+func TestTransport_TooManyRequests(t *testing.T) {
+	rule := &Rule{Path: "/items/1", Fault: TooManyRequests, Limit: 1, RetryAfter: 3 * time.Second}
+	client, teardown := setup(t, rule)
+
+	defer teardown()
+
+	ctx := context.Background()
+	_, err := client.DriveItems.Get(ctx, "1")
+
+	var oneDriveError *onedrive.Error
+	if !errors.As(err, &oneDriveError) || oneDriveError.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("DriveItems.Get returned error %v, want a 429 error", err)
+	}
+
+	if _, err := client.DriveItems.Get(ctx, "1"); err != nil {
+		t.Errorf("DriveItems.Get returned error %v after the limit of the rule", err)
+	}
+
+	if got, want := rule.Injected(), 1; got != want {
+		t.Errorf("Rule injected %d faults, want %d", got, want)
+	}
+}
+
+func TestRule_Injected_concurrent(t *testing.T) {
+	rule := &Rule{Fault: InternalServerError}
+	client, teardown := setup(t, rule)
+
+	defer teardown()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			client.DriveItems.Get(context.Background(), "1")
+		}
+	}()
+
+	for polling := true; polling; {
+		select {
+		case <-done:
+			polling = false
+		default:
+			rule.Injected()
+		}
+	}
+
+	if got, want := rule.Injected(), 10; got != want {
+		t.Errorf("Rule injected %d faults, want %d", got, want)
+	}
+}
+
+func TestTransport_Timeout(t *testing.T) {
+	client, teardown := setup(t, &Rule{Method: "GET", Fault: Timeout})
+
+	defer teardown()
+
+	_, err := client.DriveItems.Get(context.Background(), "1")
+
+	var netError net.Error
+	if !errors.As(err, &netError) || !netError.Timeout() {
+		t.Errorf("DriveItems.Get returned error %v, want a timeout", err)
+	}
+}
+
+func TestTransport_TruncatedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "0123456789")
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: &Transport{Rules: []*Rule{{Fault: TruncatedBody}}}}
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Reading the body returned error %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	if got, want := string(body), "01234"; got != want {
+		t.Errorf("Body: %q, want %q", got, want)
+	}
+}
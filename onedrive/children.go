@@ -0,0 +1,44 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// errStopIteration stops ListFunc when the caller of Children breaks out of its loop.
+var errStopIteration = errors.New("stop iteration")
+
+// Children returns an iterator over the items of a folder in the default drive of the
+// authenticated user, which goes through all the pages of the listing as the loop goes:
+//
+//	for item, err := range client.DriveItems.Children(ctx, folderId) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(item.Name)
+//	}
+//
+// An error ends the iteration. If folderId is empty, it means the items at the root of the
+// default drive will be listed. See ListFunc about the memory usage.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) Children(ctx context.Context, folderId string) iter.Seq2[*DriveItem, error] {
+	return func(yield func(*DriveItem, error) bool) {
+		err := s.ListFunc(ctx, folderId, ListOpts{}, func(item *DriveItem) error {
+			if !yield(item, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && err != errStopIteration {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDriveItemsService_Children(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if r.URL.Query().Get("$skiptoken") == "" {
+			fmt.Fprintf(w, `{"value": [{"id": "a"}, {"id": "b"}], "@odata.nextLink": "%sme/drive/items/1/children?$skiptoken=page2"}`, client.BaseURL)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "c"}]}`)
+	})
+
+	ctx := context.Background()
+	var gotIds []string
+	for item, err := range client.DriveItems.Children(ctx, "1") {
+		if err != nil {
+			t.Fatalf("DriveItems.Children returned error: %v", err)
+		}
+		gotIds = append(gotIds, item.Id)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(gotIds, want) {
+		t.Errorf("DriveItems.Children returned %v, want %v", gotIds, want)
+	}
+
+	gotIds = nil
+	for item, err := range client.DriveItems.Children(ctx, "1") {
+		if err != nil {
+			t.Fatalf("DriveItems.Children returned error: %v", err)
+		}
+		gotIds = append(gotIds, item.Id)
+		break
+	}
+	if want := []string{"a"}; !reflect.DeepEqual(gotIds, want) {
+		t.Errorf("DriveItems.Children returned %v after break, want %v", gotIds, want)
+	}
+}
+
+func TestDriveItemsService_Children_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/unknown/children", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+
+	errs := 0
+	for item, err := range client.DriveItems.Children(context.Background(), "unknown") {
+		if item != nil || !isNotFound(err) {
+			t.Errorf("DriveItems.Children returned %v, %v, want a not found error", item, err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("DriveItems.Children returned %d errors, want 1", errs)
+	}
+}
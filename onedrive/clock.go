@@ -0,0 +1,62 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the current time to the client, e.g. to know until when a drive is throttled.
+type Clock interface {
+	Now() time.Time
+}
+
+// Sleeper waits on behalf of the client, e.g. before retrying a request. Sleep returns the
+// error of the context if it is done before the duration has elapsed.
+type Sleeper interface {
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// WithClock makes the client read the time from clock instead of the system clock. Along
+// with WithSleeper, it lets tests of the retry and throttling behavior run instantly and
+// deterministically.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithSleeper makes the client wait with sleeper instead of timers, see WithClock.
+func WithSleeper(sleeper Sleeper) ClientOption {
+	return func(c *Client) {
+		c.sleeper = sleeper
+	}
+}
+
+// now returns the current time of the clock of the client, see WithClock.
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
+
+// sleep waits for the duration with the sleeper of the client, see WithSleeper.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if c.sleeper != nil {
+		return c.sleeper.Sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
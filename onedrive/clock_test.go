@@ -0,0 +1,95 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock and a Sleeper which only moves forward when slept on.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return ctx.Err()
+}
+
+func TestClient_WithClock_throttling(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	WithThrottlingBackoff()(client)
+	WithClock(clock)(client)
+	WithSleeper(clock)(client)
+
+	requests := 0
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "Fri, 01 Jan 2021 00:01:00 GMT")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error": {"code": "serviceNotAvailable", "message": "Throttled"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "1"}`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.DriveItems.Get(ctx, "1"); !isStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("DriveItems.Get returned error %v, want a 503 error", err)
+	}
+	if _, err := client.DriveItems.Get(ctx, "1"); err != nil {
+		t.Fatalf("DriveItems.Get returned error: %v", err)
+	}
+
+	if want := []time.Duration{time.Minute}; !reflect.DeepEqual(clock.sleeps, want) {
+		t.Errorf("Client slept %v, want %v", clock.sleeps, want)
+	}
+}
+
+func TestClient_WithClock_notFoundRetry(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	WithNotFoundRetryAfterCreate(time.Second)(client)
+	WithClock(clock)(client)
+	WithSleeper(clock)(client)
+
+	client.markCreated(&DriveItem{Id: "new"})
+
+	requests := 0
+	mux.HandleFunc("/me/drive/items/new", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+
+	if _, err := client.DriveItems.Get(context.Background(), "new"); !isNotFound(err) {
+		t.Fatalf("DriveItems.Get returned error %v, want not found", err)
+	}
+
+	// 200ms and 400ms fit in the window of 1s, 800ms more does not.
+	want := []time.Duration{200 * time.Millisecond, 400 * time.Millisecond}
+	if !reflect.DeepEqual(clock.sleeps, want) || requests != 3 {
+		t.Errorf("Client slept %v over %d requests, want %v over 3", clock.sleeps, requests, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WithRequestCoalescing makes identical GET requests sent concurrently share a single
+// request to OneDrive, e.g. when many components of a UI read the same folder at once. The
+// response is also shared with the identical requests sent less than window after it has
+// been received; a zero window only shares the requests in flight.
+//
+// Requests are identical when they have the same URL and headers. Downloads of ranges are
+// never shared, and a request modifying a drive discards the responses kept for the window,
+// so the changes are read by the following requests.
+func WithRequestCoalescing(window time.Duration) ClientOption {
+	return func(c *Client) {
+		c.coalescingWindow = window
+		c.coalesced = make(map[string]*coalescedRequest)
+	}
+}
+
+// receivedResponse represents what has been received for a request, which may be shared by
+// identical requests, see WithRequestCoalescing.
+type receivedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+// coalescedRequest represents a request sent on behalf of identical requests.
+type coalescedRequest struct {
+	done       chan struct{} // Closed once response is set.
+	response   *receivedResponse
+	receivedAt time.Time // Zero until the response has been received.
+}
+
+// coalescingKey returns the key identifying the requests identical to req, if it can be
+// shared.
+func (c *Client) coalescingKey(req *http.Request, isUsingPlainHttpClient bool) (string, bool) {
+	if c.coalesced == nil || req.Method != "GET" || req.Body != nil || req.Header.Get("Range") != "" {
+		return "", false
+	}
+
+	// The headers are printed sorted by name.
+	return fmt.Sprintf("%t %s %v", isUsingPlainHttpClient, req.URL, req.Header), true
+}
+
+// coalesce returns the response to the request identified by key, sending it with send
+// unless an identical request is in flight or has been answered within the window.
+func (c *Client) coalesce(ctx context.Context, key string, send func() *receivedResponse) *receivedResponse {
+	c.coalescingMu.Lock()
+	if request, ok := c.coalesced[key]; ok {
+		if request.receivedAt.IsZero() {
+			c.coalescingMu.Unlock()
+			return c.waitCoalesced(ctx, request, send)
+		}
+		if c.now().Sub(request.receivedAt) < c.coalescingWindow {
+			c.coalescingMu.Unlock()
+			return request.response
+		}
+	}
+
+	request := &coalescedRequest{done: make(chan struct{})}
+	c.coalesced[key] = request
+	c.coalescingMu.Unlock()
+
+	response := send()
+
+	c.coalescingMu.Lock()
+	request.response, request.receivedAt = response, c.now()
+	for otherKey, other := range c.coalesced {
+		expired := !other.receivedAt.IsZero() && request.receivedAt.Sub(other.receivedAt) >= c.coalescingWindow
+		if expired || (other == request && response.err != nil) {
+			delete(c.coalesced, otherKey)
+		}
+	}
+	c.coalescingMu.Unlock()
+	close(request.done)
+
+	return response
+}
+
+// waitCoalesced waits for the response to an identical request in flight. If that request
+// has been canceled by its own context, the request is sent with send instead.
+func (c *Client) waitCoalesced(ctx context.Context, request *coalescedRequest, send func() *receivedResponse) *receivedResponse {
+	select {
+	case <-request.done:
+	case <-ctx.Done():
+		return &receivedResponse{err: ctx.Err()}
+	}
+
+	err := request.response.err
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return send()
+	}
+
+	return request.response
+}
+
+// forgetCoalesced discards the responses kept for the window, see WithRequestCoalescing.
+func (c *Client) forgetCoalesced() {
+	if c.coalesced == nil {
+		return
+	}
+
+	c.coalescingMu.Lock()
+	defer c.coalescingMu.Unlock()
+
+	for key, request := range c.coalesced {
+		if !request.receivedAt.IsZero() {
+			delete(c.coalesced, key)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_WithRequestCoalescing_concurrent(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithRequestCoalescing(time.Minute)(client)
+
+	var requests int32
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	mux.HandleFunc("/me/drive/items/folder-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		atomic.AddInt32(&requests, 1)
+		received <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"id":"folder-1","name":"Photos"}`)
+	})
+
+	const readers = 5
+	items := make([]*DriveItem, readers)
+	errs := make([]error, readers)
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			items[i], errs[i] = client.DriveItems.Get(context.Background(), "folder-1")
+		}(i)
+	}
+
+	<-received
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("OneDrive received %d requests, want 1", got)
+	}
+	for i := range items {
+		if errs[i] != nil {
+			t.Errorf("DriveItems.Get returned error: %v", errs[i])
+		} else if items[i].Name != "Photos" {
+			t.Errorf("DriveItems.Get returned %+v", items[i])
+		}
+	}
+	if items[0] == items[1] {
+		t.Error("DriveItems.Get returned the same item to two callers")
+	}
+}
+
+func TestClient_WithRequestCoalescing_window(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	WithRequestCoalescing(5 * time.Second)(client)
+	WithClock(clock)(client)
+
+	requests := 0
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			fmt.Fprint(w, `{"id":"1","name":"renamed.txt"}`)
+			return
+		}
+		requests++
+		fmt.Fprintf(w, `{"id":"1","name":"file-%d.txt"}`, requests)
+	})
+
+	ctx := context.Background()
+	get := func() string {
+		t.Helper()
+		item, err := client.DriveItems.Get(ctx, "1")
+		if err != nil {
+			t.Fatalf("DriveItems.Get returned error: %v", err)
+		}
+		return item.Name
+	}
+
+	get()
+	clock.now = clock.now.Add(4 * time.Second)
+	if name := get(); name != "file-1.txt" {
+		t.Errorf("DriveItems.Get returned %q within the window, want the shared response", name)
+	}
+
+	clock.now = clock.now.Add(2 * time.Second)
+	if name := get(); name != "file-2.txt" {
+		t.Errorf("DriveItems.Get returned %q after the window, want a new response", name)
+	}
+
+	if _, err := client.DriveItems.Rename(ctx, "", "1", "renamed.txt"); err != nil {
+		t.Fatalf("DriveItems.Rename returned error: %v", err)
+	}
+	if name := get(); name != "file-3.txt" {
+		t.Errorf("DriveItems.Get returned %q after a change, want a new response", name)
+	}
+}
+
+func TestClient_coalescingKey(t *testing.T) {
+	client := NewClient(nil, WithRequestCoalescing(0))
+
+	get, _ := client.NewRequest("GET", "me/drive/items/1", nil)
+	if _, ok := client.coalescingKey(get, false); !ok {
+		t.Error("A GET request cannot be shared")
+	}
+
+	download, _ := client.NewRequest("GET", "me/drive/items/1/content", nil)
+	download.Header.Set("Range", "bytes=0-99")
+	if _, ok := client.coalescingKey(download, false); ok {
+		t.Error("A download of a range can be shared")
+	}
+
+	patch, _ := client.NewRequest("PATCH", "me/drive/items/1", map[string]string{"name": "a"})
+	if _, ok := client.coalescingKey(patch, false); ok {
+		t.Error("A PATCH request can be shared")
+	}
+
+	french, _ := client.NewRequest("GET", "me/drive/items/1", nil)
+	french.Header.Set("Accept-Language", "fr")
+	key, _ := client.coalescingKey(get, false)
+	if frenchKey, _ := client.coalescingKey(french, false); frenchKey == key {
+		t.Error("Requests with different headers have the same key")
+	}
+}
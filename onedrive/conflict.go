@@ -0,0 +1,139 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// ErrConflictSkipped is returned by MoveWithOpts when its ConflictResolver has chosen to
+// skip the item.
+var ErrConflictSkipped = errors.New("skipped because of a name conflict")
+
+// ConflictAction represents what is done with an item whose name is already taken in its
+// destination folder, see ConflictResolver.
+type ConflictAction int
+
+const (
+	// ConflictFail returns the nameAlreadyExists error.
+	ConflictFail ConflictAction = iota
+	// ConflictReplace replaces the existing item.
+	ConflictReplace
+	// ConflictRename keeps both items; OneDrive gives the new one a name with a numbered
+	// suffix, e.g. "report 1.pdf".
+	ConflictRename
+	// ConflictSkip leaves the existing item and the item to upload or to move untouched.
+	ConflictSkip
+)
+
+// Conflict represents an item whose name is already taken in its destination folder.
+type Conflict struct {
+	// Name is the name of the uploaded file. It is empty for moves.
+	Name string
+	// LocalPath is the path of the uploaded file. It is empty for moves.
+	LocalPath string
+	// ItemId is the ID of the moved item. It is empty for uploads.
+	ItemId string
+	// DestinationParentFolderId is the ID of the folder holding the existing item.
+	DestinationParentFolderId string
+}
+
+// ConflictResolver decides, item by item, what is done when an upload or a move hits an
+// existing item with the same name, instead of a single conflict behavior for all of them.
+type ConflictResolver func(conflict Conflict) ConflictAction
+
+// conflictBehavior returns the conflict behavior of the requests retried after action.
+func (action ConflictAction) conflictBehavior() string {
+	switch action {
+	case ConflictReplace:
+		return "replace"
+	case ConflictRename:
+		return "rename"
+	}
+	return ""
+}
+
+// isNameConflict reports whether err is the error returned when the name of an item is
+// already taken.
+func isNameConflict(err error) bool {
+	oneDriveError, ok := err.(*Error)
+	return ok && oneDriveError.Code == "nameAlreadyExists"
+}
+
+// resolveConflict calls attempt with the "fail" conflict behavior, then, if the name is
+// already taken, asks resolve what to do and calls attempt again with the matching
+// conflict behavior. It returns whether the item has been skipped.
+func resolveConflict(resolve ConflictResolver, conflict Conflict, attempt func(conflictBehavior string) error) (bool, error) {
+	err := attempt("fail")
+	if err == nil || !isNameConflict(err) {
+		return false, err
+	}
+
+	action := resolve(conflict)
+	if action == ConflictSkip {
+		return true, nil
+	}
+
+	conflictBehavior := action.conflictBehavior()
+	if conflictBehavior == "" {
+		return false, err
+	}
+
+	return false, attempt(conflictBehavior)
+}
+
+// MoveOpts represents the options for moving an item by MoveWithOpts.
+type MoveOpts struct {
+	// ConflictResolver, if any, is called when the name of the item is already taken in
+	// the destination folder. By default, the move fails.
+	ConflictResolver ConflictResolver
+}
+
+// MoveWithOpts moves a drive item to a new parent folder in a drive of the authenticated
+// user with options, like Move. ErrConflictSkipped is returned when the ConflictResolver
+// skips the item.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_move?view=odsp-graph-online
+func (s *DriveItemsService) MoveWithOpts(ctx context.Context, driveId string, itemId string, destinationParentFolderId string, opts MoveOpts) (*MoveItemResponse, error) {
+	if opts.ConflictResolver == nil {
+		return s.Move(ctx, driveId, itemId, destinationParentFolderId)
+	}
+
+	conflict := Conflict{ItemId: itemId, DestinationParentFolderId: destinationParentFolderId}
+
+	var response *MoveItemResponse
+	skipped, err := resolveConflict(opts.ConflictResolver, conflict, func(conflictBehavior string) error {
+		var err error
+		response, err = s.move(ctx, driveId, itemId, destinationParentFolderId, conflictBehavior)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if skipped {
+		return nil, ErrConflictSkipped
+	}
+
+	return response, nil
+}
+
+// moveURL returns the relative URL of the request moving an item.
+func moveURL(driveId string, itemId string, conflictBehavior string) string {
+	apiURL := "me/drive/items/" + url.PathEscape(itemId)
+	if driveId != "" {
+		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId)
+	}
+
+	if conflictBehavior != "" {
+		apiURL += "?@microsoft.graph.conflictBehavior=" + conflictBehavior
+	}
+
+	return apiURL
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDriveItemsService_MoveWithOpts(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	var behaviors []string
+	mux.HandleFunc("/me/drive/items/item-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		behavior := r.URL.Query().Get("@microsoft.graph.conflictBehavior")
+		behaviors = append(behaviors, behavior)
+		if behavior == "fail" {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"error": {"code": "nameAlreadyExists", "message": "Name already exists"}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"id": "item-1", "name": "report 1.pdf", "parentReference": {"id": "folder-1"}}`)
+	})
+
+	var conflicts []Conflict
+	got, err := client.DriveItems.MoveWithOpts(context.Background(), "", "item-1", "folder-1", MoveOpts{
+		ConflictResolver: func(conflict Conflict) ConflictAction {
+			conflicts = append(conflicts, conflict)
+			return ConflictRename
+		},
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.MoveWithOpts returned error: %v", err)
+	}
+
+	if got.Name != "report 1.pdf" {
+		t.Errorf("DriveItems.MoveWithOpts returned %+v", got)
+	}
+	if want := []string{"fail", "rename"}; !reflect.DeepEqual(behaviors, want) {
+		t.Errorf("The conflict behaviors of the requests are %v, want %v", behaviors, want)
+	}
+	if want := []Conflict{{ItemId: "item-1", DestinationParentFolderId: "folder-1"}}; !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("The resolved conflicts are %+v, want %+v", conflicts, want)
+	}
+
+	_, err = client.DriveItems.MoveWithOpts(context.Background(), "", "item-1", "folder-1", MoveOpts{
+		ConflictResolver: func(conflict Conflict) ConflictAction {
+			return ConflictSkip
+		},
+	})
+	if err != ErrConflictSkipped {
+		t.Errorf("DriveItems.MoveWithOpts returned error %v, want ErrConflictSkipped", err)
+	}
+}
+
+func TestDriveItemsService_UploadMany_conflictResolver(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keepPath, replacePath := filepath.Join(dir, "keep.txt"), filepath.Join(dir, "replace.txt")
+	ioutil.WriteFile(keepPath, []byte("keep"), 0644)
+	ioutil.WriteFile(replacePath, []byte("replace"), 0644)
+
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "PUT")
+
+			switch r.URL.Query().Get("@microsoft.graph.conflictBehavior") {
+			case "fail":
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"error": {"code": "nameAlreadyExists", "message": "Name already exists"}}`)
+			case "replace":
+				fmt.Fprintf(w, `{"id": "id-%s", "name": %q, "file": {}}`, name, name)
+			default:
+				t.Errorf("Unexpected request %v", r.URL)
+			}
+		}
+	}
+	mux.HandleFunc("/me/drive/items/folder-1:/keep.txt:/content", handler("keep.txt"))
+	mux.HandleFunc("/me/drive/items/folder-1:/replace.txt:/content", handler("replace.txt"))
+
+	results, err := client.DriveItems.UploadMany(context.Background(), []string{keepPath, replacePath}, "folder-1", UploadManyOpts{
+		ConflictResolver: func(conflict Conflict) ConflictAction {
+			if conflict.Name == "keep.txt" {
+				return ConflictSkip
+			}
+			return ConflictReplace
+		},
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadMany returned error: %v", err)
+	}
+
+	if !results[0].Skipped || results[0].Item != nil {
+		t.Errorf("DriveItems.UploadMany returned %+v for keep.txt, want it skipped", results[0])
+	}
+	if results[1].Skipped || results[1].Item == nil || results[1].Item.Id != "id-replace.txt" {
+		t.Errorf("DriveItems.UploadMany returned %+v for replace.txt, want it replaced", results[1])
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	notFoundRetryInitialDelay = 200 * time.Millisecond
+	notFoundRetryMaxDelay     = 2 * time.Second
+)
+
+// WithNotFoundRetryAfterCreate makes the client retry requests about an item it created
+// less than window ago when OneDrive answers that the item is not found. Right after an
+// item is created, e.g. by an upload, getting or moving it may fail for a short while
+// because of replication lag.
+//
+// Requests about any other item are not retried.
+func WithNotFoundRetryAfterCreate(window time.Duration) ClientOption {
+	return func(c *Client) {
+		c.notFoundRetryWindow = window
+	}
+}
+
+// markCreated remembers that the item has just been created by the client, so that
+// requests about it are retried on 404 for a while, see WithNotFoundRetryAfterCreate.
+func (c *Client) markCreated(item *DriveItem) {
+	if c.notFoundRetryWindow <= 0 || item == nil || item.Id == "" {
+		return
+	}
+
+	c.createdItemsMu.Lock()
+	defer c.createdItemsMu.Unlock()
+
+	now := c.now()
+	if c.createdItems == nil {
+		c.createdItems = make(map[string]time.Time)
+	}
+	for itemId, createdAt := range c.createdItems {
+		if now.Sub(createdAt) > c.notFoundRetryWindow {
+			delete(c.createdItems, itemId)
+		}
+	}
+	c.createdItems[item.Id] = now
+}
+
+// notFoundRetryDeadline returns until when requests about the item should be retried on 404.
+func (c *Client) notFoundRetryDeadline(itemId string) (time.Time, bool) {
+	if c.notFoundRetryWindow <= 0 {
+		return time.Time{}, false
+	}
+
+	c.createdItemsMu.Lock()
+	defer c.createdItemsMu.Unlock()
+
+	createdAt, ok := c.createdItems[itemId]
+	if !ok {
+		return time.Time{}, false
+	}
+	return createdAt.Add(c.notFoundRetryWindow), true
+}
+
+// doItem sends an API request about an item like Do, but retries it with a short backoff
+// when the item has just been created by the client and OneDrive answers 404.
+func (c *Client) doItem(ctx context.Context, itemId string, req *http.Request, target interface{}) error {
+	err := c.Do(ctx, req, false, target)
+
+	deadline, ok := c.notFoundRetryDeadline(itemId)
+	if !ok {
+		return err
+	}
+
+	delay := notFoundRetryInitialDelay
+	for isNotFound(err) && c.now().Add(delay).Before(deadline) && (req.Body == nil || req.GetBody != nil) {
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+
+		retryReq := req.Clone(ctx)
+		if req.GetBody != nil {
+			retryReq.Body, err = req.GetBody()
+			if err != nil {
+				return err
+			}
+		}
+
+		err = c.Do(ctx, retryReq, false, target)
+
+		delay *= 2
+		if delay > notFoundRetryMaxDelay {
+			delay = notFoundRetryMaxDelay
+		}
+	}
+
+	return err
+}
+
+// isNotFound reports whether the error is OneDrive answering that the item is not found.
+func isNotFound(err error) bool {
+	oneDriveError, ok := err.(*Error)
+	return ok && oneDriveError.StatusCode == http.StatusNotFound
+}
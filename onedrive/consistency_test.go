@@ -0,0 +1,67 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_WithNotFoundRetryAfterCreate(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithNotFoundRetryAfterCreate(5 * time.Second)(client)
+
+	mux.HandleFunc("/me/drive/items/root/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "new-folder", "name": "New", "folder": {}}`)
+	})
+
+	getAttempts := 0
+	mux.HandleFunc("/me/drive/items/new-folder", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		getAttempts++
+		if getAttempts == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "Item not found"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "new-folder", "name": "New", "folder": {}}`)
+	})
+
+	unknownAttempts := 0
+	mux.HandleFunc("/me/drive/items/unknown", func(w http.ResponseWriter, r *http.Request) {
+		unknownAttempts++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "Item not found"}}`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.DriveItems.CreateNewFolder(ctx, "", "", "New"); err != nil {
+		t.Fatalf("DriveItems.CreateNewFolder returned error: %v", err)
+	}
+
+	if _, err := client.DriveItems.Get(ctx, "new-folder"); err != nil {
+		t.Errorf("DriveItems.Get returned error: %v", err)
+	}
+	if getAttempts != 2 {
+		t.Errorf("DriveItems.Get sent %d requests, want 2", getAttempts)
+	}
+
+	if _, err := client.DriveItems.Get(ctx, "unknown"); !isNotFound(err) {
+		t.Errorf("DriveItems.Get returned error %v, want item not found", err)
+	}
+	if unknownAttempts != 1 {
+		t.Errorf("DriveItems.Get sent %d requests for an item not created by the client, want 1", unknownAttempts)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// DriveRef represents a destination folder of CopyToDrives.
+type DriveRef struct {
+	// DriveID is the ID of the destination drive. If it is empty, it means the default
+	// drive of the authenticated user.
+	DriveID string
+	// FolderID is the ID of the destination folder.
+	FolderID string
+	// Name is the name of the copy. By default, it is the name of the source item.
+	Name string
+}
+
+// CopyToDrivesOpts represents the options for distributing an item by CopyToDrives.
+type CopyToDrivesOpts struct {
+	// SourceDriveID is the ID of the drive of the source item. If it is empty, it means
+	// the default drive of the authenticated user.
+	SourceDriveID string
+	// Concurrency is the maximum number of destinations handled at the same time. By
+	// default, it is 8.
+	Concurrency int
+}
+
+// CopyToDrivesResult represents the outcome of CopyToDrives for a destination.
+type CopyToDrivesResult struct {
+	Destination DriveRef
+	// Copy is the monitor of the server-side copy, when OneDrive accepted one.
+	Copy *CopyItemResponse
+	// Uploaded is the new item, when the file had to be uploaded instead.
+	Uploaded *DriveItem
+	Err      error
+}
+
+// CopyToDrives distributes a file to several folders, possibly in different drives, at the
+// same time. A server-side copy is requested for every destination; when OneDrive refuses it,
+// e.g. between drives of different kinds, the file is downloaded once and uploaded to the
+// destination instead.
+//
+// Server-side copies complete asynchronously, see CopyToDrivesResult.Copy. The outcome of
+// every destination is reported in the results, in the same order as the destinations.
+// An error is only returned when the source item cannot be retrieved.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_copy?view=odsp-graph-online
+func (s *DriveItemsService) CopyToDrives(ctx context.Context, itemId string, destinations []DriveRef, opts CopyToDrivesOpts) ([]CopyToDrivesResult, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item to be copied.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(itemId)
+	if opts.SourceDriveID != "" {
+		apiURL = "me/drives/" + url.PathEscape(opts.SourceDriveID) + "/items/" + url.PathEscape(itemId)
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var source *DriveItem
+	if err := s.client.doItem(ctx, itemId, req, &source); err != nil {
+		return nil, err
+	}
+
+	if source.File == nil {
+		return nil, errors.New("Only file is allowed to be distributed here.")
+	}
+
+	results := make([]CopyToDrivesResult, len(destinations))
+	for i, destination := range destinations {
+		if destination.Name == "" {
+			destination.Name = source.Name
+		}
+		results[i].Destination = destination
+	}
+
+	download := &sourceDownload{service: s, item: source}
+	defer download.remove()
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTransferConcurrency
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := &results[i]
+				destination := result.Destination
+				if destination.FolderID == "" {
+					result.Err = errors.New("Please provide the destination, i.e. the ID of the new parent folder for the item.")
+					continue
+				}
+
+				result.Copy, result.Err = s.Copy(ctx, opts.SourceDriveID, itemId, destination.DriveID, destination.FolderID, destination.Name)
+				if !isCopyRefused(result.Err) {
+					continue
+				}
+
+				result.Uploaded, result.Err = download.uploadTo(ctx, destination)
+			}
+		}()
+	}
+
+	for i := range results {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := range results {
+		if results[i].Err == nil && results[i].Copy == nil && results[i].Uploaded == nil {
+			results[i].Err = ctx.Err()
+		}
+	}
+
+	return results, nil
+}
+
+// isCopyRefused reports whether OneDrive refused a server-side copy, as opposed to the copy
+// failing for another reason such as the source item missing.
+func isCopyRefused(err error) bool {
+	oneDriveError, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+
+	switch oneDriveError.StatusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusNotImplemented:
+		return true
+	}
+
+	return false
+}
+
+// sourceDownload downloads the source item of CopyToDrives into a temporary file the first
+// time it is uploaded, and shares that file between the uploads.
+type sourceDownload struct {
+	service *DriveItemsService
+	item    *DriveItem
+
+	once     sync.Once
+	filePath string
+	err      error
+}
+
+func (d *sourceDownload) download(ctx context.Context) (string, error) {
+	d.once.Do(func() {
+		item := d.item
+		if item.DownloadURL == "" {
+			d.err = errors.New("The source item has no download URL.")
+			return
+		}
+
+		tempFile, err := ioutil.TempFile("", "onedrive-copy-*")
+		if err != nil {
+			d.err = err
+			return
+		}
+		tempFile.Close()
+		d.filePath = tempFile.Name()
+
+		d.err = d.service.downloadToFile(ctx, item, d.filePath)
+	})
+
+	return d.filePath, d.err
+}
+
+func (d *sourceDownload) uploadTo(ctx context.Context, destination DriveRef) (*DriveItem, error) {
+	filePath, err := d.download(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.service.uploadFile(ctx, destination.DriveID, destination.FolderID, destination.Name, file, fileInfo.Size(), "fail", nil)
+}
+
+func (d *sourceDownload) remove() {
+	if d.filePath != "" {
+		os.Remove(d.filePath)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDriveItemsService_CopyToDrives(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/source-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprintf(w, `{"id": "source-1", "name": "report.pdf", "size": 7, "file": {}, "@microsoft.graph.downloadUrl": "%s/download/source-1"}`, serverURL+baseURLPath)
+	})
+	mux.HandleFunc("/me/drive/items/source-1/copy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), `"driveId":"personal-1"`) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": "invalidRequest", "message": "Cross-drive copy is not supported"}}`)
+			return
+		}
+
+		w.Header().Set("Location", "https://example.com/monitor")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	downloads := 0
+	mux.HandleFunc("/download/source-1", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		fmt.Fprint(w, "content")
+	})
+	mux.HandleFunc("/me/drives/personal-1/items/folder-b:/report.pdf:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != "content" {
+			t.Errorf("Uploaded content: %q, want %q", body, "content")
+		}
+
+		fmt.Fprint(w, `{"id": "uploaded-1", "name": "report.pdf"}`)
+	})
+
+	ctx := context.Background()
+	results, err := client.DriveItems.CopyToDrives(ctx, "source-1", []DriveRef{
+		{DriveID: "business-1", FolderID: "folder-a"},
+		{DriveID: "personal-1", FolderID: "folder-b"},
+		{DriveID: "business-2"},
+	}, CopyToDrivesOpts{})
+	if err != nil {
+		t.Fatalf("DriveItems.CopyToDrives returned error: %v", err)
+	}
+
+	if results[0].Err != nil || results[0].Copy == nil || results[0].Copy.Location != "https://example.com/monitor" {
+		t.Errorf("Result of the server-side copy: %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Uploaded == nil || results[1].Uploaded.Id != "uploaded-1" {
+		t.Errorf("Result of the upload fallback: %+v", results[1])
+	}
+	if results[2].Err == nil {
+		t.Errorf("Result of the destination without folder has no error")
+	}
+	if downloads != 1 {
+		t.Errorf("Source was downloaded %d times, want 1", downloads)
+	}
+}
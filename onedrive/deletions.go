@@ -0,0 +1,132 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// ListWithDeletionsOpts represents the options for listing the changes of the children of a
+// folder by ListWithDeletionsWithOpts.
+type ListWithDeletionsOpts struct {
+	// KnownChildIds are the IDs of the children of the folder known from the previous
+	// listings. The items among them which are listed with another parent, having been
+	// moved out of the folder, are reported as deleted: their Deleted facet is set, with an
+	// empty state, and their ParentReference is their new parent.
+	KnownChildIds map[string]bool
+}
+
+// ListWithDeletions lists the children of a folder in the default drive of the
+// authenticated user which have changed since deltaLink, including the deleted ones, whose
+// Deleted facet is set, e.g. for jobs reconciling a local copy of the folder. When deltaLink
+// is empty, all the current children are listed. The DeltaLink of the response is to be
+// passed to the next call.
+//
+// The changes are listed by a delta query on the folder. The drives which only support
+// delta queries on their root, such as the ones of OneDrive for Business, are queried from
+// their root instead, which is slower on large drives.
+//
+// The children moved out of the folder are not listed, see ListWithDeletionsWithOpts.
+//
+// If folderId is empty, it means the children of the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delta?view=odsp-graph-online
+func (s *DriveItemsService) ListWithDeletions(ctx context.Context, folderId string, deltaLink string) (*OneDriveDriveItemsResponse, error) {
+	return s.ListWithDeletionsWithOpts(ctx, folderId, deltaLink, ListWithDeletionsOpts{})
+}
+
+// ListWithDeletionsWithOpts lists the children of a folder which have changed since
+// deltaLink with options, like ListWithDeletions. With opts.KnownChildIds, the children
+// moved out of the folder are reported as deleted too.
+//
+// If folderId is empty, it means the children of the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delta?view=odsp-graph-online
+func (s *DriveItemsService) ListWithDeletionsWithOpts(ctx context.Context, folderId string, deltaLink string, opts ListWithDeletionsOpts) (*OneDriveDriveItemsResponse, error) {
+	if folderId == "" {
+		req, err := s.client.NewRequest("GET", "me/drive/root?$select=id", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var root *DriveItem
+		if err := s.client.Do(ctx, req, false, &root); err != nil {
+			return nil, err
+		}
+		if root == nil || root.Id == "" {
+			return nil, errors.New("The root of the drive has no ID.")
+		}
+		folderId = root.Id
+	}
+
+	apiURL := deltaLink
+	if apiURL == "" {
+		apiURL = "me/drive/items/" + url.PathEscape(folderId) + "/delta"
+	}
+
+	response, err := s.listChildrenChanges(ctx, folderId, apiURL, opts.KnownChildIds)
+	if deltaLink == "" && (isStatus(err, http.StatusBadRequest) || isStatus(err, http.StatusNotImplemented)) {
+		response, err = s.listChildrenChanges(ctx, folderId, "me/drive/root/delta", opts.KnownChildIds)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// listChildrenChanges goes through the pages of a delta query starting at apiURL, and keeps
+// the last state of the children of a folder, and of the known children moved out of it.
+func (s *DriveItemsService) listChildrenChanges(ctx context.Context, folderId string, apiURL string, knownChildIds map[string]bool) (*OneDriveDriveItemsResponse, error) {
+	response := &OneDriveDriveItemsResponse{}
+	indexes := make(map[string]int)
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page *DeltaResponse
+		if err := s.client.Do(ctx, req, false, &page); err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+
+		for _, item := range page.DriveItems {
+			if item == nil || item.ParentReference == nil {
+				continue
+			}
+			if item.ParentReference.Id != folderId {
+				if !knownChildIds[item.Id] {
+					continue
+				}
+				moved := *item
+				if moved.Deleted == nil {
+					moved.Deleted = &DriveItemDeleted{}
+				}
+				item = &moved
+			}
+
+			// An item changed several times during the enumeration is listed several times.
+			if i, ok := indexes[item.Id]; ok {
+				response.DriveItems[i] = item
+				continue
+			}
+			indexes[item.Id] = len(response.DriveItems)
+			response.DriveItems = append(response.DriveItems, item)
+		}
+
+		response.DeltaLink = page.DeltaLink
+		apiURL = page.NextLink
+	}
+
+	response.Count = len(response.DriveItems)
+	return response, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_ListWithDeletions(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/f/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("token") {
+		case "":
+			fmt.Fprintf(w, `{"value":[
+				{"id":"f","name":"Folder","folder":{},"parentReference":{"id":"root"}},
+				{"id":"1","name":"a.txt","file":{},"parentReference":{"id":"f"}},
+				{"id":"2","name":"b.txt","file":{},"parentReference":{"id":"sub"}}
+			],"@odata.nextLink":"%[1]s/me/drive/items/f/delta?token=page2"}`, serverURL+baseURLPath)
+		case "page2":
+			fmt.Fprintf(w, `{"value":[
+				{"id":"3","deleted":{"state":"deleted"},"parentReference":{"id":"f"}},
+				{"id":"1","name":"a2.txt","file":{},"parentReference":{"id":"f"}}
+			],"@odata.deltaLink":"%[1]s/me/drive/items/f/delta?token=next"}`, serverURL+baseURLPath)
+		}
+	})
+
+	response, err := client.DriveItems.ListWithDeletions(context.Background(), "f", "")
+	if err != nil {
+		t.Fatalf("DriveItems.ListWithDeletions returned error: %v", err)
+	}
+
+	if response.Count != 2 || response.DriveItems[0].Name != "a2.txt" || response.DriveItems[1].Id != "3" || response.DriveItems[1].Deleted == nil {
+		t.Errorf("DriveItems.ListWithDeletions returned %+v, want a2.txt then the deleted item 3", response.DriveItems)
+	}
+	if want := serverURL + baseURLPath + "/me/drive/items/f/delta?token=next"; response.DeltaLink != want {
+		t.Errorf("DriveItems.ListWithDeletions returned delta link %q, want %q", response.DeltaLink, want)
+	}
+}
+
+func TestDriveItemsService_ListWithDeletions_rootDeltaOnly(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"r"}`)
+	})
+	mux.HandleFunc("/me/drive/items/r/delta", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"code":"invalidRequest","message":"Delta is only supported on the root."}}`)
+	})
+	mux.HandleFunc("/me/drive/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[
+			{"id":"1","name":"a.txt","file":{},"parentReference":{"id":"r"}},
+			{"id":"2","name":"b.txt","file":{},"parentReference":{"id":"sub"}}
+		],"@odata.deltaLink":"next"}`)
+	})
+
+	response, err := client.DriveItems.ListWithDeletions(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("DriveItems.ListWithDeletions returned error: %v", err)
+	}
+
+	if response.Count != 1 || response.DriveItems[0].Id != "1" || response.DeltaLink != "next" {
+		t.Errorf("DriveItems.ListWithDeletions returned %+v and delta link %q", response.DriveItems, response.DeltaLink)
+	}
+}
+
+func TestDriveItemsService_ListWithDeletionsWithOpts_movedOut(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/f/delta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[
+			{"id":"1","name":"a.txt","file":{},"parentReference":{"id":"elsewhere"}},
+			{"id":"2","name":"b.txt","file":{},"parentReference":{"id":"elsewhere"}}
+		],"@odata.deltaLink":"next"}`)
+	})
+
+	opts := ListWithDeletionsOpts{KnownChildIds: map[string]bool{"1": true}}
+	response, err := client.DriveItems.ListWithDeletionsWithOpts(context.Background(), "f", "", opts)
+	if err != nil {
+		t.Fatalf("DriveItems.ListWithDeletionsWithOpts returned error: %v", err)
+	}
+
+	if response.Count != 1 || response.DriveItems[0].Id != "1" || response.DriveItems[0].Deleted == nil {
+		t.Errorf("DriveItems.ListWithDeletionsWithOpts returned %+v, want item 1 deleted", response.DriveItems)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"net/url"
+)
+
+// DriveItemDeleted indicates that a drive item returned by Delta has been deleted.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/deleted?view=odsp-graph-online
+type DriveItemDeleted struct {
+	State string `json:"state"`
+}
+
+// DeltaResponse represents a page of the changes returned by Delta.
+type DeltaResponse struct {
+	DriveItems []*DriveItem `json:"value"`
+	// NextLink is the URL of the next page of changes, if any.
+	NextLink string `json:"@odata.nextLink"`
+	// DeltaLink is the URL of the changes which will happen after the last page. It is only
+	// returned on the last page.
+	DeltaLink string `json:"@odata.deltaLink"`
+}
+
+// HasMore reports whether the changes are followed by another page, see NextLink.
+func (response *DeltaResponse) HasMore() bool {
+	return response.NextLink != ""
+}
+
+// Delta lists a page of the changes of a drive of the authenticated user. The first page of
+// a full enumeration is listed when link is empty; otherwise, link is the NextLink of the
+// previous page or a DeltaLink saved at the end of an earlier enumeration. See DeltaTracker
+// to follow the changes across runs.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user. A DeltaLink which has expired is answered with a 410 Gone error,
+// after which a full enumeration is needed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delta?view=odsp-graph-online
+func (s *DriveItemsService) Delta(ctx context.Context, driveId string, link string) (*DeltaResponse, error) {
+	apiURL := link
+	if apiURL == "" {
+		apiURL = "me/drive/root/delta"
+		if driveId != "" {
+			apiURL = "me/drives/" + url.PathEscape(driveId) + "/root/delta"
+		}
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *DeltaResponse
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_Delta(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/drive-1/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"value": [
+			{"id": "1", "name": "a.txt", "file": {}},
+			{"id": "2", "deleted": {"state": "deleted"}}
+		], "@odata.deltaLink": "https://graph.microsoft.com/v1.0/me/drives/drive-1/root/delta?token=abc"}`)
+	})
+
+	got, err := client.DriveItems.Delta(context.Background(), "drive-1", "")
+	if err != nil {
+		t.Fatalf("DriveItems.Delta returned error: %v", err)
+	}
+
+	if len(got.DriveItems) != 2 || got.DriveItems[0].Deleted != nil || got.DriveItems[1].Deleted == nil {
+		t.Errorf("DriveItems.Delta returned %+v, want a changed and a deleted item", got.DriveItems)
+	}
+	if got.DeltaLink == "" || got.NextLink != "" {
+		t.Errorf("DriveItems.Delta returned next link %q and delta link %q, want only a delta link", got.NextLink, got.DeltaLink)
+	}
+}
+
+func TestDeltaResponse_HasMore(t *testing.T) {
+	tests := []struct {
+		response DeltaResponse
+		want     bool
+	}{
+		{DeltaResponse{NextLink: "https://graph.microsoft.com/v1.0/me/drive/root/delta?token=next"}, true},
+		{DeltaResponse{DeltaLink: "https://graph.microsoft.com/v1.0/me/drive/root/delta?token=latest"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.response.HasMore(); got != tt.want {
+			t.Errorf("HasMore of %+v returned %v, want %v", tt.response, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+)
+
+// Shortcut represents a shared folder added to a drive tracked by a DeltaTracker, i.e. an
+// item of the drive whose RemoteItem is a folder stored in the drive of another user.
+type Shortcut struct {
+	// ItemId is the ID of the shortcut item in the tracked drive, which stands for the shared
+	// folder in the local tree.
+	ItemId string `json:"itemId"`
+	// DriveId and FolderId identify the shared folder in the drive holding it.
+	DriveId  string `json:"driveId"`
+	FolderId string `json:"folderId"`
+	// DeltaLink is the delta link of the shared folder, empty until it has been enumerated.
+	DeltaLink string `json:"deltaLink,omitempty"`
+}
+
+// ShortcutStore persists the shortcuts followed by DeltaTracker.SyncWithShortcuts between
+// runs, e.g. in a file or a database.
+type ShortcutStore interface {
+	// Load returns the saved shortcuts, or none when there are none.
+	Load(ctx context.Context) ([]Shortcut, error)
+	// Save replaces the saved shortcuts.
+	Save(ctx context.Context, shortcuts []Shortcut) error
+}
+
+// ShortcutFailure represents a shortcut whose changes could not be listed by
+// DeltaTracker.SyncWithShortcuts.
+type ShortcutFailure struct {
+	Shortcut Shortcut
+	// Forgotten tells whether the shortcut is no longer followed, because its shared folder
+	// can no longer be accessed (403 Forbidden or 404 Not Found), e.g. it has been unshared
+	// or deleted. Otherwise, its changes are listed again by the next sync.
+	Forgotten bool
+	Err       error
+}
+
+// ShortcutSyncError is returned by DeltaTracker.SyncWithShortcuts when the changes of some
+// of the shared folders could not be listed. The changes of the drive and of the other
+// shared folders have been synced and saved nonetheless.
+type ShortcutSyncError struct {
+	Failed []ShortcutFailure
+}
+
+func (e *ShortcutSyncError) Error() string {
+	if len(e.Failed) == 1 {
+		return fmt.Sprintf("The changes of 1 shared folder could not be listed: %s: %v", e.Failed[0].Shortcut.ItemId, e.Failed[0].Err)
+	}
+	return fmt.Sprintf("The changes of %d shared folders could not be listed, first %s: %v", len(e.Failed), e.Failed[0].Shortcut.ItemId, e.Failed[0].Err)
+}
+
+// FileShortcutStore is a ShortcutStore keeping the shortcuts in a local JSON file.
+type FileShortcutStore struct {
+	Path string
+}
+
+// Load implements ShortcutStore.
+func (store *FileShortcutStore) Load(ctx context.Context) ([]Shortcut, error) {
+	data, err := ioutil.ReadFile(store.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var shortcuts []Shortcut
+	if err := json.Unmarshal(data, &shortcuts); err != nil {
+		return nil, err
+	}
+	return shortcuts, nil
+}
+
+// Save implements ShortcutStore. The file is replaced atomically, like by
+// FileDeltaTokenStore.
+func (store *FileShortcutStore) Save(ctx context.Context, shortcuts []Shortcut) error {
+	data, err := json.MarshalIndent(shortcuts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(store.Path, data)
+}
+
+// SyncWithShortcuts syncs like Sync, and also follows the shortcuts to shared folders added
+// to the drive, which a delta query of the drive reports as items without children. The
+// changes of every shared folder are listed from the drive holding it, after the changes of
+// the drive, and fn is called for them with the shortcut they are reached through. The
+// children of the shared folder have the folder as parent, i.e. Shortcut.FolderId, which
+// stands for the shortcut item in the local tree; the shared folder itself is not reported.
+// fn is called with a nil shortcut for the items of the drive itself.
+//
+// The shortcuts and their delta links are saved in shortcuts along with the delta link of
+// the drive. A shortcut is forgotten once its item is deleted from the drive, which fn is
+// called for as for any other deleted item.
+//
+// A shared folder whose changes cannot be listed does not stop the sync: the shortcut is
+// forgotten if the folder can no longer be accessed, or kept with its previous delta link
+// otherwise, and a *ShortcutSyncError listing such shortcuts is returned once the delta
+// links have been saved. An error returned by fn stops the sync.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delta?view=odsp-graph-online#scanning-folders-shared-with-the-signed-in-user
+func (t *DeltaTracker) SyncWithShortcuts(ctx context.Context, shortcuts ShortcutStore, fn func(item *DriveItem, shortcut *Shortcut) error) error {
+	if t.store == nil {
+		return errors.New("Please provide the store of the delta link.")
+	}
+
+	if shortcuts == nil {
+		return errors.New("Please provide the store of the shortcuts.")
+	}
+
+	if fn == nil {
+		return errors.New("Please provide the function to call for every item.")
+	}
+
+	link, err := t.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	saved, err := shortcuts.Load(ctx)
+	if err != nil {
+		return err
+	}
+	followed := make(map[string]*Shortcut)
+	for i := range saved {
+		followed[saved[i].ItemId] = &saved[i]
+	}
+
+	deltaLink, err := listChanges(link, func(link string) (*DeltaResponse, error) {
+		return t.service.Delta(ctx, t.driveId, link)
+	}, func(item *DriveItem) error {
+		if item != nil {
+			t.service.client.checkPath(item)
+			trackShortcut(followed, item)
+		}
+		return fn(item, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	itemIds := make([]string, 0, len(followed))
+	for itemId := range followed {
+		itemIds = append(itemIds, itemId)
+	}
+	sort.Strings(itemIds)
+
+	updated := make([]Shortcut, 0, len(itemIds))
+	var failed []ShortcutFailure
+	for _, itemId := range itemIds {
+		shortcut := followed[itemId]
+
+		var fnErr error
+		shortcutDeltaLink, err := listChanges(shortcut.DeltaLink, func(link string) (*DeltaResponse, error) {
+			return t.service.folderDelta(ctx, shortcut.DriveId, shortcut.FolderId, link)
+		}, func(item *DriveItem) error {
+			if item == nil || item.Id == shortcut.FolderId {
+				return nil
+			}
+			t.service.client.checkPath(item)
+			fnErr = fn(item, shortcut)
+			return fnErr
+		})
+		if fnErr != nil {
+			return fnErr
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			failure := ShortcutFailure{
+				Shortcut:  *shortcut,
+				Forgotten: isStatus(err, http.StatusForbidden) || isStatus(err, http.StatusNotFound),
+				Err:       err,
+			}
+			failed = append(failed, failure)
+			if !failure.Forgotten {
+				updated = append(updated, *shortcut)
+			}
+			continue
+		}
+
+		shortcut.DeltaLink = shortcutDeltaLink
+		updated = append(updated, *shortcut)
+	}
+
+	// The shortcuts are saved first: if the delta link of the drive could not be saved
+	// after them, the changes of the drive are listed again, which fn tolerates.
+	if err := shortcuts.Save(ctx, updated); err != nil {
+		return err
+	}
+
+	if err := t.store.Save(ctx, deltaLink); err != nil {
+		return err
+	}
+
+	if len(failed) > 0 {
+		return &ShortcutSyncError{Failed: failed}
+	}
+
+	return nil
+}
+
+// trackShortcut updates the followed shortcuts with a change of the tracked drive.
+func trackShortcut(followed map[string]*Shortcut, item *DriveItem) {
+	if item.Deleted != nil {
+		delete(followed, item.Id)
+		return
+	}
+
+	remote := item.RemoteItem
+	if remote == nil || remote.Folder == nil || remote.ParentReference == nil || remote.ParentReference.DriveId == "" {
+		return
+	}
+
+	shortcut, ok := followed[item.Id]
+	if ok && shortcut.DriveId == remote.ParentReference.DriveId && shortcut.FolderId == remote.Id {
+		return
+	}
+
+	// A new shortcut, or one which now points to another folder, is enumerated from the start.
+	followed[item.Id] = &Shortcut{ItemId: item.Id, DriveId: remote.ParentReference.DriveId, FolderId: remote.Id}
+}
+
+// folderDelta lists a page of the changes of a folder and its descendants in any drive the
+// authenticated user has access to, like Delta.
+func (s *DriveItemsService) folderDelta(ctx context.Context, driveId string, folderId string, link string) (*DeltaResponse, error) {
+	apiURL := link
+	if apiURL == "" {
+		apiURL = "drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(folderId) + "/delta"
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *DeltaResponse
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDeltaTracker_SyncWithShortcuts(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	mux.HandleFunc("/me/drive/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("token") {
+		case "":
+			fmt.Fprintf(w, `{"value": [
+				{"id": "root", "folder": {}},
+				{"id": "shortcut", "name": "Team", "remoteItem": {"id": "shared", "folder": {"childCount": 1}, "parentReference": {"driveId": "other"}}}
+			], "@odata.deltaLink": "%sme/drive/root/delta?token=1"}`, client.BaseURL)
+		case "1":
+			fmt.Fprintf(w, `{"value": [], "@odata.deltaLink": "%sme/drive/root/delta?token=2"}`, client.BaseURL)
+		case "2":
+			fmt.Fprintf(w, `{"value": [{"id": "shortcut", "deleted": {}}], "@odata.deltaLink": "%sme/drive/root/delta?token=3"}`, client.BaseURL)
+		}
+	})
+	mux.HandleFunc("/drives/other/items/shared/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("token") {
+		case "":
+			fmt.Fprintf(w, `{"value": [
+				{"id": "shared", "name": "Team", "folder": {}},
+				{"id": "plan", "name": "plan.docx", "file": {}, "parentReference": {"driveId": "other", "id": "shared"}}
+			], "@odata.deltaLink": "%sdrives/other/items/shared/delta?token=1"}`, client.BaseURL)
+		case "1":
+			fmt.Fprintf(w, `{"value": [{"id": "budget", "name": "budget.xlsx", "file": {}, "parentReference": {"driveId": "other", "id": "shared"}}], "@odata.deltaLink": "%sdrives/other/items/shared/delta?token=2"}`, client.BaseURL)
+		default:
+			t.Errorf("The shared folder is followed after the deletion of its shortcut")
+		}
+	})
+
+	ctx := context.Background()
+	tracker := NewDeltaTracker(client, "", &FileDeltaTokenStore{Path: filepath.Join(dir, "delta")})
+	store := &FileShortcutStore{Path: filepath.Join(dir, "shortcuts.json")}
+	sync := func() []string {
+		var changes []string
+		if err := tracker.SyncWithShortcuts(ctx, store, func(item *DriveItem, shortcut *Shortcut) error {
+			if shortcut != nil {
+				changes = append(changes, shortcut.ItemId+"/"+item.Id)
+			} else {
+				changes = append(changes, item.Id)
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("DeltaTracker.SyncWithShortcuts returned error: %v", err)
+		}
+		return changes
+	}
+
+	if got, want := sync(), []string{"root", "shortcut", "shortcut/plan"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("First DeltaTracker.SyncWithShortcuts returned %v, want %v", got, want)
+	}
+
+	// The shortcut has not changed, but the shared folder is still followed after a restart.
+	if got, want := sync(), []string{"shortcut/budget"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Second DeltaTracker.SyncWithShortcuts returned %v, want %v", got, want)
+	}
+
+	if got, want := sync(), []string{"shortcut"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DeltaTracker.SyncWithShortcuts after the deletion returned %v, want %v", got, want)
+	}
+	if shortcuts, err := store.Load(ctx); err != nil || len(shortcuts) != 0 {
+		t.Errorf("FileShortcutStore.Load returned %v, %v, want no shortcut", shortcuts, err)
+	}
+}
+
+func TestDeltaTracker_SyncWithShortcuts_failingShortcut(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"value": [
+			{"id": "unshared", "name": "Old", "remoteItem": {"id": "gone", "folder": {}, "parentReference": {"driveId": "other"}}},
+			{"id": "busy", "name": "Busy", "remoteItem": {"id": "slow", "folder": {}, "parentReference": {"driveId": "other"}}},
+			{"id": "team", "name": "Team", "remoteItem": {"id": "shared", "folder": {}, "parentReference": {"driveId": "other"}}}
+		], "@odata.deltaLink": "%sme/drive/root/delta?token=1"}`, client.BaseURL)
+	})
+	mux.HandleFunc("/drives/other/items/gone/delta", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"code": "accessDenied", "message": "Access denied"}}`)
+	})
+	mux.HandleFunc("/drives/other/items/slow/delta", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error": {"code": "generalException", "message": "Try again"}}`)
+	})
+	mux.HandleFunc("/drives/other/items/shared/delta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"value": [], "@odata.deltaLink": "%sdrives/other/items/shared/delta?token=1"}`, client.BaseURL)
+	})
+
+	tokens := &memoryDeltaTokenStore{}
+	tracker := NewDeltaTracker(client, "", tokens)
+	shortcuts := &memoryShortcutStore{}
+	err := tracker.SyncWithShortcuts(context.Background(), shortcuts, func(item *DriveItem, shortcut *Shortcut) error {
+		return nil
+	})
+
+	syncErr, ok := err.(*ShortcutSyncError)
+	if !ok || len(syncErr.Failed) != 2 {
+		t.Fatalf("DeltaTracker.SyncWithShortcuts returned error %v, want a *ShortcutSyncError of 2 shortcuts", err)
+	}
+	if got := syncErr.Failed[0]; got.Shortcut.ItemId != "busy" || got.Forgotten {
+		t.Errorf("The first failure is %+v, want busy kept", got)
+	}
+	if got := syncErr.Failed[1]; got.Shortcut.ItemId != "unshared" || !got.Forgotten {
+		t.Errorf("The second failure is %+v, want unshared forgotten", got)
+	}
+
+	if want := client.BaseURL.String() + "me/drive/root/delta?token=1"; tokens.deltaLink != want {
+		t.Errorf("The saved delta link is %q, want %q", tokens.deltaLink, want)
+	}
+	var saved []string
+	for _, shortcut := range shortcuts.shortcuts {
+		saved = append(saved, shortcut.ItemId+" "+shortcut.DeltaLink)
+	}
+	if want := []string{"busy ", "team " + client.BaseURL.String() + "drives/other/items/shared/delta?token=1"}; !reflect.DeepEqual(saved, want) {
+		t.Errorf("The saved shortcuts are %q, want %q", saved, want)
+	}
+}
+
+type memoryShortcutStore struct {
+	shortcuts []Shortcut
+}
+
+func (store *memoryShortcutStore) Load(ctx context.Context) ([]Shortcut, error) {
+	return store.shortcuts, nil
+}
+
+func (store *memoryShortcutStore) Save(ctx context.Context, shortcuts []Shortcut) error {
+	store.shortcuts = shortcuts
+	return nil
+}
+
+func TestFileShortcutStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	store := &FileShortcutStore{Path: filepath.Join(dir, "shortcuts.json")}
+	if shortcuts, err := store.Load(ctx); err != nil || shortcuts != nil {
+		t.Errorf("FileShortcutStore.Load returned %v, %v for a missing file", shortcuts, err)
+	}
+
+	want := []Shortcut{{ItemId: "1", DriveId: "d", FolderId: "f", DeltaLink: "https://example.com/delta"}}
+	if err := store.Save(ctx, want); err != nil {
+		t.Fatalf("FileShortcutStore.Save returned error: %v", err)
+	}
+	if got, err := store.Load(ctx); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("FileShortcutStore.Load returned %v, %v, want %v", got, err, want)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DeltaTokenStore persists the delta link of a DeltaTracker between runs, e.g. in a file or
+// a database.
+type DeltaTokenStore interface {
+	// Load returns the saved delta link, or an empty string when there is none.
+	Load(ctx context.Context) (string, error)
+	// Save replaces the saved delta link.
+	Save(ctx context.Context, deltaLink string) error
+}
+
+// FileDeltaTokenStore is a DeltaTokenStore keeping the delta link in a local file.
+type FileDeltaTokenStore struct {
+	Path string
+}
+
+// Load implements DeltaTokenStore.
+func (store *FileDeltaTokenStore) Load(ctx context.Context) (string, error) {
+	data, err := ioutil.ReadFile(store.Path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Save implements DeltaTokenStore. The file is replaced atomically, so an interruption
+// never leaves a truncated delta link behind.
+func (store *FileDeltaTokenStore) Save(ctx context.Context, deltaLink string) error {
+	return replaceFile(store.Path, []byte(deltaLink))
+}
+
+// replaceFile replaces the content of a file atomically, by writing a temporary file next to
+// it first.
+func replaceFile(path string, data []byte) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
+
+// DeltaTracker follows the changes of a drive across runs, persisting the delta link in a
+// DeltaTokenStore so that a restarted daemon goes on from where it stopped instead of
+// enumerating the whole drive again.
+type DeltaTracker struct {
+	service *DriveItemsService
+	driveId string
+	store   DeltaTokenStore
+}
+
+// NewDeltaTracker returns a DeltaTracker of a drive of the authenticated user.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func NewDeltaTracker(client *Client, driveId string, store DeltaTokenStore) *DeltaTracker {
+	return &DeltaTracker{service: client.DriveItems, driveId: driveId, store: store}
+}
+
+// Sync calls fn for every item changed since the last successful Sync, or for every item of
+// the drive on the first run, then saves the new delta link. When fn returns an error, the
+// sync stops and the delta link is not saved, so the changes are listed again next time.
+//
+// When OneDrive answers that the saved delta link has expired, the drive is enumerated
+// again from the start, so fn must tolerate items it has already seen.
+//
+// The shared folders added to the drive are listed without their content, see
+// SyncWithShortcuts.
+//
+// The paths cached by WithPathCache of the items reported as changed or deleted are
+// forgotten, as are the paths under them.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delta?view=odsp-graph-online
+func (t *DeltaTracker) Sync(ctx context.Context, fn func(*DriveItem) error) error {
+	if t.store == nil {
+		return errors.New("Please provide the store of the delta link.")
+	}
+
+	if fn == nil {
+		return errors.New("Please provide the function to call for every item.")
+	}
+
+	link, err := t.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	deltaLink, err := listChanges(link, func(link string) (*DeltaResponse, error) {
+		return t.service.Delta(ctx, t.driveId, link)
+	}, func(item *DriveItem) error {
+		if item != nil {
+			t.service.client.checkPath(item)
+		}
+		return fn(item)
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.store.Save(ctx, deltaLink)
+}
+
+// listChanges calls fn for every change of the pages returned by listPage from link, and
+// returns the delta link of the last page. listPage lists the first page of a full
+// enumeration when link is empty, which is also done when link has expired.
+func listChanges(link string, listPage func(link string) (*DeltaResponse, error), fn func(*DriveItem) error) (string, error) {
+	for {
+		response, err := listPage(link)
+		if oneDriveError, ok := err.(*Error); ok && oneDriveError.StatusCode == http.StatusGone && link != "" {
+			link = ""
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		for _, item := range response.DriveItems {
+			if err := fn(item); err != nil {
+				return "", err
+			}
+		}
+
+		if response.NextLink != "" {
+			link = response.NextLink
+			continue
+		}
+
+		if response.DeltaLink == "" {
+			return "", errors.New("The last page of changes has no delta link.")
+		}
+
+		return response.DeltaLink, nil
+	}
+}
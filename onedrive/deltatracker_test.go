@@ -0,0 +1,108 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDeltaTracker_Sync(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	mux.HandleFunc("/me/drive/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("token") {
+		case "":
+			fmt.Fprintf(w, `{"value": [{"id": "1"}], "@odata.nextLink": "%sme/drive/root/delta?token=page2"}`, client.BaseURL)
+		case "page2":
+			fmt.Fprintf(w, `{"value": [{"id": "2"}], "@odata.deltaLink": "%sme/drive/root/delta?token=latest"}`, client.BaseURL)
+		case "latest":
+			fmt.Fprintf(w, `{"value": [{"id": "3"}], "@odata.deltaLink": "%sme/drive/root/delta?token=latest"}`, client.BaseURL)
+		case "expired":
+			w.WriteHeader(http.StatusGone)
+			fmt.Fprint(w, `{"error": {"code": "resyncRequired", "message": "Resync required"}}`)
+		}
+	})
+
+	ctx := context.Background()
+	store := &FileDeltaTokenStore{Path: filepath.Join(dir, "delta")}
+	sync := func() []string {
+		var ids []string
+		tracker := NewDeltaTracker(client, "", store)
+		if err := tracker.Sync(ctx, func(item *DriveItem) error {
+			ids = append(ids, item.Id)
+			return nil
+		}); err != nil {
+			t.Fatalf("DeltaTracker.Sync returned error: %v", err)
+		}
+		return ids
+	}
+
+	if got, want := sync(), []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("First DeltaTracker.Sync returned %v, want %v", got, want)
+	}
+	if got, want := sync(), []string{"3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Second DeltaTracker.Sync returned %v, want %v", got, want)
+	}
+
+	if err := store.Save(ctx, client.BaseURL.String()+"me/drive/root/delta?token=expired"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sync(), []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DeltaTracker.Sync after expiration returned %v, want %v", got, want)
+	}
+}
+
+func TestDeltaTracker_Sync_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "1"}], "@odata.deltaLink": "https://graph.microsoft.com/v1.0/me/drive/root/delta?token=latest"}`)
+	})
+
+	store := &memoryDeltaTokenStore{}
+	wantErr := errors.New("failed")
+	err := NewDeltaTracker(client, "", store).Sync(context.Background(), func(item *DriveItem) error {
+		return wantErr
+	})
+	if err != wantErr {
+		t.Errorf("DeltaTracker.Sync returned error %v, want %v", err, wantErr)
+	}
+	if store.deltaLink != "" {
+		t.Errorf("DeltaTracker.Sync saved %q after an error", store.deltaLink)
+	}
+}
+
+type memoryDeltaTokenStore struct {
+	deltaLink string
+}
+
+func (store *memoryDeltaTokenStore) Load(ctx context.Context) (string, error) {
+	return store.deltaLink, nil
+}
+
+func (store *memoryDeltaTokenStore) Save(ctx context.Context, deltaLink string) error {
+	store.deltaLink = deltaLink
+	return nil
+}
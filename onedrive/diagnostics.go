@@ -0,0 +1,254 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// The number of requests and throttling events kept for Diagnostics.
+const (
+	maxDiagnosedRequests         = 50
+	maxDiagnosedThrottlingEvents = 20
+)
+
+// RequestDiagnostics represents a request sent by a client, see Diagnostics.
+type RequestDiagnostics struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// URL is the URL of the request, without its query which may hold credentials, and
+	// with the paths of the items and the search queries redacted.
+	URL        string        `json:"url"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	// RequestID and ClientRequestID are the identifiers of the request on the side of
+	// Microsoft Graph, which its support asks for.
+	RequestID       string `json:"requestId,omitempty"`
+	ClientRequestID string `json:"clientRequestId,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// ThrottlingEvent represents a response throttled by OneDrive, see Diagnostics.
+type ThrottlingEvent struct {
+	Time time.Time `json:"time"`
+	// Drive is the drive targeted by the request, e.g. "me/drive", if any.
+	Drive      string        `json:"drive,omitempty"`
+	StatusCode int           `json:"statusCode"`
+	RetryAfter time.Duration `json:"retryAfter"`
+}
+
+// ClientConfiguration represents the configuration of a client, as far as it can be shared
+// in a support case.
+type ClientConfiguration struct {
+	BaseURL             string        `json:"baseUrl"`
+	DownloadRoot        string        `json:"downloadRoot,omitempty"`
+	UploadRoot          string        `json:"uploadRoot,omitempty"`
+	ReadOnly            bool          `json:"readOnly"`
+	DryRun              bool          `json:"dryRun"`
+	RawJSON             bool          `json:"rawJson"`
+	StrictDecoding      bool          `json:"strictDecoding"`
+	AcceptLanguage      string        `json:"acceptLanguage,omitempty"`
+	NotFoundRetryWindow time.Duration `json:"notFoundRetryWindow,omitempty"`
+	PathCache           bool          `json:"pathCache"`
+	ThrottlingBackoff   bool          `json:"throttlingBackoff"`
+	RequestCoalescing   bool          `json:"requestCoalescing"`
+	CoalescingWindow    time.Duration `json:"coalescingWindow,omitempty"`
+	ThumbnailCache      bool          `json:"thumbnailCache"`
+	AuditSink           bool          `json:"auditSink"`
+	Hasher              string        `json:"hasher"`
+}
+
+// DiagnosticsReport is a snapshot of the recent activity and the configuration of a
+// client, to be attached to a support ticket or an issue. It holds no access token,
+// content of the drives, nor names of their items.
+type DiagnosticsReport struct {
+	GeneratedAt      time.Time            `json:"generatedAt"`
+	GoVersion        string               `json:"goVersion"`
+	Platform         string               `json:"platform"`
+	Configuration    ClientConfiguration  `json:"configuration"`
+	Requests         []RequestDiagnostics `json:"requests"`
+	ThrottlingEvents []ThrottlingEvent    `json:"throttlingEvents"`
+	// Signature is the hex-encoded HMAC-SHA256 of the JSON encoding of the report without
+	// Signature, under the key of WithDiagnosticsKey, if any. It tells whoever holds the key
+	// whether the report has been edited since it was generated, see Verify.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Verify reports whether the report is as it was generated by a client signing its reports
+// with key, see WithDiagnosticsKey.
+func (report *DiagnosticsReport) Verify(key []byte) bool {
+	if len(key) == 0 || report.Signature == "" {
+		return false
+	}
+
+	signature, err := hex.DecodeString(report.Signature)
+	return err == nil && hmac.Equal(signature, report.sign(key))
+}
+
+// JSON returns the indented JSON encoding of the report.
+func (report *DiagnosticsReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// sign returns the HMAC-SHA256 of the report without Signature under key.
+func (report *DiagnosticsReport) sign(key []byte) []byte {
+	unsigned := *report
+	unsigned.Signature = ""
+	data, _ := json.Marshal(&unsigned)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// WithDiagnosticsKey makes Diagnostics sign its reports with key, so that whoever receives
+// a report and holds the key, e.g. the support team of an application, can tell whether it
+// has been edited, see DiagnosticsReport.Verify. Without a key, the reports are not signed.
+func WithDiagnosticsKey(key []byte) ClientOption {
+	return func(c *Client) {
+		c.diagnosticsKey = append([]byte(nil), key...)
+	}
+}
+
+// diagnosticsLog keeps the recent requests and throttling events of a client.
+type diagnosticsLog struct {
+	mu               sync.Mutex
+	requests         []RequestDiagnostics
+	throttlingEvents []ThrottlingEvent
+}
+
+// Diagnostics returns a report of the last requests sent by the client, with their
+// identifiers and timing, of the last responses throttled by OneDrive, and of the
+// configuration of the client.
+func (c *Client) Diagnostics() *DiagnosticsReport {
+	baseURL := ""
+	if c.BaseURL != nil {
+		u := *c.BaseURL
+		u.User, u.RawQuery = nil, ""
+		baseURL = u.String()
+	}
+
+	downloadRoot, uploadRoot := "", ""
+	if c.endpoints != nil {
+		if c.endpoints.download != nil {
+			downloadRoot = c.endpoints.download.String()
+		}
+		if c.endpoints.upload != nil {
+			uploadRoot = c.endpoints.upload.String()
+		}
+	}
+
+	c.pathCacheMu.Lock()
+	pathCache := c.pathCache != nil
+	c.pathCacheMu.Unlock()
+
+	report := &DiagnosticsReport{
+		GeneratedAt: c.now(),
+		GoVersion:   runtime.Version(),
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+		Configuration: ClientConfiguration{
+			BaseURL:             baseURL,
+			DownloadRoot:        downloadRoot,
+			UploadRoot:          uploadRoot,
+			ReadOnly:            c.readOnly,
+			DryRun:              c.dryRunJournal != nil,
+			RawJSON:             c.rawJSON,
+			StrictDecoding:      c.strictDecoding,
+			AcceptLanguage:      c.acceptLanguage,
+			NotFoundRetryWindow: c.notFoundRetryWindow,
+			PathCache:           pathCache,
+			ThrottlingBackoff:   c.throttledUntil != nil,
+			RequestCoalescing:   c.coalesced != nil,
+			CoalescingWindow:    c.coalescingWindow,
+			ThumbnailCache:      c.thumbnailCache != nil,
+			AuditSink:           c.auditLog != nil,
+			Hasher:              fmt.Sprintf("%T", c.fileHasher()),
+		},
+	}
+
+	c.diagnostics.mu.Lock()
+	report.Requests = append([]RequestDiagnostics(nil), c.diagnostics.requests...)
+	report.ThrottlingEvents = append([]ThrottlingEvent(nil), c.diagnostics.throttlingEvents...)
+	c.diagnostics.mu.Unlock()
+
+	if len(c.diagnosticsKey) > 0 {
+		report.Signature = hex.EncodeToString(report.sign(c.diagnosticsKey))
+	}
+	return report
+}
+
+// recordDiagnostics records a request sent by the client, along with its response if any,
+// for Diagnostics.
+func (c *Client) recordDiagnostics(req *http.Request, resp *http.Response, start time.Time, err error) {
+	u := *req.URL
+	u.User, u.RawQuery, u.Fragment = nil, "", ""
+	u.Path, u.RawPath = redactDiagnosedPath(u.Path), ""
+	request := RequestDiagnostics{
+		Time:            start,
+		Method:          req.Method,
+		URL:             u.String(),
+		Duration:        c.now().Sub(start),
+		ClientRequestID: req.Header.Get("client-request-id"),
+	}
+	if err != nil {
+		request.Error = err.Error()
+	}
+
+	var event *ThrottlingEvent
+	if resp != nil {
+		request.StatusCode = resp.StatusCode
+		request.RequestID = resp.Header.Get("request-id")
+		if id := resp.Header.Get("client-request-id"); id != "" {
+			request.ClientRequestID = id
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			event = &ThrottlingEvent{
+				Time:       start,
+				Drive:      throttlingKey(req),
+				StatusCode: resp.StatusCode,
+				RetryAfter: retryAfter(resp.Header.Get("Retry-After"), start),
+			}
+		}
+	}
+
+	l := &c.diagnostics
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.requests = append(l.requests, request)
+	if len(l.requests) > maxDiagnosedRequests {
+		l.requests = l.requests[len(l.requests)-maxDiagnosedRequests:]
+	}
+
+	if event != nil {
+		l.throttlingEvents = append(l.throttlingEvents, *event)
+		if len(l.throttlingEvents) > maxDiagnosedThrottlingEvents {
+			l.throttlingEvents = l.throttlingEvents[len(l.throttlingEvents)-maxDiagnosedThrottlingEvents:]
+		}
+	}
+}
+
+// The parts of the paths of the request URLs which name items or search the drives, e.g.
+// ":/Documents/a.txt:" in "root:/Documents/a.txt:/content", or "q='budget'".
+var (
+	diagnosedItemPath    = regexp.MustCompile(`:/[^:]*(:|$)`)
+	diagnosedSearchQuery = regexp.MustCompile(`q='(?:[^']|'')*'`)
+)
+
+// redactDiagnosedPath returns the path of a request URL without the names of items and the
+// search queries it holds.
+func redactDiagnosedPath(urlPath string) string {
+	urlPath = diagnosedItemPath.ReplaceAllString(urlPath, ":/redacted${1}")
+	return diagnosedSearchQuery.ReplaceAllString(urlPath, "q='redacted'")
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Diagnostics(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithReadOnly()(client)
+	key := []byte("support")
+	WithDiagnosticsKey(key)(client)
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("request-id", "req-1")
+		fmt.Fprint(w, `{"id":"1"}`)
+	})
+	mux.HandleFunc("/me/drive/items/2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("request-id", "req-2")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"code":"activityLimitReached","message":"Slow down."}}`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.DriveItems.Get(ctx, "1"); err != nil {
+		t.Fatalf("DriveItems.Get returned error: %v", err)
+	}
+	client.DriveItems.Get(ctx, "2")
+
+	report := client.Diagnostics()
+
+	if !report.Configuration.ReadOnly || report.Configuration.DryRun {
+		t.Errorf("The configuration is %+v", report.Configuration)
+	}
+	if len(report.Requests) != 2 || report.Requests[0].RequestID != "req-1" || report.Requests[1].StatusCode != http.StatusTooManyRequests {
+		t.Errorf("The requests are %+v", report.Requests)
+	}
+	if len(report.ThrottlingEvents) != 1 || report.ThrottlingEvents[0].Drive != "me/drive" || report.ThrottlingEvents[0].RetryAfter != 7*time.Second {
+		t.Errorf("The throttling events are %+v", report.ThrottlingEvents)
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatalf("DiagnosticsReport.JSON returned error: %v", err)
+	}
+	var decoded DiagnosticsReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Verify(key) {
+		t.Error("DiagnosticsReport.Verify returned false for the report as generated")
+	}
+	if decoded.Verify([]byte("other")) {
+		t.Error("DiagnosticsReport.Verify returned true with another key")
+	}
+	decoded.Requests[0].URL = strings.Replace(decoded.Requests[0].URL, "1", "3", -1)
+	if decoded.Verify(key) {
+		t.Error("DiagnosticsReport.Verify returned true for an edited report")
+	}
+
+	// Anyone could compute the signature of an edited report without the key.
+	decoded.Signature = hex.EncodeToString(decoded.sign(nil))
+	if decoded.Verify(nil) || decoded.Verify(key) {
+		t.Error("DiagnosticsReport.Verify returned true for a report signed without the key")
+	}
+}
+
+func TestRedactDiagnosedPath(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"/v1.0/me/drive/items/1", "/v1.0/me/drive/items/1"},
+		{"/v1.0/me/drive/root:/Documents/a.txt:/content", "/v1.0/me/drive/root:/redacted:/content"},
+		{"/v1.0/me/drive/root:/Documents/a.txt", "/v1.0/me/drive/root:/redacted"},
+		{"/v1.0/me/drive/items/1:/a.txt:/createUploadSession", "/v1.0/me/drive/items/1:/redacted:/createUploadSession"},
+		{"/v1.0/me/drive/root/search(q='budget 2024')", "/v1.0/me/drive/root/search(q='redacted')"},
+		{"/v1.0/me/drive/root/search(q='O''Brien secret')", "/v1.0/me/drive/root/search(q='redacted')"},
+	}
+	for _, test := range tests {
+		if got := redactDiagnosedPath(test.path); got != test.want {
+			t.Errorf("redactDiagnosedPath(%q) = %q, want %q", test.path, got, test.want)
+		}
+	}
+}
+
+func TestClient_Diagnostics_limit(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1"}`)
+	})
+
+	for i := 0; i < maxDiagnosedRequests+5; i++ {
+		client.DriveItems.Get(context.Background(), "1")
+	}
+
+	if got := len(client.Diagnostics().Requests); got != maxDiagnosedRequests {
+		t.Errorf("Diagnostics returned %d requests, want %d", got, maxDiagnosedRequests)
+	}
+}
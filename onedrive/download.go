@@ -0,0 +1,107 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DownloadToWriter streams the content of a file in a drive of the authenticated user into
+// w, and returns the number of bytes written.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (s *DriveItemsService) DownloadToWriter(ctx context.Context, item *DriveItem, w io.Writer) (int64, error) {
+	if w == nil {
+		return 0, errors.New("Please provide the writer to download into.")
+	}
+
+	content, err := s.DownloadItemStream(ctx, item)
+	if err != nil {
+		return 0, err
+	}
+	defer content.Close()
+
+	return io.Copy(w, content)
+}
+
+// DownloadByPath opens the content of a file in the default drive of the authenticated user
+// given its path from the root, e.g. "Documents/report.xlsx", in a single request. The
+// segments of the path are escaped as needed. The caller must close the returned reader.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (s *DriveItemsService) DownloadByPath(ctx context.Context, itemPath string) (io.ReadCloser, error) {
+	if strings.Trim(itemPath, "/") == "" {
+		return nil, errors.New("Please provide the path of the file.")
+	}
+
+	var content io.ReadCloser
+	err := s.doPath(ctx, "", itemPath, func(apiURL string) error {
+		contentURL, err := s.client.BaseURL.Parse(apiURL + "/content")
+		if err != nil {
+			return err
+		}
+
+		// The content is served at a pre-authenticated URL the request is redirected to.
+		content, err = s.openDownloadRange(ctx, contentURL.String(), "", 0, 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// DownloadToFile streams the content of a file in a drive of the authenticated user into a
+// local file, creating its parent directories if needed. The modification time of the local
+// file is set to the lastModifiedDateTime of the item.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (s *DriveItemsService) DownloadToFile(ctx context.Context, item *DriveItem, localFilePath string) error {
+	if localFilePath == "" {
+		return errors.New("Please provide the path to the file on local.")
+	}
+
+	if item == nil || item.Id == "" {
+		return errors.New("Please provide the item to download.")
+	}
+
+	if item.IsPackage() {
+		return ErrPackageItem
+	}
+
+	// The item is refreshed for its download URL, and its modification time along.
+	if item.DownloadURL == "" {
+		var err error
+		item, err = s.Get(ctx, item.Id)
+		if err != nil {
+			return err
+		}
+		if item.IsPackage() {
+			return ErrPackageItem
+		}
+		if item.DownloadURL == "" {
+			return errors.New("Only file is allowed to be downloaded here.")
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(localFilePath), 0755); err != nil {
+		return err
+	}
+
+	if err := s.downloadToFile(ctx, item, localFilePath); err != nil {
+		return err
+	}
+
+	if item.LastModifiedDateTime.IsZero() {
+		return nil
+	}
+	return os.Chtimes(localFilePath, item.LastModifiedDateTime, item.LastModifiedDateTime)
+}
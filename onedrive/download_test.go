@@ -0,0 +1,113 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_DownloadToWriter(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content of a.txt")
+	})
+
+	var buffer bytes.Buffer
+	item := &DriveItem{Id: "1", Name: "a.txt", File: &DriveItemFile{}, DownloadURL: serverURL + baseURLPath + "/download/1"}
+	n, err := client.DriveItems.DownloadToWriter(context.Background(), item, &buffer)
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadToWriter returned error: %v", err)
+	}
+
+	if want := "content of a.txt"; buffer.String() != want || n != int64(len(want)) {
+		t.Errorf("DriveItems.DownloadToWriter wrote %d bytes %q, want %q", n, buffer.String(), want)
+	}
+}
+
+func TestDriveItemsService_DownloadToFile(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprintf(w, `{"id": "1", "name": "a.txt", "file": {}, "lastModifiedDateTime": "2020-05-01T12:00:00Z",
+			"@microsoft.graph.downloadUrl": "%s%s/download/1"}`, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content of a.txt")
+	})
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	localFilePath := filepath.Join(dir, "Reports", "2020", "a.txt")
+	if err := client.DriveItems.DownloadToFile(context.Background(), &DriveItem{Id: "1"}, localFilePath); err != nil {
+		t.Fatalf("DriveItems.DownloadToFile returned error: %v", err)
+	}
+
+	got, err := ioutil.ReadFile(localFilePath)
+	if err != nil {
+		t.Fatalf("Cannot read the downloaded file: %v", err)
+	}
+	if want := "content of a.txt"; string(got) != want {
+		t.Errorf("DriveItems.DownloadToFile wrote %q, want %q", got, want)
+	}
+
+	fileInfo, err := os.Stat(localFilePath)
+	if err != nil {
+		t.Fatalf("Cannot stat the downloaded file: %v", err)
+	}
+	if want := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC); !fileInfo.ModTime().Equal(want) {
+		t.Errorf("The modification time of the downloaded file is %v, want %v", fileInfo.ModTime(), want)
+	}
+}
+
+func TestDriveItemsService_DownloadByPath(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root:/Documents/Q1 #1.xlsx:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if !strings.Contains(r.RequestURI, "Q1%20%231.xlsx") {
+			t.Errorf("The path of %q is not escaped", r.RequestURI)
+		}
+		http.Redirect(w, r, serverURL+baseURLPath+"/download/1", http.StatusFound)
+	})
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content of the report")
+	})
+
+	content, err := client.DriveItems.DownloadByPath(context.Background(), "Documents/Q1 #1.xlsx")
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadByPath returned error: %v", err)
+	}
+	defer content.Close()
+
+	got, err := ioutil.ReadAll(content)
+	if err != nil {
+		t.Fatalf("Cannot read the content: %v", err)
+	}
+	if want := "content of the report"; string(got) != want {
+		t.Errorf("DriveItems.DownloadByPath returned %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// DownloadFormat indicates the format a file is converted to by DownloadItemAsFormat.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content_format?view=odsp-graph-online#format-options
+type DownloadFormat int
+
+const (
+	// PDFFormat converts Office documents, among others, to PDF.
+	PDFFormat DownloadFormat = iota
+	// HTMLFormat converts Loop and Fluid files to HTML.
+	HTMLFormat
+	// JPGFormat converts images, e.g. HEIC photos, to JPG.
+	JPGFormat
+	// GLBFormat converts 3D models to GLB.
+	GLBFormat
+)
+
+func (format DownloadFormat) toString() string {
+	return [...]string{"pdf", "html", "jpg", "glb"}[format]
+}
+
+func (format DownloadFormat) isValid() bool {
+	return format >= PDFFormat && format <= GLBFormat
+}
@@ -0,0 +1,260 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const defaultTransferConcurrency = 8
+
+// DownloadManyOpts represents the options for downloading items by DownloadMany.
+type DownloadManyOpts struct {
+	// Concurrency is the maximum number of files downloaded at the same time.
+	// Default is 8.
+	Concurrency int
+	// Journal, if any, records the progress of the downloads, so the items already
+	// downloaded by a previous run are skipped.
+	Journal *TransferJournal
+}
+
+// DownloadManyResult represents the outcome of downloading one of the items by DownloadMany.
+type DownloadManyResult struct {
+	Item      *DriveItem
+	LocalPath string
+	// Skipped reports that the item had already been downloaded according to the journal.
+	Skipped bool
+	Err     error
+}
+
+// DownloadMany downloads many files into a local directory, which is much faster than
+// calling DownloadItem for each of them when there are thousands of small files.
+//
+// The items lacking a download URL are first retrieved with as few JSON batches as
+// possible, then the files are downloaded by a bounded pool of workers sharing the
+// connections of the HTTP client. Each file is saved under its name in destinationDir,
+// so items with the same name overwrite each other.
+//
+// The outcome of every item is reported in the results, in the same order as the items.
+// An error is only returned when the downloads could not be started at all.
+func (s *DriveItemsService) DownloadMany(ctx context.Context, items []*DriveItem, destinationDir string, opts DownloadManyOpts) ([]DownloadManyResult, error) {
+	if destinationDir == "" {
+		return nil, errors.New("Please provide the local directory to download into.")
+	}
+
+	if err := os.MkdirAll(destinationDir, 0755); err != nil {
+		return nil, err
+	}
+
+	results := make([]DownloadManyResult, len(items))
+	for i, item := range items {
+		results[i].Item = item
+
+		if opts.Journal != nil && item != nil {
+			localPath := filepath.Join(destinationDir, filepath.Base(item.Name))
+			if opts.Journal.downloaded(item, localPath) {
+				results[i].LocalPath, results[i].Skipped = localPath, true
+			}
+		}
+	}
+
+	if err := s.hydrateDownloadURLs(ctx, results); err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTransferConcurrency
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := &results[i]
+				if result.Err != nil || result.Skipped {
+					continue
+				}
+				result.LocalPath = filepath.Join(destinationDir, filepath.Base(result.Item.Name))
+				_, result.Err = opts.Journal.track(TransferEntry{
+					Operation: TransferDownload,
+					LocalPath: result.LocalPath,
+					ItemId:    result.Item.Id,
+					ETag:      result.Item.ETag,
+					Size:      result.Item.Size,
+				}, func() (*DriveItem, error) {
+					return nil, s.downloadToFile(ctx, result.Item, result.LocalPath)
+				})
+			}
+		}()
+	}
+
+	for i := range results {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := range results {
+		if results[i].Err == nil && results[i].LocalPath == "" {
+			results[i].Err = ctx.Err()
+		}
+	}
+
+	return results, nil
+}
+
+// hydrateDownloadURLs retrieves the items of the results lacking a download URL with JSON
+// batches. The results of the items which cannot be downloaded are given an error.
+func (s *DriveItemsService) hydrateDownloadURLs(ctx context.Context, results []DownloadManyResult) error {
+	var requests []batchRequest
+	var indexes []int
+	for i, result := range results {
+		item := result.Item
+		switch {
+		case result.Skipped:
+		case item == nil:
+			results[i].Err = errors.New("Please provide the item to download.")
+		case item.IsPackage():
+			results[i].Err = ErrPackageItem
+		case item.Folder != nil:
+			results[i].Err = errors.New("Only file is allowed to be downloaded here.")
+		case item.DownloadURL == "":
+			apiURL := "me/drive/items/" + url.PathEscape(item.Id)
+			if item.ParentReference != nil && item.ParentReference.DriveId != "" {
+				apiURL = "drives/" + url.PathEscape(item.ParentReference.DriveId) + "/items/" + url.PathEscape(item.Id)
+			}
+			requests = append(requests, batchRequest{Method: "GET", URL: apiURL})
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	responses, err := s.client.batch(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	for j, response := range responses {
+		i := indexes[j]
+
+		var item *DriveItem
+		if err := response.decode(&item); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		if item.IsPackage() {
+			results[i].Err = ErrPackageItem
+			continue
+		}
+
+		results[i].Item = item
+	}
+
+	return nil
+}
+
+// downloadToFile streams the content of an item having a download URL into a local file.
+func (s *DriveItemsService) downloadToFile(ctx context.Context, item *DriveItem, localFilePath string) error {
+	content, err := s.openDownload(ctx, item)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	file, err := os.Create(localFilePath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// openDownload requests the content of an item having a download URL.
+func (s *DriveItemsService) openDownload(ctx context.Context, item *DriveItem) (io.ReadCloser, error) {
+	return s.openDownloadRange(ctx, item.DownloadURL, "", 0, 0)
+}
+
+// openDownloadRange requests the content at a download URL, or the range of length bytes at
+// offset described by byteRange if it is not empty. A length of 0 means until the end of the
+// content.
+func (s *DriveItemsService) openDownloadRange(ctx context.Context, downloadURL string, byteRange string, offset int64, length int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.client.downloadURL(downloadURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+
+	resp, err := s.client.client.Do(req)
+	if err != nil {
+		return nil, processHTTPError(ctx, err)
+	}
+
+	if resp.StatusCode == http.StatusPartialContent && byteRange != "" {
+		return resp.Body, nil
+	}
+
+	if resp.StatusCode != 200 {
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error == nil {
+			return nil, fmt.Errorf("%s: %s", resp.Status, body)
+		}
+		errResp.Error.StatusCode = resp.StatusCode
+		return nil, errResp.Error
+	}
+
+	if byteRange == "" {
+		return resp.Body, nil
+	}
+
+	// The whole content has been returned, the range is cut out of it.
+	if _, err := io.CopyN(ioutil.Discard, resp.Body, offset); err != nil && err != io.EOF {
+		resp.Body.Close()
+		return nil, err
+	}
+	if length == 0 {
+		return resp.Body, nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, length), resp.Body}, nil
+}
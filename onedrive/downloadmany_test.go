@@ -0,0 +1,76 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDriveItemsService_DownloadMany(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		if len(payload.Requests) != 1 || payload.Requests[0].URL != "/me/drive/items/2" {
+			t.Errorf("Batch requests: %+v, want only the item lacking a download URL", payload.Requests)
+		}
+
+		fmt.Fprintf(w, `{"responses": [{"id": %q, "status": 200, "body": {"id": "2", "name": "b.txt", "file": {}, "@microsoft.graph.downloadUrl": "%s%s/download/2"}}]}`,
+			payload.Requests[0].Id, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, "content of "+filepath.Base(r.URL.Path))
+	})
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	items := []*DriveItem{
+		{Id: "1", Name: "a.txt", File: &DriveItemFile{}, DownloadURL: serverURL + baseURLPath + "/download/1"},
+		{Id: "2"},
+		{Id: "3", Name: "Notebook", Package: &DriveItemPackage{Type: "oneNote"}},
+	}
+
+	ctx := context.Background()
+	results, err := client.DriveItems.DownloadMany(ctx, items, dir, DownloadManyOpts{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadMany returned error: %v", err)
+	}
+
+	for i, want := range []string{"content of 1", "content of 2"} {
+		if results[i].Err != nil {
+			t.Errorf("DriveItems.DownloadMany returned error for item %d: %v", i, results[i].Err)
+			continue
+		}
+		got, _ := ioutil.ReadFile(results[i].LocalPath)
+		if string(got) != want {
+			t.Errorf("DriveItems.DownloadMany downloaded %q for item %d, want %q", got, i, want)
+		}
+	}
+
+	if results[2].Err != ErrPackageItem {
+		t.Errorf("DriveItems.DownloadMany returned error %v for a package, want %v", results[2].Err, ErrPackageItem)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -32,32 +33,136 @@ type OneDriveDriveItemsResponse struct {
 	ODataContext string       `json:"@odata.context"`
 	Count        int          `json:"@odata.count"`
 	DriveItems   []*DriveItem `json:"value"`
+	// NextLink is the URL of the next page of items, if any, see ListPage.
+	NextLink string `json:"@odata.nextLink"`
+	// DeltaLink is the URL of the changes which will happen after the last page, when the
+	// items are the changes listed by a delta query.
+	DeltaLink string `json:"@odata.deltaLink"`
+}
+
+// HasMore reports whether the items are followed by another page, see NextLink.
+func (response *OneDriveDriveItemsResponse) HasMore() bool {
+	return response.NextLink != ""
 }
 
 // DriveItem represents a OneDrive drive item.
 // Ref https://docs.microsoft.com/en-us/graph/api/resources/driveitem?view=graph-rest-1.0
 type DriveItem struct {
-	Name        string           `json:"name"`
-	Id          string           `json:"id"`
-	DownloadURL string           `json:"@microsoft.graph.downloadUrl"`
-	Description string           `json:"description"`
-	WebURL      string           `json:"webUrl"`
-	Audio       *OneDriveAudio   `json:"audio"`
-	Video       *OneDriveVideo   `json:"video"`
-	Image       *OneDriveImage   `json:"image"`
-	Photo       *OneDrivePhoto   `json:"photo"`
-	File        *DriveItemFile   `json:"file"`
-	Folder      *DriveItemFolder `json:"folder"`
+	Name                 string                `json:"name"`
+	Id                   string                `json:"id"`
+	DownloadURL          string                `json:"@microsoft.graph.downloadUrl"`
+	Description          string                `json:"description"`
+	WebURL               string                `json:"webUrl"`
+	ETag                 string                `json:"eTag"`
+	CTag                 string                `json:"cTag"`
+	Size                 int64                 `json:"size"`
+	CreatedDateTime      time.Time             `json:"createdDateTime"`
+	LastModifiedDateTime time.Time             `json:"lastModifiedDateTime"`
+	FileSystemInfo       *FileSystemInfo       `json:"fileSystemInfo"`
+	Audio                *OneDriveAudio        `json:"audio"`
+	Video                *OneDriveVideo        `json:"video"`
+	Image                *OneDriveImage        `json:"image"`
+	Photo                *OneDrivePhoto        `json:"photo"`
+	File                 *DriveItemFile        `json:"file"`
+	Folder               *DriveItemFolder      `json:"folder"`
+	Package              *DriveItemPackage     `json:"package"`
+	Deleted              *DriveItemDeleted     `json:"deleted"`
+	Publication          *DriveItemPublication `json:"publication"`
+	ParentReference      *ParentReference      `json:"parentReference"`
+	RemoteItem           *DriveItemRemoteItem  `json:"remoteItem"`
+	PendingOperations    *PendingOperations    `json:"pendingOperations"`
+
+	// Children and Thumbnails are only returned when they are expanded, e.g. by
+	// GetWithQuery with ExpandChildren and ExpandThumbnails. At most 200 children are
+	// expanded; ChildrenNextLink is the URL of the next page of children, if any.
+	Children         []*DriveItem    `json:"children"`
+	ChildrenNextLink string          `json:"children@odata.nextLink"`
+	Thumbnails       []*ThumbnailSet `json:"thumbnails"`
+
+	// Raw is the JSON object the item has been decoded from, when the client has been
+	// created with WithRawJSON. It gives access to the annotations and the properties
+	// which are not modeled by DriveItem.
+	Raw json.RawMessage `json:"-"`
+}
+
+// FileSystemInfo represents the times of a file as reported by the file system of the
+// client which has uploaded it, rather than the times of the changes on OneDrive. The zero
+// times are left out when it is sent.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/filesysteminfo?view=odsp-graph-online
+type FileSystemInfo struct {
+	CreatedDateTime      time.Time `json:"createdDateTime"`
+	LastModifiedDateTime time.Time `json:"lastModifiedDateTime"`
+	LastAccessedDateTime time.Time `json:"lastAccessedDateTime"`
+}
+
+// MarshalJSON encodes the non-zero times of the file.
+func (info FileSystemInfo) MarshalJSON() ([]byte, error) {
+	times := make(map[string]time.Time)
+	if !info.CreatedDateTime.IsZero() {
+		times["createdDateTime"] = info.CreatedDateTime
+	}
+	if !info.LastModifiedDateTime.IsZero() {
+		times["lastModifiedDateTime"] = info.LastModifiedDateTime
+	}
+	if !info.LastAccessedDateTime.IsZero() {
+		times["lastAccessedDateTime"] = info.LastAccessedDateTime
+	}
+
+	return json.Marshal(times)
 }
 
 // DriveItemFile represents a OneDrive drive item file info.
 type DriveItemFile struct {
-	MIMEType string `json:"mimeType"`
+	MIMEType string           `json:"mimeType"`
+	Hashes   *DriveItemHashes `json:"hashes"`
+}
+
+// DriveItemHashes represents the hashes of a OneDrive drive item file content.
+// Not all hashes are available on every kind of drive, but QuickXorHash is.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/hashes?view=odsp-graph-online
+type DriveItemHashes struct {
+	QuickXorHash string `json:"quickXorHash"`
+	SHA1Hash     string `json:"sha1Hash"`
+	SHA256Hash   string `json:"sha256Hash"`
+	CRC32Hash    string `json:"crc32Hash"`
 }
 
 // DriveItemFolder represents a OneDrive drive item folder info.
 type DriveItemFolder struct {
-	ChildCount int32 `json:"childCount"`
+	ChildCount int32                `json:"childCount"`
+	View       *DriveItemFolderView `json:"view"`
+}
+
+// DriveItemPackage represents a OneDrive drive item package info. A package, such as a
+// OneNote notebook, is treated as a single file by OneDrive even though it may be
+// backed by a folder, so it can be neither downloaded nor traversed as a folder.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/package?view=odsp-graph-online
+type DriveItemPackage struct {
+	Type string `json:"type"`
+}
+
+// DriveItemRemoteItem represents the item of another drive a drive item refers to, e.g. a
+// file shared with the authenticated user. Its parentReference tells the drive holding it.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/remoteitem?view=odsp-graph-online
+type DriveItemRemoteItem struct {
+	Id                   string            `json:"id"`
+	Name                 string            `json:"name"`
+	WebURL               string            `json:"webUrl"`
+	Size                 int64             `json:"size"`
+	LastModifiedDateTime time.Time         `json:"lastModifiedDateTime"`
+	File                 *DriveItemFile    `json:"file"`
+	Folder               *DriveItemFolder  `json:"folder"`
+	Package              *DriveItemPackage `json:"package"`
+	ParentReference      *ParentReference  `json:"parentReference"`
+}
+
+// IsPackage reports whether the drive item is a package, such as a OneNote notebook.
+func (item *DriveItem) IsPackage() bool {
+	return item.Package != nil
 }
 
 // NewFolderCreationRequest represents the information needed of a new OneDrive folder to be created.
@@ -130,8 +235,9 @@ type OneDriveImage struct {
 // OneDrivePhoto represents the photo metadata of a OneDrive drive item which is a photo.
 // Ref https://docs.microsoft.com/en-us/graph/api/resources/photo?view=graph-rest-1.0
 type OneDrivePhoto struct {
-	CameraMake  string `json:"cameraMake"`
-	CameraModel string `json:"cameraModel"`
+	CameraMake    string    `json:"cameraMake"`
+	CameraModel   string    `json:"cameraModel"`
+	TakenDateTime time.Time `json:"takenDateTime"`
 }
 
 // OneDriveVideo represents the video metadata of a OneDrive drive item.
@@ -165,6 +271,135 @@ func (s *DriveItemsService) List(ctx context.Context, folderId string) (*OneDriv
 	return oneDriveResponse, nil
 }
 
+// ListOpts represents the options for listing the items of a folder by ListWithOpts, ListPage,
+// ListAll and ListFunc.
+type ListOpts struct {
+	// OrderBy sorts the items on the server side. By default, the order is
+	// decided by OneDrive.
+	OrderBy ListOrderBy
+	// PageSize is the maximum number of items per page. By default, the page size is
+	// decided by OneDrive.
+	PageSize int
+}
+
+// ListWithOpts lists the items of a folder in the default drive of the authenticated user with options.
+//
+// If folderId is empty, it means the items at the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ListWithOpts(ctx context.Context, folderId string, opts ListOpts) (*OneDriveDriveItemsResponse, error) {
+	apiURL, err := listURL(folderId, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// ListPage lists a page of the items of a folder in the default drive of the authenticated
+// user with options. The first page is listed when pageLink is empty; the following ones
+// are listed by passing the NextLink of the previous page, until it is empty.
+//
+// If folderId is empty, it means the items at the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/paging?view=odsp-graph-online
+func (s *DriveItemsService) ListPage(ctx context.Context, folderId string, opts ListOpts, pageLink string) (*OneDriveDriveItemsResponse, error) {
+	apiURL := pageLink
+	if apiURL == "" {
+		var err error
+		apiURL, err = listURL(folderId, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// ListAll lists all the items of a folder in the default drive of the authenticated user
+// with options, following the pages of the listing until the last one. See ListFunc to
+// handle the items without holding them all in memory.
+//
+// If folderId is empty, it means the items at the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/paging?view=odsp-graph-online
+func (s *DriveItemsService) ListAll(ctx context.Context, folderId string, opts ListOpts) (*OneDriveDriveItemsResponse, error) {
+	var all *OneDriveDriveItemsResponse
+	pageLink := ""
+	for {
+		page, err := s.ListPage(ctx, folderId, opts, pageLink)
+		if err != nil {
+			return nil, err
+		}
+
+		if all == nil {
+			all = page
+		} else {
+			all.DriveItems = append(all.DriveItems, page.DriveItems...)
+		}
+
+		pageLink = page.NextLink
+		if pageLink == "" {
+			break
+		}
+	}
+
+	all.NextLink = ""
+	return all, nil
+}
+
+// listURL returns the URL listing the items of a folder in the default drive of the
+// authenticated user with options.
+func listURL(folderId string, opts ListOpts) (string, error) {
+	if !opts.OrderBy.isValid() {
+		return "", errors.New("Please provide an order supported by the children listing.")
+	}
+
+	if opts.PageSize < 0 {
+		return "", errors.New("Please provide a page size which is not negative.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(folderId) + "/children"
+	if folderId == "" {
+		apiURL = "me/drive/root/children"
+	}
+
+	var query []string
+	if opts.OrderBy != DefaultOrder {
+		query = append(query, "$orderby="+url.PathEscape(opts.OrderBy.toString()))
+	}
+	if opts.PageSize > 0 {
+		query = append(query, "$top="+strconv.Itoa(opts.PageSize))
+	}
+	if len(query) > 0 {
+		apiURL += "?" + strings.Join(query, "&")
+	}
+
+	return apiURL, nil
+}
+
 // List the items of a special folder in the default drive of the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get_specialfolder?view=odsp-graph-online#get-children-of-a-special-folder
@@ -201,7 +436,7 @@ func (s *DriveItemsService) Get(ctx context.Context, itemId string) (*DriveItem,
 	}
 
 	var driveItem *DriveItem
-	err = s.client.Do(ctx, req, false, &driveItem)
+	err = s.client.doItem(ctx, itemId, req, &driveItem)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +444,58 @@ func (s *DriveItemsService) Get(ctx context.Context, itemId string) (*DriveItem,
 	return driveItem, nil
 }
 
+// rootPathURL returns the relative URL of an item addressed by its path from the root of
+// a drive of the authenticated user, e.g. "me/drive/root:/Documents/report.xlsx:". The path
+// segments are escaped one by one, so the slashes between them are kept.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func rootPathURL(driveId string, itemPath string) string {
+	rootURL := "me/drive/root"
+	if driveId != "" {
+		rootURL = "me/drives/" + url.PathEscape(driveId) + "/root"
+	}
+
+	itemPath = strings.Trim(itemPath, "/")
+	if itemPath == "" {
+		return rootURL
+	}
+
+	return rootURL + ":/" + escapePath(itemPath) + ":"
+}
+
+// itemPathURL returns the relative URL of an item addressed by its path relative to a
+// folder in a drive of the authenticated user, e.g. "me/drive/items/{id}:/report.xlsx:".
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func itemPathURL(driveId string, folderId string, itemPath string) string {
+	folderURL := "me/drive/items/" + url.PathEscape(folderId)
+	if driveId != "" {
+		folderURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(folderId)
+	}
+
+	return folderURL + ":/" + escapePath(strings.Trim(itemPath, "/")) + ":"
+}
+
+// escapePath escapes the segments of a slash-separated item path one by one, see
+// escapePathSegment.
+func escapePath(itemPath string) string {
+	segments := strings.Split(itemPath, "/")
+	for i, segment := range segments {
+		segments[i] = escapePathSegment(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// escapePathSegment escapes an item name so it can be placed in a path-addressed URL.
+// On top of url.PathEscape, which escapes '#', '%' and spaces, '+' is escaped as OneDrive
+// would otherwise decode it as a space.
+func escapePathSegment(name string) string {
+	return strings.Replace(url.PathEscape(name), "+", "%2B", -1)
+}
+
 // GetByPath an item in the default drive of the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get
@@ -217,18 +504,20 @@ func (s *DriveItemsService) GetByPath(ctx context.Context, itemPath string) (*Dr
 		return nil, errors.New("Please provide the path of the item.")
 	}
 
-	apiURL := "me/drive/root:/" + url.PathEscape(itemPath)
+	var driveItem *DriveItem
+	err := s.doPath(ctx, "", itemPath, func(apiURL string) error {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return err
+		}
 
-	req, err := s.client.NewRequest("GET", apiURL, nil)
+		return s.client.Do(ctx, req, false, &driveItem)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var driveItem *DriveItem
-	err = s.client.Do(ctx, req, false, &driveItem)
-	if err != nil {
-		return nil, err
-	}
+	s.client.cachePath("", itemPath, driveItem.Id, driveItem.ETag)
 
 	return driveItem, nil
 }
@@ -325,6 +614,8 @@ func (s *DriveItemsService) CreateNewFolderWithOpts(ctx context.Context, driveId
 		return nil, err
 	}
 
+	s.client.markCreated(driveItem)
+
 	return driveItem, nil
 }
 
@@ -350,10 +641,12 @@ func (s *DriveItemsService) Delete(ctx context.Context, driveId string, itemId s
 		return err
 	}
 
-	if err := s.client.Do(ctx, req, false, nil); err != nil {
+	if err := s.client.doItem(ctx, itemId, req, nil); err != nil {
 		return err
 	}
 
+	s.client.forgetPath(itemId)
+
 	return nil
 }
 
@@ -367,6 +660,11 @@ func (s *DriveItemsService) Delete(ctx context.Context, driveId string, itemId s
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_move?view=odsp-graph-online
 func (s *DriveItemsService) Move(ctx context.Context, driveId string, itemId string, destinationParentFolderId string) (*MoveItemResponse, error) {
+	return s.move(ctx, driveId, itemId, destinationParentFolderId, "")
+}
+
+// move moves a drive item with the given conflict behavior, if any.
+func (s *DriveItemsService) move(ctx context.Context, driveId string, itemId string, destinationParentFolderId string, conflictBehavior string) (*MoveItemResponse, error) {
 	if itemId == "" {
 		return nil, errors.New("Please provide the Item ID of the item to be moved.")
 	}
@@ -383,22 +681,19 @@ func (s *DriveItemsService) Move(ctx context.Context, driveId string, itemId str
 		ParentFolder: *destinationParentFolder,
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId)
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId)
-	}
-
-	req, err := s.client.NewRequest("PATCH", apiURL, targetParentFolder)
+	req, err := s.client.NewRequest("PATCH", moveURL(driveId, itemId, conflictBehavior), targetParentFolder)
 	if err != nil {
 		return nil, err
 	}
 
 	var response *MoveItemResponse
-	err = s.client.Do(ctx, req, false, &response)
+	err = s.client.doItem(ctx, itemId, req, &response)
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.forgetPath(itemId)
+
 	return response, nil
 }
 
@@ -432,11 +727,13 @@ func (s *DriveItemsService) Rename(ctx context.Context, driveId string, itemId s
 	}
 
 	var response *RenameItemResponse
-	err = s.client.Do(ctx, req, false, &response)
+	err = s.client.doItem(ctx, itemId, req, &response)
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.forgetPath(itemId)
+
 	return response, nil
 }
 
@@ -461,18 +758,11 @@ func (s *DriveItemsService) Copy(ctx context.Context, sourceDriveId string, item
 	}
 
 	if destinationDriveId == "" {
-		reqDefaultDriveInfo, err := s.client.NewRequest("GET", "me/drive", nil)
-		if err != nil {
-			return nil, err
-		}
-
-		var defaultDrive *Drive
-		err = s.client.Do(ctx, reqDefaultDriveInfo, false, &defaultDrive)
+		var err error
+		destinationDriveId, err = s.client.Drives.defaultDriveId(ctx)
 		if err != nil {
 			return nil, err
 		}
-
-		destinationDriveId = defaultDrive.Id
 	}
 
 	destinationParentFolder := &ParentReference{
@@ -496,7 +786,7 @@ func (s *DriveItemsService) Copy(ctx context.Context, sourceDriveId string, item
 	}
 
 	var response *CopyItemResponse
-	err = s.client.Do(ctx, req, false, &response)
+	err = s.client.doItem(ctx, itemId, req, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -507,13 +797,46 @@ func (s *DriveItemsService) Copy(ctx context.Context, sourceDriveId string, item
 // UploadNewFile is to upload a file to a drive of the authenticated user.
 //
 // By default, this API will upload and then rename an item if there is an existing item
-// with the same name on OneDrive.
+// with the same name on OneDrive. See UploadNewFileWithOpts for the other behaviors.
 //
 // If driveId is empty, it means the selected drive will be the default drive of
 // the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-upload-a-new-file
 func (s *DriveItemsService) UploadNewFile(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string) (*DriveItem, error) {
+	return s.UploadNewFileWithOpts(ctx, destinationParentFolderId, localFilePath, UploadNewFileOpts{DriveID: driveId})
+}
+
+// UploadNewFileOpts represents the options for uploading a file by UploadNewFileWithOpts.
+type UploadNewFileOpts struct {
+	DriveID string
+	// ConflictBehavior customizes the conflict resolution behavior. By default,
+	// the new item will be renamed if there is an existing item with the same name.
+	// Possible values are "fail", "replace", or "rename".
+	ConflictBehavior string
+	// FileName is the name of the new item. By default, it is the name of the local file.
+	FileName string
+	// FileSystemInfo, if any, holds the times of the file to set on the new item instead
+	// of the time of the upload.
+	FileSystemInfo *FileSystemInfo
+	// PreserveModTime sets the last modification time of the new item to the one of the
+	// local file, unless FileSystemInfo already has one.
+	PreserveModTime bool
+	// VerifyIntegrity compares the hash OneDrive reports for the new item with the hash of
+	// the local file, and returns ErrUploadCorrupted if they differ, or ErrUploadUnverified
+	// if OneDrive reports no hash of this kind, along with the uploaded item.
+	VerifyIntegrity bool
+	// AwaitHash, with VerifyIntegrity, is how long to wait for OneDrive to report the hash
+	// of the new item when it is not reported right after the upload, see UploadLargeFileOpts.
+	AwaitHash time.Duration
+}
+
+// UploadNewFileWithOpts is to upload a file to a drive of the authenticated user with
+// options. The file is streamed from the disk rather than read into memory; files larger
+// than 4 MiB are uploaded through an upload session.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-upload-a-new-file
+func (s *DriveItemsService) UploadNewFileWithOpts(ctx context.Context, destinationParentFolderId string, localFilePath string, opts UploadNewFileOpts) (*DriveItem, error) {
 	if destinationParentFolderId == "" {
 		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for this new item.")
 	}
@@ -537,37 +860,45 @@ func (s *DriveItemsService) UploadNewFile(ctx context.Context, driveId string, d
 		return nil, errors.New("Only file is allowed to be uploaded here.")
 	}
 
-	fileSize := fileInfo.Size()
-
-	if fileSize > 4*1024*1024 {
-		return nil, errors.New("Only file with size less than or equal to 4MB is allowed to be uploaded here.")
+	fileName := opts.FileName
+	if fileName == "" {
+		fileName = fileInfo.Name()
 	}
 
-	fileName := fileInfo.Name()
-
-	apiURL := "me/drive/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(fileName) + ":/content?@microsoft.graph.conflictBehavior=rename"
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(fileName) + ":/content?@microsoft.graph.conflictBehavior=rename"
+	conflictBehavior := opts.ConflictBehavior
+	if conflictBehavior == "" {
+		conflictBehavior = "rename"
 	}
 
-	buffer := make([]byte, fileSize)
-	file.Read(buffer)
-	fileReader := bytes.NewReader(buffer)
+	fileSystemInfo := opts.FileSystemInfo
+	if opts.PreserveModTime && (fileSystemInfo == nil || fileSystemInfo.LastModifiedDateTime.IsZero()) {
+		info := FileSystemInfo{}
+		if fileSystemInfo != nil {
+			info = *fileSystemInfo
+		}
+		info.LastModifiedDateTime = fileInfo.ModTime().UTC()
+		fileSystemInfo = &info
+	}
 
-	fileType, _ := filetype.Match(buffer)
+	if !opts.VerifyIntegrity {
+		return s.uploadFile(ctx, opts.DriveID, destinationParentFolderId, fileName, file, fileInfo.Size(), conflictBehavior, fileSystemInfo)
+	}
 
-	req, err := s.client.NewFileUploadRequest(apiURL, fileType.MIME.Value, fileReader)
-	if err != nil {
+	// The file is hashed before the upload, which closes it.
+	digest := s.client.newUploadDigest()
+	if _, err := io.Copy(digest.hash, io.LimitReader(file, fileInfo.Size())); err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
 		return nil, err
 	}
 
-	var response *DriveItem
-	err = s.client.Do(ctx, req, false, &response)
+	item, err := s.uploadFile(ctx, opts.DriveID, destinationParentFolderId, fileName, file, fileInfo.Size(), conflictBehavior, fileSystemInfo)
 	if err != nil {
 		return nil, err
 	}
 
-	return response, nil
+	return s.verifyUpload(ctx, opts.DriveID, item, digest, opts.AwaitHash)
 }
 
 type UploadFileFromReaderOpts struct {
@@ -576,6 +907,9 @@ type UploadFileFromReaderOpts struct {
 	// existing item will be replaced. Possible values are "fail", "replace", or
 	// "rename".
 	ConflictBehavior string
+	// FileSystemInfo, if any, holds the times of the file to set on the new item instead
+	// of the time of the upload.
+	FileSystemInfo *FileSystemInfo
 }
 
 // UploadFileFromReader is to upload a file to a drive of the authenticated user
@@ -605,10 +939,7 @@ func (s *DriveItemsService) UploadFileFromReader(
 	// Limit data to 4MB
 	dataReader := io.LimitReader(fileData, 4*1024*1024)
 
-	apiURL := "me/drive/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(fileName) + ":/content"
-	if opts.DriveID != "" {
-		apiURL = "me/drives/" + url.PathEscape(opts.DriveID) + "/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(fileName) + ":/content"
-	}
+	apiURL := itemPathURL(opts.DriveID, destinationParentFolderId, fileName) + "/content"
 	if opts.ConflictBehavior != "" {
 		apiURL += "?@microsoft.graph.conflictBehavior=" + opts.ConflictBehavior
 	}
@@ -624,9 +955,278 @@ func (s *DriveItemsService) UploadFileFromReader(
 		return nil, err
 	}
 
+	s.client.markCreated(response)
+
+	return s.setUploadedFileSystemInfo(ctx, opts.DriveID, response, opts.FileSystemInfo)
+}
+
+// UploadBytesOpts represents the options for uploading data by UploadBytes.
+type UploadBytesOpts struct {
+	DriveID string
+	// ConflictBehavior customizes the conflict resolution behavior. By default,
+	// existing item will be replaced. Possible values are "fail", "replace", or
+	// "rename".
+	ConflictBehavior string
+	// FileSystemInfo, if any, holds the times of the file to set on the new item instead
+	// of the time of the upload.
+	FileSystemInfo *FileSystemInfo
+}
+
+// UploadBytes uploads data held in memory as a file to a drive of the authenticated user,
+// e.g. a JSON export or a generated thumbnail. If contentType is empty, the MIME type is
+// detected from the data. Data larger than 4 MiB is uploaded through an upload session,
+// in which case the MIME type is decided by OneDrive.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-upload-a-new-file
+func (s *DriveItemsService) UploadBytes(ctx context.Context, destinationParentFolderId string, fileName string, contentType string, data []byte, opts UploadBytesOpts) (*DriveItem, error) {
+	if destinationParentFolderId == "" {
+		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for this new item.")
+	}
+
+	if fileName == "" {
+		return nil, errors.New("Please provide the file name.")
+	}
+
+	if len(data) > 4*1024*1024 {
+		return s.UploadLargeFile(ctx, destinationParentFolderId, LargeFile{
+			Name: fileName,
+			Size: uint64(len(data)),
+			Data: bytes.NewReader(data),
+		}, UploadLargeFileOpts{DriveID: opts.DriveID, ConflictBehavior: opts.ConflictBehavior, FileSystemInfo: opts.FileSystemInfo})
+	}
+
+	if contentType == "" {
+		fileType, _ := filetype.Match(data)
+		contentType = fileType.MIME.Value
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	apiURL := itemPathURL(opts.DriveID, destinationParentFolderId, fileName) + "/content"
+	if opts.ConflictBehavior != "" {
+		apiURL += "?@microsoft.graph.conflictBehavior=" + opts.ConflictBehavior
+	}
+
+	req, err := s.client.NewFileUploadRequest(apiURL, contentType, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var response *DriveItem
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.markCreated(response)
+
+	return s.setUploadedFileSystemInfo(ctx, opts.DriveID, response, opts.FileSystemInfo)
+}
+
+// CreateEmptyFile creates a zero-byte file in a drive of the authenticated user, e.g. as a
+// placeholder or a lock file.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If conflictBehavior is empty, existing item will be replaced. Possible values
+// are "fail", "replace", or "rename".
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-upload-a-new-file
+func (s *DriveItemsService) CreateEmptyFile(ctx context.Context, driveId string, destinationParentFolderId string, fileName string, conflictBehavior string) (*DriveItem, error) {
+	if destinationParentFolderId == "" {
+		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for this new item.")
+	}
+
+	if fileName == "" {
+		return nil, errors.New("Please provide the file name.")
+	}
+
+	apiURL := itemPathURL(driveId, destinationParentFolderId, fileName) + "/content"
+	if conflictBehavior != "" {
+		apiURL += "?@microsoft.graph.conflictBehavior=" + conflictBehavior
+	}
+
+	req, err := s.client.NewFileUploadRequest(apiURL, "application/octet-stream", bytes.NewReader(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	var response *DriveItem
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.markCreated(response)
+
 	return response, nil
 }
 
+// UploadNewFileToPathOpts represents the options for uploading a file by UploadNewFileToPath.
+type UploadNewFileToPathOpts struct {
+	DriveID string
+	// ContentType is the MIME type of the file. By default, it is detected from
+	// the content of the file.
+	ContentType string
+	// ConflictBehavior customizes the conflict resolution behavior. By default,
+	// existing item will be replaced. Possible values are "fail", "replace", or
+	// "rename".
+	ConflictBehavior string
+	// CreateParents creates the missing parent folders of the destination path.
+	CreateParents bool
+	// CheckQuota makes the upload of a file larger than 4 MiB fail fast with
+	// ErrInsufficientQuota when the remaining quota of the drive is smaller than
+	// the file.
+	CheckQuota bool
+}
+
+// UploadNewFileToPath is to upload a file to a drive of the authenticated user, addressing the
+// destination by its path from the root of the drive, e.g. "Reports/2024/summary.pdf",
+// instead of by the ID of its parent folder.
+//
+// Files larger than 4 MiB are uploaded through an upload session, which is only possible
+// when fileData is an io.ReaderAt whose size is known, such as *os.File or *bytes.Reader.
+//
+// If opts.DriveID is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-upload-a-new-file
+func (s *DriveItemsService) UploadNewFileToPath(ctx context.Context, destinationPath string, fileData io.Reader, opts UploadNewFileToPathOpts) (*DriveItem, error) {
+	destinationPath = strings.Trim(destinationPath, "/")
+	if destinationPath == "" {
+		return nil, errors.New("Please provide the destination, i.e. the path of the new item.")
+	}
+
+	if fileData == nil {
+		return nil, errors.New("Please provide the file reader.")
+	}
+
+	query := ""
+	if opts.ConflictBehavior != "" {
+		query = "?@microsoft.graph.conflictBehavior=" + opts.ConflictBehavior
+	}
+
+	var upload func() (*DriveItem, error)
+	if readerAt, size, ok := readerAtSize(fileData); ok && size > 4*1024*1024 {
+		if opts.CheckQuota {
+			if err := s.checkQuota(ctx, opts.DriveID, uint64(size)); err != nil {
+				return nil, err
+			}
+		}
+
+		file := LargeFile{Name: path.Base(destinationPath), Size: uint64(size), Data: readerAt}
+		upload = func() (response *DriveItem, err error) {
+			err = s.doPath(ctx, opts.DriveID, destinationPath, func(apiURL string) error {
+				response, err = s.uploadLargeFile(ctx, apiURL+"/createUploadSession"+query, file, nil, 4*1024*1024, nil, nil, nil)
+				return err
+			})
+			return response, err
+		}
+	} else {
+		buffer, err := ioutil.ReadAll(io.LimitReader(fileData, 4*1024*1024+1))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(buffer) > 4*1024*1024 {
+			return nil, errors.New("Only file with size less than or equal to 4MB is allowed to be uploaded here, unless the reader is an io.ReaderAt of known size.")
+		}
+
+		contentType := opts.ContentType
+		if contentType == "" {
+			fileType, _ := filetype.Match(buffer)
+			contentType = fileType.MIME.Value
+		}
+
+		upload = func() (response *DriveItem, err error) {
+			err = s.doPath(ctx, opts.DriveID, destinationPath, func(apiURL string) error {
+				req, err := s.client.NewFileUploadRequest(apiURL+"/content"+query, contentType, bytes.NewReader(buffer))
+				if err != nil {
+					return err
+				}
+
+				return s.client.Do(ctx, req, false, &response)
+			})
+			return response, err
+		}
+	}
+
+	response, err := upload()
+	if oneDriveError, ok := err.(*Error); ok && oneDriveError.StatusCode == http.StatusNotFound && opts.CreateParents {
+		if err := s.createParentFolders(ctx, opts.DriveID, path.Dir(destinationPath)); err != nil {
+			return nil, err
+		}
+		response, err = upload()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.markCreated(response)
+
+	return response, nil
+}
+
+// createParentFolders creates every missing folder of a path from the root of a drive of
+// the authenticated user.
+func (s *DriveItemsService) createParentFolders(ctx context.Context, driveId string, folderPath string) error {
+	// path.Dir returns "." for a file at the root; the dots of folder names are kept.
+	folderPath = strings.Trim(folderPath, "/")
+	if folderPath == "" || folderPath == "." {
+		return nil
+	}
+
+	segments := strings.Split(folderPath, "/")
+	for i, segment := range segments {
+		newFolder := &NewFolderCreationRequest{
+			FolderName:       segment,
+			ConflictBehavior: "fail",
+		}
+
+		err := s.doPath(ctx, driveId, strings.Join(segments[:i], "/"), func(apiURL string) error {
+			req, err := s.client.NewRequest("POST", apiURL+"/children", newFolder)
+			if err != nil {
+				return err
+			}
+
+			var driveItem *DriveItem
+			return s.client.Do(ctx, req, false, &driveItem)
+		})
+		if oneDriveError, ok := err.(*Error); ok && oneDriveError.Code == "nameAlreadyExists" {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readerAtSize returns the reader as an io.ReaderAt together with its total size, when the
+// reader supports random access and its size can be known.
+func readerAtSize(r io.Reader) (io.ReaderAt, int64, bool) {
+	readerAt, ok := r.(io.ReaderAt)
+	if !ok {
+		return nil, 0, false
+	}
+
+	switch sized := r.(type) {
+	case interface{ Size() int64 }:
+		return readerAt, sized.Size(), true
+	case interface{ Stat() (os.FileInfo, error) }:
+		fileInfo, err := sized.Stat()
+		if err != nil || !fileInfo.Mode().IsRegular() {
+			return nil, 0, false
+		}
+		return readerAt, fileInfo.Size(), true
+	}
+
+	return nil, 0, false
+}
+
 // UploadSession provides information about how to upload large files to
 // OneDrive, OneDrive for Business, or SharePoint document libraries.
 //
@@ -653,6 +1253,36 @@ type UploadLargeFileOpts struct {
 	ConflictBehavior string
 	// ChunkSize customizes the size of chunks. Default is 4 MiB.
 	ChunkSize uint64
+	// CheckQuota makes the upload fail fast with ErrInsufficientQuota when the
+	// remaining quota of the drive is smaller than the file, instead of failing
+	// only once most of the file has been uploaded.
+	CheckQuota bool
+	// SessionStore, if any, keeps a record of the upload session while the file is being
+	// uploaded, so the sessions abandoned by a crash can be cancelled afterwards with
+	// CancelStaleUploadSessions.
+	SessionStore UploadSessionStore
+	// FileSystemInfo, if any, holds the times of the file on the local file system, which
+	// are set on the item instead of the time of the upload, e.g. to keep the original
+	// modification times of backed-up files.
+	FileSystemInfo *FileSystemInfo
+	// Description, if any, is set on the item.
+	Description string
+	// DeferCommit makes the item be created only once all the bytes have been uploaded,
+	// by committing the upload session, so no partial file is ever visible and the
+	// metadata above is attached atomically.
+	DeferCommit bool
+	// VerifyIntegrity compares the hash OneDrive reports for the new item with the hash of
+	// the uploaded data, and returns ErrUploadCorrupted if they differ, or
+	// ErrUploadUnverified if OneDrive reports no hash of this kind, along with the uploaded
+	// item. The data is hashed as it is uploaded, and only read again if the upload did not
+	// read it in order.
+	VerifyIntegrity bool
+	// AwaitHash, with VerifyIntegrity, is how long to wait for OneDrive to report the hash
+	// of the new item, which can lag behind the upload. Until then, the item is fetched
+	// again with a backoff; ErrUploadUnverified is returned if the hash is still missing.
+	// If it is zero, ErrUploadUnverified is returned right away for an item reported without
+	// the hash.
+	AwaitHash time.Duration
 }
 
 // UploadLargeFile is to upload a file larger than 4 MiB to a drive of the
@@ -670,6 +1300,22 @@ func (s *DriveItemsService) UploadLargeFile(
 	file LargeFile,
 	opts UploadLargeFileOpts,
 ) (*DriveItem, error) {
+	upload, err := s.prepareLargeUpload(ctx, destinationParentFolderId, file, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return upload(ctx, nil)
+}
+
+// prepareLargeUpload checks the file to upload by UploadLargeFile, and returns the function
+// uploading it, calling beforeChunk, if any, before every chunk.
+func (s *DriveItemsService) prepareLargeUpload(
+	ctx context.Context,
+	destinationParentFolderId string,
+	file LargeFile,
+	opts UploadLargeFileOpts,
+) (func(ctx context.Context, beforeChunk chunkHook) (*DriveItem, error), error) {
 	if destinationParentFolderId == "" {
 		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for this new item.")
 	}
@@ -684,59 +1330,188 @@ func (s *DriveItemsService) UploadLargeFile(
 		return nil, errors.New("Please provide the file reader.")
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(file.Name) + ":/createUploadSession"
-	if opts.DriveID != "" {
-		apiURL = "me/drives/" + url.PathEscape(opts.DriveID) + "/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(file.Name) + ":/createUploadSession"
-	}
+	apiURL := itemPathURL(opts.DriveID, destinationParentFolderId, file.Name) + "/createUploadSession"
 	if opts.ConflictBehavior != "" {
 		apiURL += "?@microsoft.graph.conflictBehavior=" + opts.ConflictBehavior
 	}
 
-	apiUrl, err := s.client.BaseURL.Parse(apiURL)
-	if err != nil {
-		return nil, err
+	if opts.CheckQuota {
+		if err := s.checkQuota(ctx, opts.DriveID, file.Size); err != nil {
+			return nil, err
+		}
 	}
 
-	req, err := http.NewRequest("POST", apiUrl.String(), nil)
+	var chunkSize uint64 = 4 * 1024 * 1024
+	if opts.ChunkSize != 0 {
+		chunkSize = opts.ChunkSize
+	}
+	record := &UploadSessionRecord{DriveId: opts.DriveID, ParentFolderId: destinationParentFolderId, Name: file.Name, Size: int64(file.Size)}
+	sessionRequest := newUploadSessionRequest(file.Name, opts.ConflictBehavior, opts.Description, opts.FileSystemInfo, opts.DeferCommit)
+
+	return func(ctx context.Context, beforeChunk chunkHook) (*DriveItem, error) {
+		upload := file
+		var digest *uploadDigest
+		var reader *hashingReaderAt
+		if opts.VerifyIntegrity {
+			digest = s.client.newUploadDigest()
+			reader = &hashingReaderAt{reader: file.Data, hash: digest.hash}
+			upload.Data = reader
+		}
+
+		response, err := s.uploadLargeFile(ctx, apiURL, upload, sessionRequest, chunkSize, opts.SessionStore, record, beforeChunk)
+		if err != nil {
+			return nil, err
+		}
+
+		s.client.markCreated(response)
+
+		if digest != nil {
+			if err := digest.rehash(reader, file); err != nil {
+				return nil, err
+			}
+			return s.verifyUpload(ctx, opts.DriveID, response, digest, opts.AwaitHash)
+		}
+
+		return response, nil
+	}, nil
+}
+
+// checkQuota returns ErrInsufficientQuota if the remaining quota of a drive of the
+// authenticated user is smaller than size. Drives over their quota report a negative
+// remaining quota.
+func (s *DriveItemsService) checkQuota(ctx context.Context, driveId string, size uint64) error {
+	drive, err := s.client.Drives.Get(ctx, driveId)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if drive.Quota == nil || drive.Quota.Total <= 0 {
+		return nil
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	var session UploadSession
-	err = s.client.Do(ctx, req, false, &session)
+	if remaining := int64(drive.Quota.Remaining); remaining <= 0 || uint64(remaining) < size {
+		return fmt.Errorf("%w: %d bytes remaining, %d bytes needed", ErrInsufficientQuota, drive.Quota.Remaining, size)
+	}
+
+	return nil
+}
+
+// uploadLargeFile creates an upload session with the given createUploadSession URL and
+// body, if any, and uploads the file through it in chunks, see uploadChunks. If store is not
+// nil, record is saved into it, completed with the session, until the upload is over.
+//
+// The session is deleted once the upload is over, unless the upload has been suspended by
+// beforeChunk with ErrUploadSuspended, so it can be resumed. If it cannot be deleted, its
+// record is kept in store and the error is returned, unless the upload failed already.
+func (s *DriveItemsService) uploadLargeFile(ctx context.Context, apiURL string, file LargeFile, sessionRequest *uploadSessionRequest, chunkSize uint64, store UploadSessionStore, record *UploadSessionRecord, beforeChunk chunkHook) (item *DriveItem, err error) {
+	session, err := s.createUploadSession(ctx, apiURL, sessionRequest)
 	if err != nil {
 		return nil, err
 	}
+	if s.client.dryRunJournal != nil {
+		// The upload session has only been recorded, there is nothing to upload the chunks to.
+		return &DriveItem{Name: file.Name, Size: int64(file.Size)}, nil
+	}
+	if store != nil {
+		record.UploadURL = session.UploadUrl
+		record.ExpirationDateTime = session.ExpirationDateTime
+		record.CreatedDateTime = s.client.now()
+		if err := store.Save(ctx, *record); err != nil {
+			return nil, err
+		}
+	}
 	defer func() {
-		req, err := http.NewRequest("DELETE", session.UploadUrl, nil)
-		if err != nil {
-			return // err
+		if errors.Is(err, ErrUploadSuspended) {
+			return
 		}
-		resp, err := s.client.client.Do(req)
-		if err != nil {
-			return // err
+
+		// The session is deleted even when ctx is done, e.g. once the upload got canceled.
+		if cancelErr := s.cancelUploadSession(detachedContext{ctx}, session.UploadUrl); cancelErr != nil {
+			// The record is kept so the session can still be cancelled from the store.
+			if err == nil {
+				err = cancelErr
+			}
+			return
 		}
-		if resp.StatusCode != 204 {
-			return // err
+		if store != nil {
+			store.Delete(ctx, session.UploadUrl)
 		}
 	}()
 
-	var chunkSize uint64 = 4 * 1024 * 1024
-	if opts.ChunkSize != 0 {
-		chunkSize = opts.ChunkSize
-	}
 	buffer := make([]byte, chunkSize)
-	return s.uploadChunk(ctx, session.UploadUrl, buffer, 0, chunkSize, file)
+	return s.uploadChunks(ctx, session.UploadUrl, buffer, 0, chunkSize, file, beforeChunk)
 }
 
-func (s *DriveItemsService) uploadChunk(
-	ctx context.Context,
-	sessURL string,
-	buffer []byte,
-	offset, length uint64,
-	file LargeFile,
-) (*DriveItem, error) {
+// chunkHook is called by uploadChunks before uploading the chunk starting at offset to the
+// upload session at uploadURL, i.e. once the bytes before it have been uploaded.
+type chunkHook func(ctx context.Context, uploadURL string, offset uint64) error
+
+// maxChunkRetries is the maximum number of times in a row a chunk which failed transiently
+// is retried, see uploadChunks.
+const maxChunkRetries = 5
+
+// uploadChunks uploads the file through an upload session, starting with the chunk at
+// offset, until the session returns the item. The chunks are at most len(buffer) bytes
+// long. A session which has received all the bytes without returning the item, because it
+// was created with deferCommit, is committed.
+//
+// When a chunk fails transiently, i.e. with a network error, 429 or 5xx, the failure is
+// backed off, then the session is asked which bytes it is still expecting and the upload
+// continues from there.
+//
+// beforeChunk, if any, is called before every chunk, and stops the upload if it fails.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online#resuming-an-in-progress-upload
+func (s *DriveItemsService) uploadChunks(ctx context.Context, sessURL string, buffer []byte, offset, length uint64, file LargeFile, beforeChunk chunkHook) (*DriveItem, error) {
+	chunkSize := uint64(len(buffer))
+	failures := 0
+	delay := time.Second
+	for {
+		if beforeChunk != nil {
+			if err := beforeChunk(ctx, sessURL, offset); err != nil {
+				return nil, err
+			}
+		}
+
+		item, session, transient, err := s.putChunk(ctx, sessURL, buffer, offset, length, file)
+		switch {
+		case err == nil && item != nil:
+			return item, nil
+		case err == nil:
+			failures, delay = 0, time.Second
+		case !transient || ctx.Err() != nil || failures >= maxChunkRetries:
+			return nil, err
+		default:
+			failures++
+			if err := s.client.sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+			if delay < 30*time.Second {
+				delay *= 2
+			}
+
+			var statusErr error
+			session, statusErr = s.UploadSessionStatus(ctx, sessURL)
+			if statusErr != nil {
+				return nil, err
+			}
+		}
+
+		if len(session.NextExpectedRanges) < 1 {
+			return s.commitUploadSession(ctx, sessURL)
+		}
+
+		offset, length, err = nextChunk(session.NextExpectedRanges[0], chunkSize, file.Size)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// putChunk uploads length bytes of the file from offset. It returns the item once the file
+// is complete, or the session expecting the next chunks. transient reports whether err is
+// worth retrying.
+func (s *DriveItemsService) putChunk(ctx context.Context, sessURL string, buffer []byte, offset, length uint64, file LargeFile) (item *DriveItem, session *UploadSession, transient bool, err error) {
 	if uint64(len(buffer)) < length {
 		buffer = make([]byte, length)
 	}
@@ -749,14 +1524,14 @@ func (s *DriveItemsService) uploadChunk(
 		if err == io.EOF {
 			// We should have get DataItem object as response already. No data to read, and no
 			// data in buffer. No other chunk! We have nothing to send to get it.
-			return nil, errors.New("unexpected EOF")
+			return nil, nil, false, errors.New("unexpected EOF")
 		}
-		return nil, err
+		return nil, nil, false, err
 	}
 	buffer = buffer[:n]
-	uploadReq, err := http.NewRequestWithContext(ctx, "PUT", sessURL, bytes.NewReader(buffer))
+	uploadReq, err := http.NewRequestWithContext(ctx, "PUT", s.client.uploadURL(sessURL), bytes.NewReader(buffer))
 	if err != nil {
-		return nil, err
+		return nil, nil, false, err
 	}
 	uploadReq.Header.Set("Content-Length", strconv.Itoa(n))
 	uploadReq.Header.Set("Content-Range",
@@ -768,93 +1543,188 @@ func (s *DriveItemsService) uploadChunk(
 	)
 	resp, err := s.client.client.Do(uploadReq)
 	if err != nil {
-		return nil, processHTTPError(ctx, err)
+		return nil, nil, true, processHTTPError(ctx, err)
 	}
 	defer resp.Body.Close()
 
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, true, err
 	}
-	switch resp.StatusCode {
+	switch {
 	// File is complete
 	// https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession#completing-a-file
-	case 200, 201:
-		var item DriveItem
+	case resp.StatusCode == 200 || resp.StatusCode == 201:
 		if err := json.Unmarshal(responseBody, &item); err != nil {
-			return nil, err
+			return nil, nil, false, err
 		}
-		return &item, nil
+		return item, nil, false, nil
 	// Next chunk expected
 	// https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession#response-1
-	case 202:
-		var session UploadSession
+	case resp.StatusCode == 202:
 		if err := json.Unmarshal(responseBody, &session); err != nil {
-			return nil, err
+			return nil, nil, false, err
 		}
+		return nil, session, false, nil
+	default:
+		transient = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
 
-		if len(session.NextExpectedRanges) < 1 {
-			return nil, fmt.Errorf("next expected ranges is empty, but we didn't receive DriveItem obejct in response")
+		var oneDriveError *ErrorResponse
+		if err := json.Unmarshal(responseBody, &oneDriveError); err != nil || oneDriveError == nil || oneDriveError.Error == nil {
+			return nil, nil, transient, fmt.Errorf("%s: %s", resp.Status, responseBody)
 		}
+		oneDriveError.Error.StatusCode = resp.StatusCode
+		return nil, nil, transient, oneDriveError.Error
+	}
+}
 
-		sp := strings.Split(session.NextExpectedRanges[0], "-")
-		start, end := sp[0], sp[1]
-		nextOffset, err := strconv.ParseUint(start, 10, 64)
-		if err != nil {
-			return nil, err
-		}
-		nextLength := length
-		if end != "" {
-			nextLength, err = strconv.ParseUint(end, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-		}
+// nextChunk returns the offset and the length of the next chunk to upload from the first
+// of the nextExpectedRanges of an upload session, e.g. "26-" or "26-99".
+func nextChunk(expectedRange string, chunkSize uint64, size uint64) (uint64, uint64, error) {
+	offset, err := nextExpectedOffset(expectedRange)
+	if err != nil {
+		return 0, 0, err
+	}
+	if offset >= size {
+		return 0, 0, fmt.Errorf("The upload session is expecting byte %d of a file of %d bytes.", offset, size)
+	}
 
-		// upload next chunk and expect the final to return DriverItem.
-		return s.uploadChunk(ctx, sessURL, buffer, nextOffset, nextLength, file)
-	default:
-		var oneDriveError *ErrorResponse
-		if err := json.Unmarshal(responseBody, &oneDriveError); err != nil {
-			return nil, err
+	length := size - offset
+	if i := strings.Index(expectedRange, "-"); i >= 0 && expectedRange[i+1:] != "" {
+		end, err := strconv.ParseUint(expectedRange[i+1:], 10, 64)
+		if err != nil || end < offset {
+			return 0, 0, fmt.Errorf("The expected range %q of the upload session is not valid.", expectedRange)
 		}
-		if oneDriveError.Error == nil {
-			return nil, fmt.Errorf("%s: %s", resp.Status, responseBody)
+		if end-offset+1 < length {
+			length = end - offset + 1
 		}
-		return nil, oneDriveError.Error
 	}
+	if length > chunkSize {
+		length = chunkSize
+	}
+
+	return offset, length, nil
 }
 
 func (s *DriveItemsService) DownloadItem(ctx context.Context, item *DriveItem) ([]byte, error) {
-	if item.DownloadURL == "" {
-		var err error
-		item, err = s.Get(ctx, item.Id)
-		if err != nil {
-			return nil, err
-		}
+	content, err := s.DownloadItemStream(ctx, item)
+	if err != nil {
+		return nil, err
 	}
+	defer content.Close()
 
-	resp, err := s.client.client.Get(item.DownloadURL)
+	body, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, processHTTPError(ctx, err)
+	}
+	return body, nil
+}
+
+// DownloadItemStream opens the content of a file in a drive of the authenticated user, so
+// files of any size can be piped to disk or to another service without being held in
+// memory. The caller must close the returned reader.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (s *DriveItemsService) DownloadItemStream(ctx context.Context, item *DriveItem) (io.ReadCloser, error) {
+	item, err := s.downloadableItem(ctx, item)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	return s.openDownload(ctx, item)
+}
+
+// DownloadItemRange opens length bytes of the content of a file in a drive of the
+// authenticated user, starting at offset, e.g. to preview the head of a large file or to
+// resume a download. A length of 0 reads until the end of the file. The caller must close
+// the returned reader.
+//
+// A Range header is sent to the download URL; if the range is ignored, the bytes out of
+// the range are skipped on the client side.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online#partial-range-downloads
+func (s *DriveItemsService) DownloadItemRange(ctx context.Context, item *DriveItem, offset int64, length int64) (io.ReadCloser, error) {
+	if offset < 0 || length < 0 {
+		return nil, errors.New("Please provide an offset and a length which are not negative.")
+	}
+
+	item, err := s.downloadableItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange += strconv.FormatInt(offset+length-1, 10)
+	}
+
+	return s.openDownloadRange(ctx, item.DownloadURL, byteRange, offset, length)
+}
+
+// DownloadItemAsFormat downloads the content of a file in a drive of the authenticated user
+// converted to another format, e.g. an Office document as PDF, without a separate
+// conversion service.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content_format?view=odsp-graph-online
+func (s *DriveItemsService) DownloadItemAsFormat(ctx context.Context, item *DriveItem, format DownloadFormat) ([]byte, error) {
+	if item == nil || item.Id == "" {
+		return nil, errors.New("Please provide the item to download.")
+	}
+
+	if !format.isValid() {
+		return nil, errors.New("Please provide a supported format.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(item.Id) + "/content?format=" + format.toString()
+	if item.ParentReference != nil && item.ParentReference.DriveId != "" {
+		apiURL = "drives/" + url.PathEscape(item.ParentReference.DriveId) + "/items/" + url.PathEscape(item.Id) + "/content?format=" + format.toString()
+	}
+
+	contentURL, err := s.client.BaseURL.Parse(apiURL)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(body, &errResp); err != nil {
+
+	// The converted content is served at a pre-authenticated URL the request is redirected to.
+	content, err := s.openDownloadRange(ctx, contentURL.String(), "", 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	body, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, processHTTPError(ctx, err)
+	}
+	return body, nil
+}
+
+// downloadableItem returns the item with its download URL, retrieving it again if needed.
+func (s *DriveItemsService) downloadableItem(ctx context.Context, item *DriveItem) (*DriveItem, error) {
+	if item == nil || item.Id == "" {
+		return nil, errors.New("Please provide the item to download.")
+	}
+
+	if item.IsPackage() {
+		return nil, ErrPackageItem
+	}
+
+	if item.DownloadURL == "" {
+		var err error
+		item, err = s.Get(ctx, item.Id)
+		if err != nil {
 			return nil, err
 		}
-		if errResp.Error == nil {
-			return nil, fmt.Errorf("%s: %s", resp.Status, string(body))
+		if item.IsPackage() {
+			return nil, ErrPackageItem
 		}
-		return nil, errResp.Error
 	}
-	return body, nil
+
+	if item.DownloadURL == "" {
+		return nil, errors.New("Only file is allowed to be downloaded here.")
+	}
+
+	return item, nil
 }
 
 // UploadToReplaceFile is to upload a file to replace an existing file in a drive of the authenticated user.
@@ -924,7 +1794,7 @@ func (s *DriveItemsService) UploadToReplaceFile(ctx context.Context, driveId str
 	}
 
 	var response *DriveItem
-	err = s.client.Do(ctx, req, false, &response)
+	err = s.client.doItem(ctx, itemId, req, &response)
 	if err != nil {
 		return nil, err
 	}
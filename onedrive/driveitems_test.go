@@ -7,12 +7,16 @@ package onedrive
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDriveItemsService_ListRoot_authenticatedUser(t *testing.T) {
@@ -98,3 +102,652 @@ func TestDriveItemsService_Get_authenticatedUser(t *testing.T) {
 	}
 
 }
+
+func TestDriveItemsService_ListWithOpts_orderBy(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.Query().Get("$orderby"), "lastModifiedDateTime desc"; got != want {
+			t.Errorf("Request $orderby: %q, want %q", got, want)
+		}
+
+		jsonData := getTestDataFromFile(t, "fake_driveItems.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	_, err := client.DriveItems.ListWithOpts(ctx, "1", ListOpts{OrderBy: LastModifiedDesc})
+	if err != nil {
+		t.Errorf("DriveItems.ListWithOpts returned error: %v", err)
+	}
+
+	_, err = client.DriveItems.ListWithOpts(ctx, "1", ListOpts{OrderBy: ListOrderBy(42)})
+	if err == nil {
+		t.Errorf("DriveItems.ListWithOpts should reject an unsupported order")
+	}
+}
+
+func TestDriveItemsService_ListAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.Query().Get("$top"), "2"; got != want {
+			t.Errorf("Request $top: %q, want %q", got, want)
+		}
+
+		switch r.URL.Query().Get("$skiptoken") {
+		case "":
+			fmt.Fprintf(w, `{"value": [{"id": "a"}, {"id": "b"}], "@odata.nextLink": "%sme/drive/items/1/children?$top=2&$skiptoken=page2"}`, client.BaseURL)
+		case "page2":
+			fmt.Fprint(w, `{"value": [{"id": "c"}]}`)
+		}
+	})
+
+	ctx := context.Background()
+	page, err := client.DriveItems.ListPage(ctx, "1", ListOpts{PageSize: 2}, "")
+	if err != nil {
+		t.Fatalf("DriveItems.ListPage returned error: %v", err)
+	}
+	if len(page.DriveItems) != 2 || page.NextLink == "" {
+		t.Errorf("DriveItems.ListPage returned %d items and next link %q, want 2 items and a next link", len(page.DriveItems), page.NextLink)
+	}
+
+	all, err := client.DriveItems.ListAll(ctx, "1", ListOpts{PageSize: 2})
+	if err != nil {
+		t.Fatalf("DriveItems.ListAll returned error: %v", err)
+	}
+
+	var gotIds []string
+	for _, item := range all.DriveItems {
+		gotIds = append(gotIds, item.Id)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(gotIds, want) || all.NextLink != "" {
+		t.Errorf("DriveItems.ListAll returned %v and next link %q, want %v", gotIds, all.NextLink, want)
+	}
+
+	if _, err := client.DriveItems.ListAll(ctx, "1", ListOpts{PageSize: -1}); err == nil {
+		t.Errorf("DriveItems.ListAll should reject a negative page size")
+	}
+}
+
+func TestDriveItemsService_DownloadItem_package(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "1", "name": "Notebook", "package": {"type": "oneNote"}}`)
+	})
+
+	ctx := context.Background()
+	_, err := client.DriveItems.DownloadItem(ctx, &DriveItem{Id: "1"})
+	if err != ErrPackageItem {
+		t.Errorf("DriveItems.DownloadItem returned error %v, want %v", err, ErrPackageItem)
+	}
+}
+
+func TestDriveItemsService_DownloadItem_canceled(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, serverURL+baseURLPath+"/download/1/content", http.StatusFound)
+	})
+	mux.HandleFunc("/download/1/content", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	})
+
+	item := &DriveItem{Id: "1", File: &DriveItemFile{}, DownloadURL: serverURL + baseURLPath + "/download/1"}
+	_, err := client.DriveItems.DownloadItem(ctx, item)
+	if err != context.Canceled {
+		t.Errorf("DriveItems.DownloadItem returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDriveItemsService_DownloadItemStream(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprintf(w, `{"id": "1", "name": "a.txt", "file": {}, "@microsoft.graph.downloadUrl": "%s%s/download/1"}`, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, "content of a.txt")
+	})
+
+	content, err := client.DriveItems.DownloadItemStream(context.Background(), &DriveItem{Id: "1"})
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadItemStream returned error: %v", err)
+	}
+	defer content.Close()
+
+	got, err := ioutil.ReadAll(content)
+	if err != nil {
+		t.Fatalf("Cannot read the content: %v", err)
+	}
+	if want := "content of a.txt"; string(got) != want {
+		t.Errorf("DriveItems.DownloadItemStream returned %q, want %q", got, want)
+	}
+}
+
+func TestDriveItemsService_DownloadItemRange(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		http.ServeContent(w, r, "a.txt", time.Time{}, strings.NewReader("content of a.txt"))
+	})
+	mux.HandleFunc("/download/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content of b.txt")
+	})
+
+	tests := []struct {
+		itemId         string
+		offset, length int64
+		want           string
+	}{
+		{"1", 11, 1, "a"},
+		{"1", 11, 0, "a.txt"},
+		{"2", 11, 1, "b"},
+		{"2", 11, 0, "b.txt"},
+	}
+	for _, test := range tests {
+		item := &DriveItem{Id: test.itemId, File: &DriveItemFile{}, DownloadURL: serverURL + baseURLPath + "/download/" + test.itemId}
+		content, err := client.DriveItems.DownloadItemRange(context.Background(), item, test.offset, test.length)
+		if err != nil {
+			t.Fatalf("DriveItems.DownloadItemRange(%s, %d, %d) returned error: %v", test.itemId, test.offset, test.length, err)
+		}
+
+		got, err := ioutil.ReadAll(content)
+		content.Close()
+		if err != nil {
+			t.Fatalf("Cannot read the content: %v", err)
+		}
+		if string(got) != test.want {
+			t.Errorf("DriveItems.DownloadItemRange(%s, %d, %d) returned %q, want %q", test.itemId, test.offset, test.length, got, test.want)
+		}
+	}
+
+	if _, err := client.DriveItems.DownloadItemRange(context.Background(), &DriveItem{Id: "1"}, -1, 0); err == nil {
+		t.Error("DriveItems.DownloadItemRange returned no error for a negative offset")
+	}
+}
+
+func TestDriveItemsService_DownloadItemAsFormat(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/d/items/1/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("format"), "pdf"; got != want {
+			t.Errorf("format = %q, want %q", got, want)
+		}
+
+		http.Redirect(w, r, serverURL+baseURLPath+"/converted/1", http.StatusFound)
+	})
+	mux.HandleFunc("/converted/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "%PDF-1.7")
+	})
+
+	item := &DriveItem{Id: "1", ParentReference: &ParentReference{DriveId: "d"}}
+	got, err := client.DriveItems.DownloadItemAsFormat(context.Background(), item, PDFFormat)
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadItemAsFormat returned error: %v", err)
+	}
+	if want := "%PDF-1.7"; string(got) != want {
+		t.Errorf("DriveItems.DownloadItemAsFormat returned %q, want %q", got, want)
+	}
+
+	if _, err := client.DriveItems.DownloadItemAsFormat(context.Background(), item, DownloadFormat(42)); err == nil {
+		t.Error("DriveItems.DownloadItemAsFormat returned no error for an unsupported format")
+	}
+}
+
+func TestDriveItemsService_UploadNewFileWithOpts(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "local.txt")
+	if err := ioutil.WriteFile(localPath, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/me/drives/d/items/folder-1:/remote.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		if got, want := r.URL.Query().Get("@microsoft.graph.conflictBehavior"), "fail"; got != want {
+			t.Errorf("conflictBehavior = %q, want %q", got, want)
+		}
+		if r.ContentLength != 5 {
+			t.Errorf("Content-Length = %d, want 5", r.ContentLength)
+		}
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != "hello" {
+			t.Errorf("The body is %q, want %q", body, "hello")
+		}
+		fmt.Fprint(w, `{"id":"1","name":"remote.txt"}`)
+	})
+
+	item, err := client.DriveItems.UploadNewFileWithOpts(context.Background(), "folder-1", localPath, UploadNewFileOpts{
+		DriveID:          "d",
+		ConflictBehavior: "fail",
+		FileName:         "remote.txt",
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadNewFileWithOpts returned error: %v", err)
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.UploadNewFileWithOpts returned %+v", item)
+	}
+}
+
+func TestDriveItemsService_UploadBytes(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder-1:/export.json:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		if got, want := r.URL.Query().Get("@microsoft.graph.conflictBehavior"), "rename"; got != want {
+			t.Errorf("conflictBehavior = %q, want %q", got, want)
+		}
+		if got, want := r.Header.Get("Content-Type"), "application/json"; got != want {
+			t.Errorf("Content-Type = %q, want %q", got, want)
+		}
+		if r.ContentLength != 11 {
+			t.Errorf("Content-Length = %d, want 11", r.ContentLength)
+		}
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != `{"a":"b c"}` {
+			t.Errorf("The body is %q", body)
+		}
+		fmt.Fprint(w, `{"id":"1","name":"export.json"}`)
+	})
+
+	ctx := context.Background()
+	item, err := client.DriveItems.UploadBytes(ctx, "folder-1", "export.json", "application/json", []byte(`{"a":"b c"}`), UploadBytesOpts{ConflictBehavior: "rename"})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadBytes returned error: %v", err)
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.UploadBytes returned %+v", item)
+	}
+
+	if _, err := client.DriveItems.UploadBytes(ctx, "folder-1", "", "", []byte("x"), UploadBytesOpts{}); err == nil {
+		t.Error("DriveItems.UploadBytes returned no error for an empty file name")
+	}
+}
+
+func TestDriveItemsService_UploadNewFileToPath_createParents(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	uploaded := false
+	var createdFolders []string
+
+	mux.HandleFunc("/me/drive/root:/Reports/2024/summary.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		if len(createdFolders) < 2 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+			return
+		}
+
+		uploaded = true
+		fmt.Fprint(w, `{"id": "new-item", "name": "summary.txt"}`)
+	})
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		createdFolders = append(createdFolders, "Reports")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"error": {"code": "nameAlreadyExists", "message": "Name already exists"}}`)
+	})
+	mux.HandleFunc("/me/drive/root:/Reports:/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		createdFolders = append(createdFolders, "2024")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "folder-2024", "name": "2024", "folder": {}}`)
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveItems.UploadNewFileToPath(ctx, "Reports/2024/summary.txt", strings.NewReader("summary"), UploadNewFileToPathOpts{CreateParents: true})
+	if err != nil {
+		t.Errorf("DriveItems.UploadNewFileToPath returned error: %v", err)
+	}
+
+	if !uploaded || gotDriveItem == nil || gotDriveItem.Id != "new-item" {
+		t.Errorf("DriveItems.UploadNewFileToPath returned %+v, want the uploaded item", gotDriveItem)
+	}
+
+	if want := []string{"Reports", "2024"}; !reflect.DeepEqual(createdFolders, want) {
+		t.Errorf("DriveItems.UploadNewFileToPath created folders %v, want %v", createdFolders, want)
+	}
+}
+
+func TestDriveItemsService_UploadNewFileToPath_createDottedParents(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	var createdFolders []string
+	mux.HandleFunc("/me/drive/root:/.config/v1./app.json:/content", func(w http.ResponseWriter, r *http.Request) {
+		if len(createdFolders) < 2 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "new-item", "name": "app.json"}`)
+	})
+	createFolder := func(w http.ResponseWriter, r *http.Request) {
+		var folder NewFolderCreationRequest
+		json.NewDecoder(r.Body).Decode(&folder)
+		createdFolders = append(createdFolders, folder.FolderName)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": "folder", "name": %q, "folder": {}}`, folder.FolderName)
+	}
+	mux.HandleFunc("/me/drive/root/children", createFolder)
+	mux.HandleFunc("/me/drive/root:/.config:/children", createFolder)
+
+	_, err := client.DriveItems.UploadNewFileToPath(context.Background(), ".config/v1./app.json", strings.NewReader("{}"), UploadNewFileToPathOpts{CreateParents: true})
+	if err != nil {
+		t.Errorf("DriveItems.UploadNewFileToPath returned error: %v", err)
+	}
+
+	if want := []string{".config", "v1."}; !reflect.DeepEqual(createdFolders, want) {
+		t.Errorf("DriveItems.UploadNewFileToPath created folders %v, want %v", createdFolders, want)
+	}
+}
+
+func TestDriveItemsService_CreateEmptyFile(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder-1:/.lock:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		if got, want := r.URL.Query().Get("@microsoft.graph.conflictBehavior"), "fail"; got != want {
+			t.Errorf("Request conflictBehavior: %q, want %q", got, want)
+		}
+
+		if r.ContentLength != 0 {
+			t.Errorf("Request content length: %d, want 0", r.ContentLength)
+		}
+
+		fmt.Fprint(w, `{"id": "lock-item", "name": ".lock", "size": 0, "file": {}}`)
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveItems.CreateEmptyFile(ctx, "", "folder-1", ".lock", "fail")
+	if err != nil {
+		t.Errorf("DriveItems.CreateEmptyFile returned error: %v", err)
+	}
+
+	if gotDriveItem == nil || gotDriveItem.Id != "lock-item" {
+		t.Errorf("DriveItems.CreateEmptyFile returned %+v, want %q", gotDriveItem, "lock-item")
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_insufficientQuota(t *testing.T) {
+	// A drive over its quota reports a negative remaining quota.
+	for _, quota := range []string{
+		`{"total": 1000, "used": 900, "remaining": 100}`,
+		`{"total": 1000, "used": 1050, "remaining": -50}`,
+	} {
+		client, mux, _, teardown := setup()
+
+		mux.HandleFunc("/me/drive", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+
+			fmt.Fprintf(w, `{"id": "drive-1", "driveType": "personal", "quota": %s}`, quota)
+		})
+		mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("An upload session was created despite the insufficient quota %s", quota)
+		})
+
+		ctx := context.Background()
+		file := LargeFile{Name: "big.bin", Size: 101, Data: strings.NewReader(strings.Repeat("x", 101))}
+		_, err := client.DriveItems.UploadLargeFile(ctx, "folder-1", file, UploadLargeFileOpts{CheckQuota: true})
+		if !errors.Is(err, ErrInsufficientQuota) {
+			t.Errorf("DriveItems.UploadLargeFile returned error %v with quota %s, want %v", err, quota, ErrInsufficientQuota)
+		}
+
+		teardown()
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_retryChunk(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	WithSleeper(clock)(client)
+
+	mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+	})
+
+	var ranges []string
+	failed := false
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			// The chunk which failed has been partly received.
+			fmt.Fprint(w, `{"nextExpectedRanges":["6-9"]}`)
+		case "PUT":
+			contentRange := r.Header.Get("Content-Range")
+			ranges = append(ranges, contentRange)
+			switch {
+			case contentRange == "bytes 4-7/10" && !failed:
+				failed = true
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprint(w, `{"error":{"code":"serviceNotAvailable","message":"Try again."}}`)
+			case contentRange == "bytes 0-3/10":
+				w.WriteHeader(http.StatusAccepted)
+				fmt.Fprint(w, `{"nextExpectedRanges":["4-9"]}`)
+			case contentRange == "bytes 6-9/10":
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id":"1","name":"big.bin","size":10}`)
+			default:
+				t.Errorf("Unexpected Content-Range %q", contentRange)
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		case "DELETE":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := context.Background()
+	file := LargeFile{Name: "big.bin", Size: 10, Data: strings.NewReader("0123456789")}
+	item, err := client.DriveItems.UploadLargeFile(ctx, "folder-1", file, UploadLargeFileOpts{ChunkSize: 4})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadLargeFile returned error: %v", err)
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.UploadLargeFile returned %+v", item)
+	}
+
+	want := []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 6-9/10"}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("The chunks uploaded are %v, want %v", ranges, want)
+	}
+	if len(clock.sleeps) != 1 {
+		t.Errorf("The failure has been backed off %d times, want 1", len(clock.sleeps))
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_manyChunks(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	const size = 500
+	mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+	})
+	received := 0
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		received += len(body)
+		if received == size {
+			fmt.Fprint(w, `{"id":"1","name":"big.bin"}`)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"nextExpectedRanges":["%d-"]}`, received)
+	})
+
+	file := LargeFile{Name: "big.bin", Size: size, Data: strings.NewReader(strings.Repeat("x", size))}
+	item, err := client.DriveItems.UploadLargeFile(context.Background(), "folder-1", file, UploadLargeFileOpts{ChunkSize: 1})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadLargeFile returned error: %v", err)
+	}
+	if item.Id != "1" || received != size {
+		t.Errorf("DriveItems.UploadLargeFile returned %+v after %d bytes", item, received)
+	}
+}
+
+func TestNextChunk(t *testing.T) {
+	tests := []struct {
+		expectedRange  string
+		offset, length uint64
+	}{
+		{"0-", 0, 4},
+		{"8-", 8, 2},
+		{"4-5", 4, 2},
+		{"4-99", 4, 4},
+	}
+	for _, tt := range tests {
+		offset, length, err := nextChunk(tt.expectedRange, 4, 10)
+		if err != nil || offset != tt.offset || length != tt.length {
+			t.Errorf("nextChunk(%q) = %d, %d, %v, want %d, %d", tt.expectedRange, offset, length, err, tt.offset, tt.length)
+		}
+	}
+
+	for _, expectedRange := range []string{"10-", "5-4", "x-"} {
+		if _, _, err := nextChunk(expectedRange, 4, 10); err == nil {
+			t.Errorf("nextChunk(%q) returned no error", expectedRange)
+		}
+	}
+}
+
+func TestEscapePathSegment(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.xlsx", "report.xlsx"},
+		{"C# notes.txt", "C%23%20notes.txt"},
+		{"100%.txt", "100%25.txt"},
+		{"a+b.txt", "a%2Bb.txt"},
+		{" padded ", "%20padded%20"},
+		{"?;,", "%3F%3B%2C"},
+	}
+
+	for _, test := range tests {
+		if got := escapePathSegment(test.name); got != test.want {
+			t.Errorf("escapePathSegment(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestDriveItemsService_pathAddressing_specialCharacters(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	var gotPath string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+
+		fmt.Fprint(w, `{"id": "1", "name": "Item"}`)
+	})
+
+	const name = " C# 100%+1 "
+	const escapedName = "%20C%23%20100%25%2B1%20"
+
+	ctx := context.Background()
+	tests := []struct {
+		method string
+		call   func() error
+		want   string
+	}{
+		{"GetByPath", func() error {
+			_, err := client.DriveItems.GetByPath(ctx, "Documents/"+name)
+			return err
+		}, "/me/drive/root:/Documents/" + escapedName + ":"},
+		{"CreateEmptyFile", func() error {
+			_, err := client.DriveItems.CreateEmptyFile(ctx, "", "folder-1", name, "")
+			return err
+		}, "/me/drive/items/folder-1:/" + escapedName + ":/content"},
+		{"UploadFileFromReader", func() error {
+			_, err := client.DriveItems.UploadFileFromReader(ctx, "folder-1", name, "text/plain", strings.NewReader("data"), UploadFileFromReaderOpts{DriveID: "drive-1"})
+			return err
+		}, "/me/drives/drive-1/items/folder-1:/" + escapedName + ":/content"},
+		{"UploadNewFileToPath", func() error {
+			_, err := client.DriveItems.UploadNewFileToPath(ctx, "Documents/"+name, strings.NewReader("data"), UploadNewFileToPathOpts{})
+			return err
+		}, "/me/drive/root:/Documents/" + escapedName + ":/content"},
+	}
+
+	for _, test := range tests {
+		gotPath = ""
+		if err := test.call(); err != nil {
+			t.Errorf("DriveItems.%s returned error: %v", test.method, err)
+		}
+
+		if gotPath != test.want {
+			t.Errorf("DriveItems.%s requested %q, want %q", test.method, gotPath, test.want)
+		}
+	}
+}
+
+func TestFileSystemInfo_MarshalJSON(t *testing.T) {
+	info := FileSystemInfo{LastModifiedDateTime: time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	got, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	if want := `{"lastModifiedDateTime":"2020-05-01T12:00:00Z"}`; string(got) != want {
+		t.Errorf("json.Marshal returned %s, want %s", got, want)
+	}
+}
@@ -6,7 +6,10 @@ package onedrive
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 )
 
 // DrivesService handles communication with the drives related methods of the OneDrive API.
@@ -18,8 +21,24 @@ type DrivesService service
 type OneDriveDrivesResponse struct {
 	ODataContext string   `json:"@odata.context"`
 	Drives       []*Drive `json:"value"`
+	// NextLink is the URL of the next page of drives, if any.
+	NextLink string `json:"@odata.nextLink"`
 }
 
+// HasMore reports whether the drives are followed by another page, see NextLink.
+func (response *OneDriveDrivesResponse) HasMore() bool {
+	return response.NextLink != ""
+}
+
+// The possible values for the type of a drive.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/drive?view=odsp-graph-online#properties
+const (
+	PersonalDriveType        = "personal"
+	BusinessDriveType        = "business"
+	DocumentLibraryDriveType = "documentLibrary"
+)
+
 // Drive represents a OneDrive drive.
 type Drive struct {
 	Id        string      `json:"id"`
@@ -28,6 +47,12 @@ type Drive struct {
 	Quota     *DriveQuota `json:"quota"`
 }
 
+// IsPersonal reports whether the drive is a OneDrive personal drive, which lacks some
+// features of OneDrive for Business and SharePoint document libraries.
+func (drive *Drive) IsPersonal() bool {
+	return drive.DriveType == PersonalDriveType
+}
+
 // DriveQuota represents the usage quota of a drive.
 type DriveQuota struct {
 	Used      int    `json:"used"`
@@ -40,11 +65,12 @@ type DriveQuota struct {
 // Get a specified drive of the authenticated user.
 //
 // If driveId is empty, it means the selected drive will be the default drive of
-// the authenticated user.
+// the authenticated user. Otherwise, any drive the authenticated user can access is
+// resolved directly by its ID. ErrDriveNotFound is returned if there is no such drive.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get?view=odsp-graph-online
 func (s *DrivesService) Get(ctx context.Context, driveId string) (*Drive, error) {
-	apiURL := "me/drives/" + url.PathEscape(driveId)
+	apiURL := "drives/" + url.PathEscape(driveId)
 	if driveId == "" {
 		apiURL = "me/drive"
 	}
@@ -56,13 +82,122 @@ func (s *DrivesService) Get(ctx context.Context, driveId string) (*Drive, error)
 
 	var defaultDrive *Drive
 	err = s.client.Do(ctx, req, false, &defaultDrive)
+	if err != nil {
+		if oneDriveError, ok := err.(*Error); ok && oneDriveError.Code == "itemNotFound" {
+			return nil, fmt.Errorf("%w: %v", ErrDriveNotFound, oneDriveError)
+		}
+		return nil, err
+	}
+
+	return defaultDrive, nil
+}
+
+// defaultDrive returns the default drive of the authenticated user. The drive is only
+// retrieved from OneDrive once per client.
+func (s *DrivesService) defaultDrive(ctx context.Context) (*Drive, error) {
+	s.client.defaultDriveMu.Lock()
+	defer s.client.defaultDriveMu.Unlock()
+
+	if s.client.defaultDrive != nil {
+		return s.client.defaultDrive, nil
+	}
+
+	defaultDrive, err := s.Get(ctx, "")
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.defaultDrive = defaultDrive
+
 	return defaultDrive, nil
 }
 
+// defaultDriveId returns the ID of the default drive of the authenticated user.
+func (s *DrivesService) defaultDriveId(ctx context.Context) (string, error) {
+	defaultDrive, err := s.defaultDrive(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return defaultDrive.Id, nil
+}
+
+// checkPersonalSupport turns the error of a request rejected by OneDrive as unsupported,
+// see isUnsupported, into ErrNotSupportedOnPersonal when the default drive of the
+// authenticated user is a personal one, because such requests are usually about features
+// only available on OneDrive for Business and SharePoint.
+func (s *DrivesService) checkPersonalSupport(ctx context.Context, err error) error {
+	if !isUnsupported(err) {
+		return err
+	}
+
+	defaultDrive, driveErr := s.defaultDrive(ctx)
+	if driveErr != nil || !defaultDrive.IsPersonal() {
+		return err
+	}
+
+	return &unsupportedError{platform: ErrNotSupportedOnPersonal, err: err}
+}
+
+// checkBusinessSupport turns the error of a request rejected by OneDrive as unsupported
+// into ErrNotSupportedOnBusiness when the default drive of the authenticated user is not a
+// personal one, for the requests about features only available on OneDrive personal.
+func (s *DrivesService) checkBusinessSupport(ctx context.Context, err error) error {
+	if !isUnsupported(err) {
+		return err
+	}
+
+	defaultDrive, driveErr := s.defaultDrive(ctx)
+	if driveErr != nil || defaultDrive.IsPersonal() {
+		return err
+	}
+
+	return &unsupportedError{platform: ErrNotSupportedOnBusiness, err: err}
+}
+
+// isUnsupported reports whether OneDrive has rejected a request because it does not
+// support it, rather than because the request is invalid: 501 Not Implemented, or a
+// 400 Bad Request with the notSupported code or a message saying so, e.g.
+// "Unsupported request.".
+func isUnsupported(err error) bool {
+	oneDriveError, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+
+	switch oneDriveError.StatusCode {
+	case http.StatusNotImplemented:
+		return true
+	case http.StatusBadRequest:
+		message := strings.ToLower(oneDriveError.Message)
+		return strings.EqualFold(oneDriveError.Code, "notSupported") ||
+			strings.Contains(message, "unsupported") || strings.Contains(message, "not supported")
+	}
+	return false
+}
+
+// unsupportedError is the error of a request rejected by OneDrive because the platform of
+// the drive lacks the feature. It is the ErrNotSupportedOnPersonal or
+// ErrNotSupportedOnBusiness platform error, and wraps the error of the API.
+type unsupportedError struct {
+	platform error
+	err      error
+}
+
+func (e *unsupportedError) Error() string {
+	return e.platform.Error() + ": " + e.err.Error()
+}
+
+// Is reports whether target is the platform error.
+func (e *unsupportedError) Is(target error) bool {
+	return target == e.platform
+}
+
+// Unwrap returns the error of the API.
+func (e *unsupportedError) Unwrap() error {
+	return e.err
+}
+
 // List all the drives of the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_list?view=odsp-graph-online
@@ -7,6 +7,7 @@ package onedrive
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -98,3 +99,44 @@ func TestDrivesService_List_authenticatedUser(t *testing.T) {
 	}
 
 }
+
+func TestDrivesService_Get_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/unknown", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "Item not found"}}`)
+	})
+
+	ctx := context.Background()
+	_, err := client.Drives.Get(ctx, "unknown")
+	if !errors.Is(err, ErrDriveNotFound) {
+		t.Errorf("Drives.Get returned error %v, want %v", err, ErrDriveNotFound)
+	}
+}
+
+func TestDrivesService_List_nextLink(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "1"}], "@odata.nextLink": "https://graph.microsoft.com/v1.0/me/drives?$skiptoken=abc"}`)
+	})
+
+	got, err := client.Drives.List(context.Background())
+	if err != nil {
+		t.Fatalf("Drives.List returned error: %v", err)
+	}
+
+	if want := "https://graph.microsoft.com/v1.0/me/drives?$skiptoken=abc"; got.NextLink != want {
+		t.Errorf("Drives.List returned the next link %q, want %q", got.NextLink, want)
+	}
+	if !got.HasMore() {
+		t.Error("HasMore returned false, want true")
+	}
+}
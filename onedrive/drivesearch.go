@@ -19,6 +19,13 @@ type DriveSearchService service
 type OneDriveDriveSearchResponse struct {
 	ODataContext string       `json:"@odata.context"`
 	DriveItems   []*DriveItem `json:"value"`
+	// NextLink is the URL of the next page of results, if any.
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// HasMore reports whether the results are followed by another page, see NextLink.
+func (response *OneDriveDriveSearchResponse) HasMore() bool {
+	return response.NextLink != ""
 }
 
 // Search the items in the default drive of the authenticated user.
@@ -0,0 +1,179 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// FindDuplicateOpts represents the options for looking up a duplicate of a local file by FindDuplicate.
+type FindDuplicateOpts struct {
+	DriveID string
+	// Recursive makes the lookup cover the whole subtree of the destination folder
+	// instead of only its direct children.
+	Recursive bool
+}
+
+// FindDuplicate looks for an item with the same size and QuickXorHash as the local file
+// in a folder of a drive of the authenticated user. Another hash is compared when the
+// client has been created with WithHasher. A nil item is returned when there is no duplicate.
+//
+// The local file is only hashed when an item of the same size is found.
+func (s *DriveItemsService) FindDuplicate(ctx context.Context, folderId string, localFilePath string, opts FindDuplicateOpts) (*DriveItem, error) {
+	duplicate, _, err := s.findDuplicate(ctx, folderId, localFilePath, opts)
+	return duplicate, err
+}
+
+// findDuplicate is FindDuplicate which also returns the ID of the folder, as it was
+// listed, in which the duplicate has been found.
+func (s *DriveItemsService) findDuplicate(ctx context.Context, folderId string, localFilePath string, opts FindDuplicateOpts) (*DriveItem, string, error) {
+	if folderId == "" {
+		return nil, "", errors.New("Please provide the ID of the folder to look into.")
+	}
+
+	if localFilePath == "" {
+		return nil, "", errors.New("Please provide the path to the file on local.")
+	}
+
+	fileInfo, err := os.Stat(localFilePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if fileInfo.IsDir() {
+		return nil, "", errors.New("Only file is allowed to be looked up here.")
+	}
+
+	hasher := s.client.fileHasher()
+	var localHash string
+	folderIds := []string{folderId}
+	for len(folderIds) > 0 {
+		currentFolderId := folderIds[0]
+		folderIds = folderIds[1:]
+
+		children, err := s.listChildren(ctx, opts.DriveID, currentFolderId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, child := range children {
+			if child.IsPackage() {
+				continue
+			}
+
+			if child.Folder != nil {
+				if opts.Recursive {
+					folderIds = append(folderIds, child.Id)
+				}
+				continue
+			}
+
+			if child.File == nil || child.File.Hashes == nil || child.Size != fileInfo.Size() {
+				continue
+			}
+
+			remoteHash := hasher.RemoteHash(child.File.Hashes)
+			if remoteHash == "" {
+				continue
+			}
+
+			if localHash == "" {
+				localHash, err = hasher.HashFile(localFilePath)
+				if err != nil {
+					return nil, "", err
+				}
+			}
+
+			if remoteHash == localHash {
+				return child, currentFolderId, nil
+			}
+		}
+	}
+
+	return nil, "", nil
+}
+
+// UploadNewFileDeduplicatedOpts represents the options for uploading a file by UploadNewFileDeduplicated.
+type UploadNewFileDeduplicatedOpts struct {
+	// Recursive makes the lookup for duplicates cover the whole subtree of the
+	// destination folder instead of only its direct children.
+	Recursive bool
+	// CopyDuplicate makes a server-side copy of a duplicate found deeper in the
+	// subtree into the destination folder, instead of skipping the upload entirely.
+	CopyDuplicate bool
+}
+
+// UploadNewFileDeduplicatedResult represents the outcome of UploadNewFileDeduplicated.
+// Exactly one of Uploaded or Duplicate is set.
+type UploadNewFileDeduplicatedResult struct {
+	// Uploaded is the new item when no duplicate was found.
+	Uploaded *DriveItem
+	// Duplicate is the existing item with the same content as the local file.
+	Duplicate *DriveItem
+	// Copy is the monitor of the server-side copy of Duplicate, if one was started.
+	Copy *CopyItemResponse
+}
+
+// UploadNewFileDeduplicated uploads a file to a drive of the authenticated user unless an
+// item with identical size and QuickXorHash already exists in the destination folder.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func (s *DriveItemsService) UploadNewFileDeduplicated(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string, opts UploadNewFileDeduplicatedOpts) (*UploadNewFileDeduplicatedResult, error) {
+	duplicate, foundInFolderId, err := s.findDuplicate(ctx, destinationParentFolderId, localFilePath, FindDuplicateOpts{DriveID: driveId, Recursive: opts.Recursive})
+	if err != nil {
+		return nil, err
+	}
+
+	if duplicate == nil {
+		uploaded, err := s.UploadNewFile(ctx, driveId, destinationParentFolderId, localFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return &UploadNewFileDeduplicatedResult{Uploaded: uploaded}, nil
+	}
+
+	result := &UploadNewFileDeduplicatedResult{Duplicate: duplicate}
+	if opts.CopyDuplicate && foundInFolderId != destinationParentFolderId {
+		result.Copy, err = s.Copy(ctx, driveId, duplicate.Id, driveId, destinationParentFolderId, filepath.Base(localFilePath))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// listChildren lists all the items of a folder in a drive of the authenticated user,
+// following the pages of the listing.
+func (s *DriveItemsService) listChildren(ctx context.Context, driveId string, folderId string) ([]*DriveItem, error) {
+	apiURL := "me/drive/items/" + url.PathEscape(folderId) + "/children"
+	if driveId != "" {
+		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(folderId) + "/children"
+	}
+
+	var children []*DriveItem
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var oneDriveResponse *OneDriveDriveItemsResponse
+		err = s.client.Do(ctx, req, false, &oneDriveResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		children = append(children, oneDriveResponse.DriveItems...)
+		apiURL = oneDriveResponse.NextLink
+	}
+
+	return children, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDriveItemsService_FindDuplicate_recursive(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	localFilePath := filepath.Join(dir, "hello.txt")
+	if err := ioutil.WriteFile(localFilePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Cannot create the local file: %v", err)
+	}
+
+	localHash, err := QuickXorHashFile(localFilePath)
+	if err != nil {
+		t.Fatalf("QuickXorHashFile returned error: %v", err)
+	}
+
+	mux.HandleFunc("/me/drive/items/root-id/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"value": [
+			{"id": "other", "name": "other.txt", "size": 5, "file": {"hashes": {"quickXorHash": "AAAAAAAAAAAAAAAAAAAAAAAAAAA="}}},
+			{"id": "sub", "name": "Sub", "folder": {"childCount": 1}}
+		]}`)
+	})
+	mux.HandleFunc("/me/drive/items/sub/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprintf(w, `{"value": [
+			{"id": "same", "name": "hello-copy.txt", "size": 5, "file": {"hashes": {"quickXorHash": %q}}}
+		]}`, localHash)
+	})
+
+	ctx := context.Background()
+	duplicate, err := client.DriveItems.FindDuplicate(ctx, "root-id", localFilePath, FindDuplicateOpts{})
+	if err != nil {
+		t.Errorf("DriveItems.FindDuplicate returned error: %v", err)
+	}
+	if duplicate != nil {
+		t.Errorf("DriveItems.FindDuplicate returned %+v, want no duplicate outside the subtree", duplicate)
+	}
+
+	duplicate, err = client.DriveItems.FindDuplicate(ctx, "root-id", localFilePath, FindDuplicateOpts{Recursive: true})
+	if err != nil {
+		t.Errorf("DriveItems.FindDuplicate returned error: %v", err)
+	}
+	if duplicate == nil || duplicate.Id != "same" {
+		t.Errorf("DriveItems.FindDuplicate returned %+v, want item %q", duplicate, "same")
+	}
+}
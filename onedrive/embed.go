@@ -0,0 +1,143 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ItemPreview represents the short-lived URLs at which an item can be previewed, e.g. in
+// an iframe.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_preview?view=odsp-graph-online
+type ItemPreview struct {
+	// GetURL is the URL of the preview, suitable for an iframe.
+	GetURL string `json:"getUrl"`
+	// PostURL is the URL of the preview when it must be requested with a POST, along with
+	// PostParameters, as a form.
+	PostURL        string `json:"postUrl"`
+	PostParameters string `json:"postParameters"`
+}
+
+// PreviewOpts represents the options for previewing an item by Preview.
+type PreviewOpts struct {
+	// Page is the page of the document to start at, e.g. "3".
+	Page string `json:"page,omitempty"`
+	// Zoom is the zoom level to start at, e.g. 1.5.
+	Zoom float64 `json:"zoom,omitempty"`
+}
+
+// Preview returns the short-lived URLs at which an item of a drive of the authenticated
+// user can be previewed without signing in.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_preview?view=odsp-graph-online
+func (s *DriveItemsService) Preview(ctx context.Context, driveId string, itemId string, opts PreviewOpts) (*ItemPreview, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item to preview.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/preview"
+	if driveId != "" {
+		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId) + "/preview"
+	}
+
+	req, err := s.client.NewRequest("POST", apiURL, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var preview *ItemPreview
+	if err := s.client.Do(ctx, req, false, &preview); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// ItemLink represents a URL at which an item can be opened, see ItemLinks.
+type ItemLink struct {
+	URL string
+	// RequiresSignIn reports whether the visitors must sign in with an account allowed to
+	// access the item.
+	RequiresSignIn bool
+	// ExpiresAfter is how long the URL remains valid, or 0 if it does not expire.
+	ExpiresAfter time.Duration
+}
+
+// IFrame returns an iframe element of the given size, in pixels, displaying the URL, ready
+// to be inserted into an HTML page.
+func (link *ItemLink) IFrame(width int, height int) string {
+	return fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" frameborder="0" scrolling="no" allowfullscreen></iframe>`, html.EscapeString(link.URL), width, height)
+}
+
+// ItemLinks represents the URLs at which an item can be opened or embedded, e.g. by a CMS.
+type ItemLinks struct {
+	// Web is the URL of the item in the OneDrive web client.
+	Web ItemLink
+	// Embed is the anonymous embed link of the item, or nil if the drive does not support
+	// embed links, like the ones of OneDrive for Business.
+	Embed *ItemLink
+	// Preview is the URL of the preview of the item, or nil if the item cannot be previewed.
+	Preview *ItemLink
+}
+
+// previewExpiry is how long the URL of a preview remains valid, according to the
+// documentation.
+const previewExpiry = 15 * time.Minute
+
+// Links returns the URLs at which an item of the default drive of the authenticated user
+// can be opened or embedded, along with whether the visitors must sign in. An anonymous
+// embed link is created for the item if there is none yet, which lets anyone having the
+// link view the item.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createlink?view=odsp-graph-online#embeddable-links
+func (s *DriveItemsService) Links(ctx context.Context, item *DriveItem) (*ItemLinks, error) {
+	if item == nil || item.Id == "" {
+		return nil, errors.New("Please provide the item to link to.")
+	}
+
+	if item.WebURL == "" {
+		var err error
+		item, err = s.Get(ctx, item.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	links := &ItemLinks{Web: ItemLink{URL: item.WebURL, RequiresSignIn: true}}
+
+	permission, err := s.client.DrivePermissions.CreateShareLink(ctx, item.Id, Embed, Anonymous)
+	switch {
+	case err == nil && permission != nil && permission.Link.URL != "":
+		links.Embed = &ItemLink{URL: permission.Link.URL}
+	case err != nil && !isUnsupportedLink(err):
+		return nil, err
+	}
+
+	preview, err := s.Preview(ctx, "", item.Id, PreviewOpts{})
+	switch {
+	case err == nil && preview != nil && preview.GetURL != "":
+		links.Preview = &ItemLink{URL: preview.GetURL, ExpiresAfter: previewExpiry}
+	case err != nil && !isUnsupportedLink(err):
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// isUnsupportedLink reports whether err tells that a kind of link is not supported for an
+// item or a drive.
+func isUnsupportedLink(err error) bool {
+	return isStatus(err, http.StatusBadRequest) || isStatus(err, http.StatusForbidden) || isStatus(err, http.StatusNotImplemented)
+}
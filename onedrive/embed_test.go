@@ -0,0 +1,80 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_Preview(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/d/items/1/preview", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var opts PreviewOpts
+		json.NewDecoder(r.Body).Decode(&opts)
+		if opts.Page != "2" {
+			t.Errorf("Request page = %q, want %q", opts.Page, "2")
+		}
+		fmt.Fprint(w, `{"getUrl":"https://preview.example/1?page=2"}`)
+	})
+
+	preview, err := client.DriveItems.Preview(context.Background(), "d", "1", PreviewOpts{Page: "2"})
+	if err != nil {
+		t.Fatalf("DriveItems.Preview returned error: %v", err)
+	}
+	if want := "https://preview.example/1?page=2"; preview.GetURL != want {
+		t.Errorf("DriveItems.Preview returned %q, want %q", preview.GetURL, want)
+	}
+}
+
+func TestDriveItemsService_Links(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1","webUrl":"https://onedrive.example/1"}`)
+	})
+	mux.HandleFunc("/me/drive/items/1/createLink", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var request CreateShareLinkRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Type != "embed" || request.Scope != "anonymous" {
+			t.Errorf("Request link = %+v, want an anonymous embed link", request)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"code":"invalidRequest","message":"Embed links are not supported."}}`)
+	})
+	mux.HandleFunc("/me/drive/items/1/preview", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"getUrl":"https://preview.example/1?a=1&b=2"}`)
+	})
+
+	links, err := client.DriveItems.Links(context.Background(), &DriveItem{Id: "1"})
+	if err != nil {
+		t.Fatalf("DriveItems.Links returned error: %v", err)
+	}
+
+	if links.Web.URL != "https://onedrive.example/1" || !links.Web.RequiresSignIn {
+		t.Errorf("DriveItems.Links returned web link %+v", links.Web)
+	}
+	if links.Embed != nil {
+		t.Errorf("DriveItems.Links returned embed link %+v, want none", links.Embed)
+	}
+	if links.Preview == nil || links.Preview.RequiresSignIn || links.Preview.ExpiresAfter == 0 {
+		t.Fatalf("DriveItems.Links returned preview link %+v", links.Preview)
+	}
+
+	want := `<iframe src="https://preview.example/1?a=1&amp;b=2" width="640" height="480" frameborder="0" scrolling="no" allowfullscreen></iframe>`
+	if got := links.Preview.IFrame(640, 480); got != want {
+		t.Errorf("ItemLink.IFrame returned %s, want %s", got, want)
+	}
+}
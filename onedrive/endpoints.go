@@ -0,0 +1,154 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Endpoints represents the hosts a client sends its requests to instead of the ones of
+// Microsoft Graph, e.g. an API gateway, an emulator of the API or an egress appliance. It is
+// created by ParseEndpoints, which validates the URLs.
+type Endpoints struct {
+	api      *url.URL
+	download *url.URL
+	upload   *url.URL
+}
+
+// ParseEndpoints parses the root URLs of the requests to the API, to the pre-authenticated
+// download URLs and to the upload sessions. The download and upload URLs may be empty, in
+// which case the URLs returned by the API are used as they are.
+//
+// The URLs must be absolute http or https URLs, without user information, query nor
+// fragment. A trailing slash is added to the API root if it has none.
+func ParseEndpoints(apiRoot string, downloadRoot string, uploadRoot string) (*Endpoints, error) {
+	api, err := parseEndpoint("API", apiRoot)
+	if err != nil {
+		return nil, err
+	}
+	if api == nil {
+		return nil, fmt.Errorf("Please provide the root URL of the API.")
+	}
+	if !strings.HasSuffix(api.Path, "/") {
+		api.Path += "/"
+	}
+
+	download, err := parseEndpoint("download", downloadRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := parseEndpoint("upload", uploadRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Endpoints{api: api, download: download, upload: upload}, nil
+}
+
+func parseEndpoint(name string, rawURL string) (*url.URL, error) {
+	if rawURL == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("The %s root URL is not valid: %v", name, err)
+	}
+
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, fmt.Errorf("The %s root URL %q must use http or https.", name, rawURL)
+	case u.Host == "":
+		return nil, fmt.Errorf("The %s root URL %q has no host.", name, rawURL)
+	case u.User != nil || u.RawQuery != "" || u.Fragment != "":
+		return nil, fmt.Errorf("The %s root URL %q must not have user information, query nor fragment.", name, rawURL)
+	}
+
+	return u, nil
+}
+
+// WithEndpoints makes the client send its requests to the given endpoints instead of the
+// ones of Microsoft Graph. The requests to the API are resolved relative to the API root,
+// which becomes the BaseURL of the client. The pre-authenticated download URLs and the
+// upload session URLs returned by the API are sent to the download and upload roots, if
+// any: their scheme and host are replaced, and their path is appended to the path of the
+// root. The redirects are followed as they are.
+//
+// The absolute URLs of Microsoft Graph returned by the API, such as the next links of the
+// listings and the delta links, are resolved relative to the API root too; NewRequest
+// refuses those outside of the version of the API of the client. The URLs of other hosts,
+// e.g. of SharePoint sites, are left as they are.
+func WithEndpoints(endpoints *Endpoints) ClientOption {
+	return func(c *Client) {
+		if endpoints == nil {
+			return
+		}
+
+		api := *endpoints.api
+		c.BaseURL = &api
+		c.endpoints = endpoints
+	}
+}
+
+// apiRequestURL returns the URL an absolute URL of Microsoft Graph returned by the API is to
+// be requested at, see WithEndpoints. The other URLs are left as they are.
+func (c *Client) apiRequestURL(u *url.URL) (*url.URL, error) {
+	graph, _ := url.Parse(defaultBaseURL)
+	if c.endpoints == nil || !strings.EqualFold(u.Host, graph.Host) {
+		return u, nil
+	}
+
+	if !strings.HasPrefix(u.Path, graph.Path) {
+		return nil, fmt.Errorf("The URL %q is not under the root %q of the API.", sanitizeURL(u), graph)
+	}
+
+	rerooted := *u
+	rerooted.Scheme, rerooted.Host = c.BaseURL.Scheme, c.BaseURL.Host
+	rerooted.Path = c.BaseURL.Path + strings.TrimPrefix(u.Path, graph.Path)
+	rerooted.RawPath = ""
+	if u.RawPath != "" {
+		rerooted.RawPath = c.BaseURL.EscapedPath() + strings.TrimPrefix(u.RawPath, graph.EscapedPath())
+	}
+	return &rerooted, nil
+}
+
+// downloadURL returns the URL a pre-authenticated download URL is to be requested at, see
+// WithEndpoints. The URLs of the API are left as they are.
+func (c *Client) downloadURL(rawURL string) string {
+	if c.endpoints == nil || strings.HasPrefix(rawURL, c.BaseURL.String()) {
+		return rawURL
+	}
+	return rerootURL(rawURL, c.endpoints.download)
+}
+
+// uploadURL returns the URL an upload session URL is to be requested at, see WithEndpoints.
+func (c *Client) uploadURL(rawURL string) string {
+	if c.endpoints == nil {
+		return rawURL
+	}
+	return rerootURL(rawURL, c.endpoints.upload)
+}
+
+// rerootURL moves a URL under root, if any.
+func rerootURL(rawURL string, root *url.URL) string {
+	if root == nil {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	u.Scheme, u.Host = root.Scheme, root.Host
+	u.Path = strings.TrimSuffix(root.Path, "/") + u.Path
+	if u.RawPath != "" {
+		u.RawPath = strings.TrimSuffix(root.EscapedPath(), "/") + u.RawPath
+	}
+	return u.String()
+}
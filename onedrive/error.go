@@ -4,6 +4,60 @@
 
 package onedrive
 
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrPackageItem is returned when the content of a package item, such as a OneNote
+// notebook, is requested. Packages have no downloadable content of their own.
+var ErrPackageItem = errors.New("package items cannot be downloaded")
+
+// ErrDriveNotFound is returned when the requested drive does not exist or cannot be
+// accessed by the authenticated user.
+var ErrDriveNotFound = errors.New("drive not found")
+
+// ErrNotSupportedOnPersonal is returned when a request is rejected because the feature
+// is not available on OneDrive personal drives. The *Error of the API is wrapped along,
+// see errors.As.
+var ErrNotSupportedOnPersonal = errors.New("not supported on OneDrive personal")
+
+// ErrNotSupportedOnBusiness is returned when a request is rejected because the feature
+// is only available on OneDrive personal drives. The *Error of the API is wrapped along,
+// see errors.As.
+var ErrNotSupportedOnBusiness = errors.New("not supported on OneDrive for Business")
+
+// ErrReadOnlyClient is returned by every request which would modify a drive when the
+// client has been created with WithReadOnly.
+var ErrReadOnlyClient = errors.New("client is read-only")
+
+// ErrInsufficientQuota is returned when the remaining quota of a drive is too small for
+// the file to be uploaded.
+var ErrInsufficientQuota = errors.New("insufficient quota")
+
+// ErrUploadCanceled is returned by LargeUpload.Wait when the upload has been canceled.
+var ErrUploadCanceled = errors.New("upload canceled")
+
+// ErrUploadSuspended is returned by LargeUpload.Wait when the upload has been shut down
+// before it was over. The upload session is kept, so the upload can be resumed with
+// ResumeUpload.
+var ErrUploadSuspended = errors.New("upload suspended")
+
+// ErrUploadCorrupted is returned when the hash OneDrive reports for an uploaded item
+// differs from the hash of the uploaded data. The corrupted item is left on OneDrive, to be
+// uploaded again or deleted, and returned along with the error.
+var ErrUploadCorrupted = errors.New("upload corrupted")
+
+// ErrUploadUnverified is returned when OneDrive does not report the hash of an uploaded item
+// in time to verify it, see UploadLargeFileOpts.AwaitHash. The item is left on OneDrive, as
+// it may well be intact, and returned along with the error.
+var ErrUploadUnverified = errors.New("upload unverified")
+
+// ErrSkipFolder is returned by the function called by Walk for a folder, to skip the
+// children of the folder. It is not returned by Walk.
+var ErrSkipFolder = errors.New("skip this folder")
+
 // ErrorResponse represents the error response returned by OneDrive drive API.
 type ErrorResponse struct {
 	Error *Error `json:"error"`
@@ -11,6 +65,7 @@ type ErrorResponse struct {
 
 // Error represents the error in the response returned by OneDrive drive API.
 type Error struct {
+	StatusCode       int         `json:"-"` // HTTP status code of the response carrying the error.
 	Code             string      `json:"code"`
 	Message          string      `json:"message"`
 	LocalizedMessage string      `json:"localizedMessage"`
@@ -18,15 +73,49 @@ type Error struct {
 }
 
 func (e *Error) Error() string {
-	if e.InnerError != nil {
-		return e.Code + " - " + e.Message + " (" + e.InnerError.Date + ")"
+	if e.InnerError != nil && !e.InnerError.Date.IsZero() {
+		return e.Code + " - " + e.Message + " (" + e.InnerError.Date.Format(time.RFC3339) + ")"
 	}
 	return e.Code + " - " + e.Message
 }
 
 // InnerError represents the error details in the error returned by OneDrive drive API.
 type InnerError struct {
-	Date            string `json:"date"`
-	RequestId       string `json:"request-id"`
-	ClientRequestId string `json:"client-request-id"`
+	Date            time.Time `json:"date"`
+	RequestId       string    `json:"request-id"`
+	ClientRequestId string    `json:"client-request-id"`
+}
+
+// innerErrorDateLayout is the layout of the dates of the errors, which Microsoft Graph
+// returns in UTC without time zone, e.g. "2020-06-22T08:43:51".
+const innerErrorDateLayout = "2006-01-02T15:04:05"
+
+// UnmarshalJSON decodes the details of an error, whose date may lack its time zone.
+func (e *InnerError) UnmarshalJSON(data []byte) error {
+	type innerError InnerError
+	var details struct {
+		*innerError
+		Date string `json:"date"`
+	}
+	details.innerError = (*innerError)(e)
+
+	if err := json.Unmarshal(data, &details); err != nil {
+		return err
+	}
+
+	e.Date = time.Time{}
+	if details.Date == "" {
+		return nil
+	}
+
+	date, err := time.Parse(time.RFC3339, details.Date)
+	if err != nil {
+		date, err = time.Parse(innerErrorDateLayout, details.Date)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.Date = date
+	return nil
 }
@@ -0,0 +1,51 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestError_innerErrorDate(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "Item not found",
+			"innerError": {"date": "2020-06-22T08:43:51", "request-id": "req-1", "client-request-id": "client-1"}}}`)
+	})
+
+	_, err := client.DriveItems.Get(context.Background(), "missing")
+	oneDriveError, ok := err.(*Error)
+	if !ok || oneDriveError.InnerError == nil {
+		t.Fatalf("DriveItems.Get returned error %v, want an *Error with details", err)
+	}
+
+	innerError := oneDriveError.InnerError
+	if want := time.Date(2020, 6, 22, 8, 43, 51, 0, time.UTC); !innerError.Date.Equal(want) {
+		t.Errorf("InnerError.Date is %v, want %v", innerError.Date, want)
+	}
+	if innerError.RequestId != "req-1" || innerError.ClientRequestId != "client-1" {
+		t.Errorf("InnerError is %+v, want its request IDs", innerError)
+	}
+
+	if got, want := err.Error(), "itemNotFound - Item not found (2020-06-22T08:43:51Z)"; got != want {
+		t.Errorf("Error returned %q, want %q", got, want)
+	}
+}
+
+func TestError_noDate(t *testing.T) {
+	err := &Error{Code: "generalException", Message: "General exception", InnerError: &InnerError{RequestId: "req-1"}}
+
+	if got, want := err.Error(), "generalException - General exception"; got != want {
+		t.Errorf("Error returned %q, want %q", got, want)
+	}
+}
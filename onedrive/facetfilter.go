@@ -0,0 +1,91 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ListFolders lists the folders of a folder in the default drive of the authenticated user,
+// going through all the pages of the listing. The other items are filtered out on the
+// server side with $filter where it is supported, so listing the subfolders of a folder of
+// thousands of files stays cheap; they are filtered out on the client side otherwise,
+// including when the drive rejects the filter.
+//
+// If folderId is empty, it means the folders at the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ListFolders(ctx context.Context, folderId string) (*OneDriveDriveItemsResponse, error) {
+	return s.listChildrenWithFacet(ctx, folderId, "folder ne null", func(item *DriveItem) bool {
+		return item.Folder != nil
+	})
+}
+
+// ListFiles lists the files of a folder in the default drive of the authenticated user, like
+// ListFolders lists its folders. The packages, such as OneNote notebooks, are not files.
+//
+// If folderId is empty, it means the files at the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ListFiles(ctx context.Context, folderId string) (*OneDriveDriveItemsResponse, error) {
+	return s.listChildrenWithFacet(ctx, folderId, "file ne null", func(item *DriveItem) bool {
+		return item.File != nil
+	})
+}
+
+// listChildrenWithFacet lists the items of a folder matching filter, which keep must agree
+// with for the drives ignoring or rejecting the filter.
+func (s *DriveItemsService) listChildrenWithFacet(ctx context.Context, folderId string, filter string, keep func(*DriveItem) bool) (*OneDriveDriveItemsResponse, error) {
+	apiURL := "me/drive/items/" + url.PathEscape(folderId) + "/children"
+	if folderId == "" {
+		apiURL = "me/drive/root/children"
+	}
+
+	response, err := s.listKeptChildren(ctx, apiURL+"?$filter="+url.QueryEscape(filter), keep)
+	if isStatus(err, http.StatusBadRequest) || isStatus(err, http.StatusNotImplemented) {
+		response, err = s.listKeptChildren(ctx, apiURL, keep)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// listKeptChildren goes through the pages of a listing starting at apiURL, and keeps the
+// items for which keep returns true.
+func (s *DriveItemsService) listKeptChildren(ctx context.Context, apiURL string, keep func(*DriveItem) bool) (*OneDriveDriveItemsResponse, error) {
+	response := &OneDriveDriveItemsResponse{}
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page *OneDriveDriveItemsResponse
+		if err := s.client.Do(ctx, req, false, &page); err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+
+		if response.ODataContext == "" {
+			response.ODataContext = page.ODataContext
+		}
+		for _, item := range page.DriveItems {
+			if keep(item) {
+				response.DriveItems = append(response.DriveItems, item)
+			}
+		}
+
+		apiURL = page.NextLink
+	}
+
+	response.Count = len(response.DriveItems)
+	return response, nil
+}
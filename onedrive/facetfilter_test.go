@@ -0,0 +1,86 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_ListFolders(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("$filter"), "folder ne null"; got != want {
+			t.Errorf("$filter = %q, want %q", got, want)
+		}
+
+		if r.URL.Query().Get("$skiptoken") == "" {
+			fmt.Fprintf(w, `{"value":[{"id":"a","folder":{}}],"@odata.nextLink":"%s%s/me/drive/items/1/children?$filter=folder+ne+null&$skiptoken=2"}`, serverURL, baseURLPath)
+			return
+		}
+		fmt.Fprint(w, `{"value":[{"id":"b","folder":{}}]}`)
+	})
+
+	folders, err := client.DriveItems.ListFolders(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("DriveItems.ListFolders returned error: %v", err)
+	}
+
+	if folders.Count != 2 || folders.DriveItems[0].Id != "a" || folders.DriveItems[1].Id != "b" {
+		t.Errorf("DriveItems.ListFolders returned %+v, want a and b", folders.DriveItems)
+	}
+}
+
+func TestDriveItemsService_ListFiles_filterIgnored(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("$filter"), "file ne null"; got != want {
+			t.Errorf("$filter = %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"value":[{"id":"a","folder":{}},{"id":"b","file":{}},{"id":"c","package":{"type":"oneNote"}}]}`)
+	})
+
+	files, err := client.DriveItems.ListFiles(context.Background(), "")
+	if err != nil {
+		t.Fatalf("DriveItems.ListFiles returned error: %v", err)
+	}
+
+	if files.Count != 1 || files.DriveItems[0].Id != "b" {
+		t.Errorf("DriveItems.ListFiles returned %+v, want b", files.DriveItems)
+	}
+}
+
+func TestDriveItemsService_ListFolders_filterRejected(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/f/children", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$filter") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":"invalidRequest","message":"Invalid filter clause"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"value":[{"id":"a","folder":{}},{"id":"b","file":{}}]}`)
+	})
+
+	folders, err := client.DriveItems.ListFolders(context.Background(), "f")
+	if err != nil {
+		t.Fatalf("DriveItems.ListFolders returned error: %v", err)
+	}
+
+	if folders.Count != 1 || folders.DriveItems[0].Id != "a" {
+		t.Errorf("DriveItems.ListFolders returned %+v, want a", folders.DriveItems)
+	}
+}
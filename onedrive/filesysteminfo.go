@@ -0,0 +1,69 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// fileSystemInfoItem represents the properties of an item which carry its file system times,
+// when updating the item.
+type fileSystemInfoItem struct {
+	FileSystemInfo *FileSystemInfo `json:"fileSystemInfo"`
+}
+
+// SetFileSystemInfo sets the times of an item in a drive of the authenticated user as
+// reported by the file system of the client, e.g. to keep the original modification time of a
+// file which has been uploaded rather than the time of the upload. The zero times are left
+// unchanged.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_update?view=odsp-graph-online
+func (s *DriveItemsService) SetFileSystemInfo(ctx context.Context, driveId string, itemId string, info FileSystemInfo) (*DriveItem, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item to be updated.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(itemId)
+	if driveId != "" {
+		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId)
+	}
+
+	req, err := s.client.NewRequest("PATCH", apiURL, &fileSystemInfoItem{FileSystemInfo: &info})
+	if err != nil {
+		return nil, err
+	}
+
+	var response *DriveItem
+	err = s.client.doItem(ctx, itemId, req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// setUploadedFileSystemInfo sets info, if any, on an item which has just been uploaded with
+// a simple upload, which cannot carry it, and returns the updated item.
+func (s *DriveItemsService) setUploadedFileSystemInfo(ctx context.Context, driveId string, item *DriveItem, info *FileSystemInfo) (*DriveItem, error) {
+	if info == nil || item == nil || item.Id == "" {
+		return item, nil
+	}
+
+	updated, err := s.SetFileSystemInfo(ctx, driveId, item.Id, *info)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		// Nothing to decode, e.g. in dry-run mode.
+		return item, nil
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_SetFileSystemInfo(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/d/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		want := `{"fileSystemInfo":{"createdDateTime":"2019-01-02T03:04:05Z"}}`
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != want {
+			t.Errorf("The body is %s, want %s", body, want)
+		}
+		fmt.Fprint(w, `{"id":"1","fileSystemInfo":{"createdDateTime":"2019-01-02T03:04:05Z"}}`)
+	})
+
+	info := FileSystemInfo{CreatedDateTime: time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)}
+	item, err := client.DriveItems.SetFileSystemInfo(context.Background(), "d", "1", info)
+	if err != nil {
+		t.Fatalf("DriveItems.SetFileSystemInfo returned error: %v", err)
+	}
+	if item.FileSystemInfo == nil || !item.FileSystemInfo.CreatedDateTime.Equal(info.CreatedDateTime) {
+		t.Errorf("DriveItems.SetFileSystemInfo returned %+v", item)
+	}
+
+	if _, err := client.DriveItems.SetFileSystemInfo(context.Background(), "d", "", info); err == nil {
+		t.Error("DriveItems.SetFileSystemInfo returned no error for an empty item ID")
+	}
+}
+
+func TestDriveItemsService_UploadNewFileWithOpts_preserveModTime(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "photo.jpg")
+	if err := ioutil.WriteFile(localPath, []byte("jpeg"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2018, 7, 1, 9, 30, 0, 0, time.UTC)
+	if err := os.Chtimes(localPath, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/me/drive/items/folder-1:/photo.jpg:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id":"1","name":"photo.jpg"}`)
+	})
+	patched := false
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		want := `{"fileSystemInfo":{"createdDateTime":"2018-06-30T00:00:00Z","lastModifiedDateTime":"2018-07-01T09:30:00Z"}}`
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != want {
+			t.Errorf("The body is %s, want %s", body, want)
+		}
+		patched = true
+		fmt.Fprint(w, `{"id":"1","name":"photo.jpg","fileSystemInfo":{"lastModifiedDateTime":"2018-07-01T09:30:00Z"}}`)
+	})
+
+	item, err := client.DriveItems.UploadNewFileWithOpts(context.Background(), "folder-1", localPath, UploadNewFileOpts{
+		FileSystemInfo:  &FileSystemInfo{CreatedDateTime: time.Date(2018, 6, 30, 0, 0, 0, 0, time.UTC)},
+		PreserveModTime: true,
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadNewFileWithOpts returned error: %v", err)
+	}
+	if !patched {
+		t.Error("The times of the file have not been set")
+	}
+	if item.FileSystemInfo == nil || !item.FileSystemInfo.LastModifiedDateTime.Equal(modTime) {
+		t.Errorf("DriveItems.UploadNewFileWithOpts returned %+v", item)
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_fileSystemInfo(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		want := `{"item":{"name":"big.bin","fileSystemInfo":{"lastModifiedDateTime":"2018-07-01T09:30:00Z"}}}`
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != want {
+			t.Errorf("The body is %s, want %s", body, want)
+		}
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"big.bin"}`)
+	})
+
+	file := LargeFile{Name: "big.bin", Size: 10, Data: strings.NewReader(strings.Repeat("x", 10))}
+	item, err := client.DriveItems.UploadLargeFile(context.Background(), "folder-1", file, UploadLargeFileOpts{
+		FileSystemInfo: &FileSystemInfo{LastModifiedDateTime: time.Date(2018, 7, 1, 9, 30, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadLargeFile returned error: %v", err)
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.UploadLargeFile returned %+v", item)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// DriveItemFolderView represents how the items of a folder are presented by the OneDrive
+// apps. Empty properties are left unchanged by SetFolderView.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/folderview?view=odsp-graph-online
+type DriveItemFolderView struct {
+	// SortBy is "default", "name", "type", "size", "takenOrCreatedDateTime",
+	// "lastModifiedDateTime" or "sequence".
+	SortBy string `json:"sortBy,omitempty"`
+	// SortOrder is "ascending" or "descending".
+	SortOrder string `json:"sortOrder,omitempty"`
+	// ViewType is "default", "icons", "details" or "thumbnails".
+	ViewType string `json:"viewType,omitempty"`
+}
+
+// setFolderViewRequest represents the JSON object sent to update the view of a folder.
+type setFolderViewRequest struct {
+	Folder struct {
+		View *DriveItemFolderView `json:"view"`
+	} `json:"folder"`
+}
+
+// SetFolderView updates how the items of a folder in a drive of the authenticated user are
+// presented by the OneDrive apps, e.g. to present all the shared folders of a team the
+// same way. The updated folder is returned.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_update?view=odsp-graph-online
+func (s *DriveItemsService) SetFolderView(ctx context.Context, driveId string, folderId string, view *DriveItemFolderView) (*DriveItem, error) {
+	if folderId == "" {
+		return nil, errors.New("Please provide the ID of the folder.")
+	}
+
+	if view == nil || *view == (DriveItemFolderView{}) {
+		return nil, errors.New("Please provide the view of the folder.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(folderId)
+	if driveId != "" {
+		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(folderId)
+	}
+
+	request := &setFolderViewRequest{}
+	request.Folder.View = view
+
+	req, err := s.client.NewRequest("PATCH", apiURL, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *DriveItem
+	err = s.client.doItem(ctx, folderId, req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
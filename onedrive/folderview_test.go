@@ -0,0 +1,53 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDriveItemsService_SetFolderView(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/drive-1/items/folder-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"folder":{"view":{"sortBy":"name","viewType":"thumbnails"}}}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("Request body = %s, want %s", got, want)
+		}
+
+		fmt.Fprint(w, `{"id": "folder-1", "name": "Photos", "folder": {"childCount": 3, "view": {"sortBy": "name", "sortOrder": "ascending", "viewType": "thumbnails"}}}`)
+	})
+
+	got, err := client.DriveItems.SetFolderView(context.Background(), "drive-1", "folder-1", &DriveItemFolderView{SortBy: "name", ViewType: "thumbnails"})
+	if err != nil {
+		t.Fatalf("DriveItems.SetFolderView returned error: %v", err)
+	}
+
+	want := &DriveItemFolderView{SortBy: "name", SortOrder: "ascending", ViewType: "thumbnails"}
+	if got.Folder == nil || !reflect.DeepEqual(got.Folder.View, want) {
+		t.Errorf("DriveItems.SetFolderView returned %+v, want view %+v", got.Folder, want)
+	}
+}
+
+func TestDriveItemsService_SetFolderView_emptyView(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	if _, err := client.DriveItems.SetFolderView(context.Background(), "", "folder-1", &DriveItemFolderView{}); err == nil {
+		t.Errorf("DriveItems.SetFolderView did not return an error for an empty view")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// fullPathSelect is the $select query of the items retrieved by FullPath.
+const fullPathSelect = "id,name,parentReference"
+
+// FullPath returns the path of an item in the default drive of the authenticated user from
+// the root of the drive, e.g. "Documents/Reports/summary.pdf", so the items returned by
+// Delta or by a search can be shown to people. The path of the root folder is empty.
+//
+// The path is derived from the parentReference of the item. OneDrive does not report it
+// in every case, e.g. for the items returned by Delta, so the parent folders are retrieved
+// one by one up to the first one whose path is known.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/itemreference?view=odsp-graph-online
+func (s *DriveItemsService) FullPath(ctx context.Context, itemId string) (string, error) {
+	if itemId == "" {
+		return "", errors.New("Please provide the Item ID of the item.")
+	}
+
+	var names []string
+	parentPath := ""
+	for {
+		item, err := s.getPathItem(ctx, itemId)
+		if err != nil {
+			return "", err
+		}
+
+		// The root folder is the only item without parent.
+		if item.ParentReference == nil || item.ParentReference.Id == "" {
+			break
+		}
+
+		names = append(names, item.Name)
+
+		if p, ok := parentReferencePath(item.ParentReference); ok {
+			parentPath = p
+			break
+		}
+
+		itemId = item.ParentReference.Id
+	}
+
+	// The names have been collected from the item up to the root.
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+
+	return strings.Trim(path.Join(parentPath, strings.Join(names, "/")), "/"), nil
+}
+
+// getPathItem retrieves the name and the parentReference of an item in the default drive
+// of the authenticated user.
+func (s *DriveItemsService) getPathItem(ctx context.Context, itemId string) (*DriveItem, error) {
+	req, err := s.client.NewRequest("GET", "me/drive/items/"+url.PathEscape(itemId)+"?$select="+fullPathSelect, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *DriveItem
+	if err := s.client.doItem(ctx, itemId, req, &item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// parentReferencePath returns the path of a parent folder from the root of its drive, given
+// the path of its parentReference, e.g. "/drive/root:/Documents/Reports". The path is
+// percent-encoded by OneDrive.
+func parentReferencePath(parentReference *ParentReference) (string, bool) {
+	i := strings.Index(parentReference.Path, "root:")
+	if i < 0 {
+		return "", false
+	}
+
+	parentPath := strings.Trim(parentReference.Path[i+len("root:"):], "/")
+	if unescaped, err := url.PathUnescape(parentPath); err == nil {
+		parentPath = unescaped
+	}
+
+	return parentPath, true
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_FullPath(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/report", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.Query().Get("$select"), fullPathSelect; got != want {
+			t.Errorf("Request query $select is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"id": "report", "name": "summary.pdf", "parentReference": {"id": "reports", "path": "/drive/root:/Documents/Annual%20Reports"}}`)
+	})
+
+	got, err := client.DriveItems.FullPath(context.Background(), "report")
+	if err != nil {
+		t.Fatalf("DriveItems.FullPath returned error: %v", err)
+	}
+
+	if want := "Documents/Annual Reports/summary.pdf"; got != want {
+		t.Errorf("DriveItems.FullPath returned %q, want %q", got, want)
+	}
+}
+
+func TestDriveItemsService_FullPath_ancestors(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/report", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "report", "name": "summary.pdf", "parentReference": {"id": "reports"}}`)
+	})
+	mux.HandleFunc("/me/drive/items/reports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "reports", "name": "Reports", "parentReference": {"id": "documents"}}`)
+	})
+	mux.HandleFunc("/me/drive/items/documents", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "documents", "name": "Documents", "parentReference": {"id": "root"}}`)
+	})
+	mux.HandleFunc("/me/drive/items/root", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "root", "name": "root", "parentReference": {"driveId": "drive-1"}}`)
+	})
+
+	tests := []struct {
+		itemId string
+		want   string
+	}{
+		{"report", "Documents/Reports/summary.pdf"},
+		{"documents", "Documents"},
+		{"root", ""},
+	}
+
+	for _, tt := range tests {
+		got, err := client.DriveItems.FullPath(context.Background(), tt.itemId)
+		if err != nil {
+			t.Fatalf("DriveItems.FullPath(%q) returned error: %v", tt.itemId, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("DriveItems.FullPath(%q) returned %q, want %q", tt.itemId, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+// Hasher computes the hashes of local files which are compared with the hashes OneDrive
+// reports for drive items, e.g. by FindDuplicate and UploadIfChanged. Applications can
+// provide their own, e.g. to offload the hashing to a hardware accelerator, see WithHasher.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/hashes?view=odsp-graph-online
+type Hasher interface {
+	// HashFile computes the hash of a local file, encoded the same way as RemoteHash.
+	HashFile(localFilePath string) (string, error)
+	// RemoteHash returns the hash OneDrive reports for a file, or an empty string when it
+	// does not report this kind of hash.
+	RemoteHash(hashes *DriveItemHashes) string
+}
+
+var (
+	// QuickXorHasher compares QuickXorHash, which OneDrive reports on every kind of drive.
+	// It is the default Hasher.
+	QuickXorHasher Hasher = quickXorHasher{}
+	// SHA1Hasher compares SHA1Hash, which only OneDrive personal reports.
+	SHA1Hasher Hasher = hexHasher{newHash: sha1.New, remoteHash: func(hashes *DriveItemHashes) string { return hashes.SHA1Hash }}
+	// SHA256Hasher compares SHA256Hash, which only some drives report.
+	SHA256Hasher Hasher = hexHasher{newHash: sha256.New, remoteHash: func(hashes *DriveItemHashes) string { return hashes.SHA256Hash }}
+)
+
+// WithHasher makes the client compare local files with drive items by the given Hasher
+// instead of QuickXorHasher. When OneDrive does not report the kind of hash for an item of
+// the same size, the local file is considered changed only if it was modified after the item,
+// and it never matches the item when looking for duplicates.
+func WithHasher(hasher Hasher) ClientOption {
+	return func(c *Client) {
+		c.hasher = hasher
+	}
+}
+
+// fileHasher returns the Hasher of the client, see WithHasher.
+func (c *Client) fileHasher() Hasher {
+	if c.hasher == nil {
+		return QuickXorHasher
+	}
+	return c.hasher
+}
+
+type quickXorHasher struct{}
+
+func (quickXorHasher) HashFile(localFilePath string) (string, error) {
+	return QuickXorHashFile(localFilePath)
+}
+
+func (quickXorHasher) RemoteHash(hashes *DriveItemHashes) string {
+	if hashes == nil {
+		return ""
+	}
+	return hashes.QuickXorHash
+}
+
+// hexHasher is a Hasher of the hashes OneDrive reports in uppercase hexadecimal.
+type hexHasher struct {
+	newHash    func() hash.Hash
+	remoteHash func(hashes *DriveItemHashes) string
+}
+
+func (h hexHasher) HashFile(localFilePath string) (string, error) {
+	file, err := os.Open(localFilePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	digest := h.newHash()
+	if _, err := io.Copy(digest, file); err != nil {
+		return "", err
+	}
+
+	return strings.ToUpper(hex.EncodeToString(digest.Sum(nil))), nil
+}
+
+func (h hexHasher) RemoteHash(hashes *DriveItemHashes) string {
+	if hashes == nil {
+		return ""
+	}
+	return strings.ToUpper(h.remoteHash(hashes))
+}
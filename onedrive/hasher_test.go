@@ -0,0 +1,87 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	localFilePath := filepath.Join(dir, "hello.txt")
+	if err := ioutil.WriteFile(localFilePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Cannot create the local file: %v", err)
+	}
+
+	quickXorHash, err := QuickXorHashFile(localFilePath)
+	if err != nil {
+		t.Fatalf("QuickXorHashFile returned error: %v", err)
+	}
+
+	hashes := &DriveItemHashes{
+		QuickXorHash: quickXorHash,
+		SHA1Hash:     "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+		SHA256Hash:   "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824",
+	}
+
+	for name, hasher := range map[string]Hasher{"QuickXor": QuickXorHasher, "SHA1": SHA1Hasher, "SHA256": SHA256Hasher} {
+		got, err := hasher.HashFile(localFilePath)
+		if err != nil {
+			t.Errorf("%sHasher.HashFile returned error: %v", name, err)
+			continue
+		}
+		if want := hasher.RemoteHash(hashes); got != want {
+			t.Errorf("%sHasher.HashFile returned %q, want %q", name, got, want)
+		}
+		if hasher.RemoteHash(nil) != "" {
+			t.Errorf("%sHasher.RemoteHash returned a hash without hashes", name)
+		}
+	}
+}
+
+func TestDriveItemsService_FindDuplicate_withHasher(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithHasher(SHA1Hasher)(client)
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	localFilePath := filepath.Join(dir, "hello.txt")
+	if err := ioutil.WriteFile(localFilePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Cannot create the local file: %v", err)
+	}
+
+	mux.HandleFunc("/me/drive/items/root-id/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"id": "no-sha1", "name": "a.txt", "size": 5, "file": {"hashes": {"quickXorHash": "AAAAAAAAAAAAAAAAAAAAAAAAAAA="}}},
+			{"id": "same", "name": "b.txt", "size": 5, "file": {"hashes": {"sha1Hash": "AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D"}}}
+		]}`)
+	})
+
+	duplicate, err := client.DriveItems.FindDuplicate(context.Background(), "root-id", localFilePath, FindDuplicateOpts{})
+	if err != nil {
+		t.Fatalf("DriveItems.FindDuplicate returned error: %v", err)
+	}
+	if duplicate == nil || duplicate.Id != "same" {
+		t.Errorf("DriveItems.FindDuplicate returned %+v, want item %q", duplicate, "same")
+	}
+}
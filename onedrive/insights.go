@@ -0,0 +1,145 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// InsightsService handles communication with the insights related methods of the Microsoft Graph API,
+// which suggest the documents trending around, used by or shared with the authenticated user.
+// Insights are not available to users of OneDrive personal, ErrNotSupportedOnPersonal is returned instead.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/officegraphinsights?view=graph-rest-1.0
+type InsightsService service
+
+// OneDriveInsightsResponse represents the JSON object containing insight list returned by the Microsoft Graph API.
+type OneDriveInsightsResponse struct {
+	ODataContext string     `json:"@odata.context"`
+	Insights     []*Insight `json:"value"`
+	// NextLink is the URL of the next page of insights, if any.
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// HasMore reports whether the insights are followed by another page, see NextLink.
+func (response *OneDriveInsightsResponse) HasMore() bool {
+	return response.NextLink != ""
+}
+
+// Insight represents a document suggested by the Microsoft Graph insights. Depending on the
+// kind of insight, only one of Weight, LastUsed or LastShared is filled.
+type Insight struct {
+	Id                    string                        `json:"id"`
+	Weight                float64                       `json:"weight"`
+	LastUsed              *InsightUsageDetails          `json:"lastUsed"`
+	LastShared            *InsightSharingDetail         `json:"lastShared"`
+	ResourceVisualization *InsightResourceVisualization `json:"resourceVisualization"`
+	ResourceReference     *InsightResourceReference     `json:"resourceReference"`
+}
+
+// InsightUsageDetails represents when the document of a used insight was last accessed or modified.
+type InsightUsageDetails struct {
+	LastAccessedDateTime time.Time `json:"lastAccessedDateTime"`
+	LastModifiedDateTime time.Time `json:"lastModifiedDateTime"`
+}
+
+// InsightSharingDetail represents how and by whom the document of a shared insight was shared.
+type InsightSharingDetail struct {
+	SharedDateTime time.Time        `json:"sharedDateTime"`
+	SharingSubject string           `json:"sharingSubject"`
+	SharingType    string           `json:"sharingType"`
+	SharedBy       *InsightIdentity `json:"sharedBy"`
+}
+
+// InsightIdentity represents the person who shared the document of a shared insight.
+type InsightIdentity struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Address     string `json:"address"`
+}
+
+// InsightResourceVisualization represents the properties of the document of an insight useful to display it.
+type InsightResourceVisualization struct {
+	Title                string `json:"title"`
+	Type                 string `json:"type"`
+	MediaType            string `json:"mediaType"`
+	PreviewImageURL      string `json:"previewImageUrl"`
+	PreviewText          string `json:"previewText"`
+	ContainerWebURL      string `json:"containerWebUrl"`
+	ContainerDisplayName string `json:"containerDisplayName"`
+	ContainerType        string `json:"containerType"`
+}
+
+// InsightResourceReference represents the reference to the document of an insight. For drive items,
+// Id is the Microsoft Graph path of the item, e.g. "drives/{drive-id}/items/{item-id}".
+type InsightResourceReference struct {
+	Id     string `json:"id"`
+	Type   string `json:"type"`
+	WebURL string `json:"webUrl"`
+}
+
+// ListTrending lists the documents trending around the authenticated user.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/insights-list-trending?view=graph-rest-1.0
+func (s *InsightsService) ListTrending(ctx context.Context) (*OneDriveInsightsResponse, error) {
+	return s.list(ctx, "me/insights/trending")
+}
+
+// ListUsed lists the documents recently viewed or modified by the authenticated user.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/insights-list-used?view=graph-rest-1.0
+func (s *InsightsService) ListUsed(ctx context.Context) (*OneDriveInsightsResponse, error) {
+	return s.list(ctx, "me/insights/used")
+}
+
+// ListShared lists the documents shared with or by the authenticated user.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/insights-list-shared?view=graph-rest-1.0
+func (s *InsightsService) ListShared(ctx context.Context) (*OneDriveInsightsResponse, error) {
+	return s.list(ctx, "me/insights/shared")
+}
+
+func (s *InsightsService) list(ctx context.Context, apiURL string) (*OneDriveInsightsResponse, error) {
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveInsightsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, s.client.Drives.checkPersonalSupport(ctx, err)
+	}
+
+	return oneDriveResponse, nil
+}
+
+// GetDriveItem resolves the document of an insight to its drive item. Only insights about
+// drive items can be resolved.
+func (s *InsightsService) GetDriveItem(ctx context.Context, insight *Insight) (*DriveItem, error) {
+	if insight == nil || insight.ResourceReference == nil || insight.ResourceReference.Id == "" {
+		return nil, errors.New("Please provide an insight referring to its resource.")
+	}
+
+	if insight.ResourceReference.Type != "microsoft.graph.driveItem" {
+		return nil, errors.New("Only insight about a drive item can be resolved to a drive item.")
+	}
+
+	req, err := s.client.NewRequest("GET", strings.TrimPrefix(insight.ResourceReference.Id, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestInsightsService_ListUsed_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/insights/used", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		jsonData := getTestDataFromFile(t, "fake_insights_used.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+	mux.HandleFunc("/drives/drive-1/items/item-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "item-1", "name": "Quarterly Report.docx"}`)
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Insights.ListUsed(ctx)
+	if err != nil {
+		t.Errorf("Insights.ListUsed returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveInsightsResponse
+	json.Unmarshal(getTestDataFromFile(t, "fake_insights_used.json"), &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Insights.ListUsed returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+
+	gotDriveItem, err := client.Insights.GetDriveItem(ctx, gotOneDriveResponse.Insights[0])
+	if err != nil {
+		t.Errorf("Insights.GetDriveItem returned error: %v", err)
+	}
+
+	if gotDriveItem == nil || gotDriveItem.Id != "item-1" {
+		t.Errorf("Insights.GetDriveItem returned %+v, want %q", gotDriveItem, "item-1")
+	}
+}
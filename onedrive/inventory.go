@@ -0,0 +1,90 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// largeFilesSelect lists the properties needed by FindLargeFiles, to keep the listing small.
+const largeFilesSelect = "id,name,size,lastModifiedDateTime,webUrl,file,folder,package,parentReference"
+
+// FindLargeFilesOpts represents the options for the report of FindLargeFiles.
+type FindLargeFilesOpts struct {
+	// DriveID is the ID of the drive to scan. If it is empty, it means the default drive of
+	// the authenticated user.
+	DriveID string
+	// FolderID is the ID of the folder to scan. If it is empty, it means the whole drive.
+	FolderID string
+	// MinSize is the size, in bytes, from which a file is reported.
+	MinSize int64
+	// ModifiedBefore, if set, only reports the files which have not been modified since.
+	ModifiedBefore time.Time
+	// Extensions, if set, only reports the files with one of these extensions, e.g. ".mp4".
+	// The comparison is case-insensitive.
+	Extensions []string
+	// Progress, if set, is called after every page of the listing, see WalkOpts.
+	Progress func(ScanProgress)
+}
+
+// FindLargeFiles lists the files of a drive, or of a folder and its subfolders, of at least
+// opts.MinSize bytes, from the largest to the smallest, e.g. to decide what to clean up
+// when the storage is running out.
+//
+// The size of a folder is the total size of its content, so the folders smaller than
+// opts.MinSize are not listed at all. Packages, such as OneNote notebooks, are reported as
+// files.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) FindLargeFiles(ctx context.Context, opts FindLargeFilesOpts) ([]*DriveItem, error) {
+	if opts.MinSize < 0 {
+		return nil, errors.New("Please provide a size threshold which is not negative.")
+	}
+
+	extensions := make(map[string]bool)
+	for _, extension := range opts.Extensions {
+		extensions["."+strings.ToLower(strings.TrimPrefix(extension, "."))] = true
+	}
+
+	var largeFiles []*DriveItem
+	walkOpts := WalkOpts{DriveID: opts.DriveID, FolderID: opts.FolderID, Progress: opts.Progress}
+	err := s.walk(ctx, walkOpts, largeFilesSelect, func(itemPath string, item *DriveItem) error {
+		if item.Folder != nil && !item.IsPackage() {
+			if item.Size < opts.MinSize {
+				return ErrSkipFolder
+			}
+			return nil
+		}
+
+		if item.Size < opts.MinSize {
+			return nil
+		}
+
+		if !opts.ModifiedBefore.IsZero() && !item.LastModifiedDateTime.Before(opts.ModifiedBefore) {
+			return nil
+		}
+
+		if len(extensions) > 0 && !extensions[strings.ToLower(path.Ext(item.Name))] {
+			return nil
+		}
+
+		largeFiles = append(largeFiles, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(largeFiles, func(i, j int) bool {
+		return largeFiles[i].Size > largeFiles[j].Size
+	})
+
+	return largeFiles, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_FindLargeFiles(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/drive-1/root/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("$select"); got != largeFilesSelect {
+			t.Errorf("Request $select = %q, want %q", got, largeFilesSelect)
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"value": [{"id": "package", "name": "Notebook", "size": 100, "lastModifiedDateTime": "2019-01-01T00:00:00Z", "folder": {}, "package": {"type": "oneNote"}}]}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"value": [
+			{"id": "video", "name": "holiday.MP4", "size": 500, "lastModifiedDateTime": "2019-01-01T00:00:00Z", "file": {}},
+			{"id": "small", "name": "note.txt", "size": 5, "lastModifiedDateTime": "2019-01-01T00:00:00Z", "file": {}},
+			{"id": "big-folder", "name": "Archive", "size": 2000, "folder": {"childCount": 2}},
+			{"id": "small-folder", "name": "Notes", "size": 50, "folder": {"childCount": 9}}
+		], "@odata.nextLink": "`+client.BaseURL.String()+`me/drives/drive-1/root/children?$select=`+largeFilesSelect+`&page=2"}`)
+	})
+	mux.HandleFunc("/me/drives/drive-1/items/big-folder/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"id": "recent", "name": "recent.mp4", "size": 1000, "lastModifiedDateTime": "2021-06-01T00:00:00Z", "file": {}},
+			{"id": "old", "name": "old.mp4", "size": 900, "lastModifiedDateTime": "2019-06-01T00:00:00Z", "file": {}},
+			{"id": "iso", "name": "disk.iso", "size": 100, "lastModifiedDateTime": "2019-06-01T00:00:00Z", "file": {}}
+		]}`)
+	})
+	mux.HandleFunc("/me/drives/drive-1/items/small-folder/children", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("DriveItems.FindLargeFiles listed a folder smaller than the threshold")
+	})
+
+	got, err := client.DriveItems.FindLargeFiles(context.Background(), FindLargeFilesOpts{
+		DriveID:        "drive-1",
+		MinSize:        100,
+		ModifiedBefore: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Extensions:     []string{"mp4"},
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.FindLargeFiles returned error: %v", err)
+	}
+
+	var gotIds []string
+	for _, item := range got {
+		gotIds = append(gotIds, item.Id)
+	}
+	want := []string{"old", "video"}
+	if !reflect.DeepEqual(gotIds, want) {
+		t.Errorf("DriveItems.FindLargeFiles returned %v, want %v", gotIds, want)
+	}
+}
+
+func TestDriveItemsService_FindLargeFiles_negativeSize(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	_, err := client.DriveItems.FindLargeFiles(context.Background(), FindLargeFilesOpts{MinSize: -1})
+	if err == nil {
+		t.Errorf("DriveItems.FindLargeFiles did not return an error for a negative size")
+	}
+}
+
+func TestDriveItemsService_FindLargeFiles_progress(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	rootPage1 := `{"value": [{"id": "folder", "name": "Archive", "size": 10, "folder": {}}], "@odata.nextLink": "` + client.BaseURL.String() + `me/drive/root/children?page=2"}`
+	rootPage2 := `{"value": [{"id": "a", "name": "a.bin", "size": 1, "file": {}}]}`
+	folderPage := `{"value": [{"id": "b", "name": "b.bin", "size": 2, "file": {}}, {"id": "c", "name": "c.bin", "size": 3, "file": {}}]}`
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, rootPage2)
+			return
+		}
+		fmt.Fprint(w, rootPage1)
+	})
+	mux.HandleFunc("/me/drive/items/folder/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, folderPage)
+	})
+
+	var got []ScanProgress
+	_, err := client.DriveItems.FindLargeFiles(context.Background(), FindLargeFilesOpts{
+		Progress: func(progress ScanProgress) {
+			got = append(got, progress)
+		},
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.FindLargeFiles returned error: %v", err)
+	}
+
+	want := []ScanProgress{
+		{ItemsDiscovered: 1, FoldersListed: 0, FoldersPending: 2, PagesFetched: 1, MetadataBytes: int64(len(rootPage1))},
+		{ItemsDiscovered: 2, FoldersListed: 1, FoldersPending: 1, PagesFetched: 2, MetadataBytes: int64(len(rootPage1) + len(rootPage2))},
+		{ItemsDiscovered: 4, FoldersListed: 2, FoldersPending: 0, PagesFetched: 3, MetadataBytes: int64(len(rootPage1) + len(rootPage2) + len(folderPage))},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DriveItems.FindLargeFiles reported %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+)
+
+// ItemHandle is a drive item bound to the client, so the methods about the item can be
+// called without passing its drive and item IDs around:
+//
+//	folder := client.DriveItems.Handle("", folderId)
+//	children, err := folder.Children(ctx)
+//	...
+//	err = client.DriveItems.HandleFor(children[0]).MoveTo(ctx, archiveFolderId)
+//
+// It is not safe for concurrent use.
+type ItemHandle struct {
+	service *DriveItemsService
+	driveId string
+	itemId  string
+	item    *DriveItem
+}
+
+// Handle returns a handle on the item with the given ID in a drive of the authenticated user.
+// No request is sent until a method of the handle is called.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func (s *DriveItemsService) Handle(driveId string, itemId string) *ItemHandle {
+	return &ItemHandle{service: s, driveId: driveId, itemId: itemId}
+}
+
+// HandleFor returns a handle on an item which has already been retrieved, e.g. by List. The
+// drive of the item is taken from its parentReference, if any.
+func (s *DriveItemsService) HandleFor(item *DriveItem) *ItemHandle {
+	handle := &ItemHandle{service: s, item: item}
+	if item != nil {
+		handle.itemId = item.Id
+		if item.ParentReference != nil {
+			handle.driveId = item.ParentReference.DriveId
+		}
+	}
+	return handle
+}
+
+// ID returns the ID of the item.
+func (h *ItemHandle) ID() string {
+	return h.itemId
+}
+
+// DriveID returns the ID of the drive of the item, or an empty string for the default drive
+// of the authenticated user.
+func (h *ItemHandle) DriveID() string {
+	return h.driveId
+}
+
+// Item returns the last known state of the item, or nil if it has not been retrieved yet.
+func (h *ItemHandle) Item() *DriveItem {
+	return h.item
+}
+
+// itemURL returns the relative URL of the item.
+func (h *ItemHandle) itemURL() string {
+	if h.driveId == "" {
+		return "me/drive/items/" + url.PathEscape(h.itemId)
+	}
+	return "me/drives/" + url.PathEscape(h.driveId) + "/items/" + url.PathEscape(h.itemId)
+}
+
+// Refresh retrieves the current state of the item, which Item returns afterwards.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
+func (h *ItemHandle) Refresh(ctx context.Context) (*DriveItem, error) {
+	if h.itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item.")
+	}
+
+	req, err := h.service.client.NewRequest("GET", h.itemURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *DriveItem
+	if err := h.service.client.doItem(ctx, h.itemId, req, &item); err != nil {
+		return nil, err
+	}
+
+	h.item = item
+	return item, nil
+}
+
+// Children lists all the items of the folder.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (h *ItemHandle) Children(ctx context.Context) ([]*DriveItem, error) {
+	if h.itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the folder.")
+	}
+
+	return h.service.listChildren(ctx, h.driveId, h.itemId)
+}
+
+// Download writes the content of the file to w. The item is refreshed first when its
+// download URL is not known.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (h *ItemHandle) Download(ctx context.Context, w io.Writer) error {
+	if h.item == nil || h.item.DownloadURL == "" {
+		if _, err := h.Refresh(ctx); err != nil {
+			return err
+		}
+	}
+
+	if h.item.IsPackage() {
+		return ErrPackageItem
+	}
+
+	if h.item.File == nil || h.item.DownloadURL == "" {
+		return errors.New("Only file is allowed to be downloaded here.")
+	}
+
+	content, err := h.service.openDownload(ctx, h.item)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	_, err = io.Copy(w, content)
+	return err
+}
+
+// MoveTo moves the item into another folder of the same drive.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_move?view=odsp-graph-online
+func (h *ItemHandle) MoveTo(ctx context.Context, destinationParentFolderId string) error {
+	response, err := h.service.Move(ctx, h.driveId, h.itemId, destinationParentFolderId)
+	if err != nil {
+		return err
+	}
+
+	if h.item != nil {
+		parentReference := response.ParentFolder
+		h.item.ParentReference = &parentReference
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestItemHandle(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/drive-1/items/folder-1/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if r.URL.Query().Get("$skiptoken") == "" {
+			fmt.Fprintf(w, `{"value": [{"id": "file-1", "name": "a.txt", "file": {}, "parentReference": {"driveId": "drive-1", "id": "folder-1"}}], "@odata.nextLink": "%sme/drives/drive-1/items/folder-1/children?$skiptoken=page2"}`, client.BaseURL)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "file-2", "name": "b.txt", "file": {}, "parentReference": {"driveId": "drive-1", "id": "folder-1"}}]}`)
+	})
+	mux.HandleFunc("/me/drives/drive-1/items/file-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, `{"id": "file-1", "name": "a.txt", "file": {}, "@microsoft.graph.downloadUrl": "%s%s/download/file-1"}`, serverURL, baseURLPath)
+		case "PATCH":
+			fmt.Fprint(w, `{"id": "file-1", "name": "a.txt", "parentReference": {"driveId": "drive-1", "id": "archive"}}`)
+		default:
+			t.Errorf("Request method: %v, want GET or PATCH", r.Method)
+		}
+	})
+	mux.HandleFunc("/download/file-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content of a.txt")
+	})
+
+	ctx := context.Background()
+	children, err := client.DriveItems.Handle("drive-1", "folder-1").Children(ctx)
+	if err != nil {
+		t.Fatalf("ItemHandle.Children returned error: %v", err)
+	}
+
+	var gotIds []string
+	for _, child := range children {
+		gotIds = append(gotIds, child.Id)
+	}
+	if want := []string{"file-1", "file-2"}; !reflect.DeepEqual(gotIds, want) {
+		t.Errorf("ItemHandle.Children returned %v, want %v", gotIds, want)
+	}
+
+	file := client.DriveItems.HandleFor(children[0])
+	if file.DriveID() != "drive-1" || file.ID() != "file-1" {
+		t.Errorf("DriveItems.HandleFor returned a handle on %q/%q, want drive-1/file-1", file.DriveID(), file.ID())
+	}
+
+	var content bytes.Buffer
+	if err := file.Download(ctx, &content); err != nil {
+		t.Fatalf("ItemHandle.Download returned error: %v", err)
+	}
+	if content.String() != "content of a.txt" {
+		t.Errorf("ItemHandle.Download wrote %q, want %q", content.String(), "content of a.txt")
+	}
+
+	if err := file.MoveTo(ctx, "archive"); err != nil {
+		t.Fatalf("ItemHandle.MoveTo returned error: %v", err)
+	}
+	if got := file.Item().ParentReference.Id; got != "archive" {
+		t.Errorf("ItemHandle.Item has parent %q after MoveTo, want %q", got, "archive")
+	}
+}
+
+func TestItemHandle_Download_package(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	notebook := &DriveItem{Id: "1", Package: &DriveItemPackage{Type: "oneNote"}, DownloadURL: "https://example.com/1"}
+	if err := client.DriveItems.HandleFor(notebook).Download(context.Background(), &bytes.Buffer{}); err != ErrPackageItem {
+		t.Errorf("ItemHandle.Download returned error %v, want %v", err, ErrPackageItem)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operation represents a request modifying a drive which has been recorded instead of
+// being sent, see WithDryRun.
+type Operation struct {
+	Time   time.Time
+	Method string
+	URL    string
+	// Payload is the JSON body of the request, if any. The content of uploaded files
+	// is not recorded, only its length.
+	Payload       json.RawMessage
+	ContentLength int64
+}
+
+// OperationJournal records the operations of a client in dry-run mode. It is safe for
+// concurrent use.
+type OperationJournal struct {
+	mu         sync.Mutex
+	operations []Operation
+}
+
+// Operations returns the operations recorded so far, in the order they were made.
+func (j *OperationJournal) Operations() []Operation {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	operations := make([]Operation, len(j.operations))
+	copy(operations, j.operations)
+	return operations
+}
+
+func (j *OperationJournal) record(req *http.Request) error {
+	operation := Operation{
+		Time:          time.Now(),
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		ContentLength: req.ContentLength,
+	}
+
+	if req.Body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		payload, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(payload))
+		operation.Payload = payload
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.operations = append(j.operations, operation)
+	return nil
+}
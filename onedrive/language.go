@@ -0,0 +1,35 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"net/http"
+)
+
+type acceptLanguageKey struct{}
+
+// ContextWithAcceptLanguage returns a copy of ctx making the requests sent with it carry
+// the given Accept-Language header, e.g. "fr-FR", instead of the one set by WithAcceptLanguage.
+// This is useful for servers relaying the errors of OneDrive to users of different locales.
+func ContextWithAcceptLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, acceptLanguageKey{}, language)
+}
+
+// setAcceptLanguage sets the Accept-Language header of a request, unless it is already set.
+func (c *Client) setAcceptLanguage(ctx context.Context, req *http.Request) {
+	if req.Header.Get("Accept-Language") != "" {
+		return
+	}
+
+	language := c.acceptLanguage
+	if contextLanguage, ok := ctx.Value(acceptLanguageKey{}).(string); ok {
+		language = contextLanguage
+	}
+
+	if language != "" {
+		req.Header.Set("Accept-Language", language)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_WithAcceptLanguage(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithAcceptLanguage("fr-FR")(client)
+
+	var gotLanguage string
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		gotLanguage = r.Header.Get("Accept-Language")
+
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "Item not found", "localizedMessage": "Élément introuvable"}}`)
+	})
+
+	ctx := context.Background()
+	_, err := client.DriveItems.Get(ctx, "1")
+	if oneDriveError, ok := err.(*Error); !ok || oneDriveError.LocalizedMessage != "Élément introuvable" {
+		t.Errorf("DriveItems.Get returned error %v, want the localized error", err)
+	}
+	if want := "fr-FR"; gotLanguage != want {
+		t.Errorf("Accept-Language header: %q, want %q", gotLanguage, want)
+	}
+
+	client.DriveItems.Get(ContextWithAcceptLanguage(ctx, "de-DE"), "1")
+	if want := "de-DE"; gotLanguage != want {
+		t.Errorf("Accept-Language header: %q, want %q", gotLanguage, want)
+	}
+}
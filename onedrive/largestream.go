@@ -0,0 +1,90 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// LargeStream represents a file to upload by UploadLargeStream, whose content is read
+// sequentially, e.g. from stdin, an HTTP response or a tar archive.
+type LargeStream struct {
+	Name string
+	Size uint64
+	Data io.Reader
+}
+
+// UploadLargeStream is to upload a file larger than 4 MiB to a drive of the authenticated
+// user, like UploadLargeFile, when its content can only be read once. The size of the file
+// must be known up front. Only one chunk is held in memory at a time.
+//
+// A chunk which failed transiently is uploaded again from memory; if the upload session
+// expects bytes before the current chunk, which OneDrive does not normally do, the upload
+// fails, since the stream cannot be rewound.
+//
+// OneDrive API docs:
+// https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession
+func (s *DriveItemsService) UploadLargeStream(ctx context.Context, destinationParentFolderId string, file LargeStream, opts UploadLargeFileOpts) (*DriveItem, error) {
+	if file.Data == nil {
+		return nil, errors.New("Please provide the file reader.")
+	}
+
+	return s.UploadLargeFile(ctx, destinationParentFolderId, LargeFile{
+		Name: file.Name,
+		Size: file.Size,
+		Data: &streamReaderAt{reader: file.Data},
+	}, opts)
+}
+
+// streamReaderAt is an io.ReaderAt reading a stream sequentially. It keeps the bytes of the
+// last read, so they can be read again, but the bytes before cannot.
+type streamReaderAt struct {
+	reader io.Reader
+	window []byte // The bytes from offset which have been read from the reader.
+	offset int64
+	err    error // The error which ended the stream, if any.
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *streamReaderAt) ReadAt(p []byte, offset int64) (int, error) {
+	if offset < r.offset {
+		return 0, fmt.Errorf("The stream cannot be read again from byte %d, it has been read up to byte %d.", offset, r.offset)
+	}
+
+	// Forget the bytes before offset, reading the ones skipped, if any.
+	if skip := offset - r.offset; skip > int64(len(r.window)) {
+		if _, err := io.CopyN(ioutil.Discard, r.reader, skip-int64(len(r.window))); err != nil {
+			return 0, err
+		}
+		r.window = r.window[:0]
+	} else {
+		r.window = r.window[skip:]
+	}
+	r.offset = offset
+
+	if missing := len(p) - len(r.window); missing > 0 && r.err == nil {
+		window := make([]byte, len(p))
+		copy(window, r.window)
+		n, err := io.ReadFull(r.reader, window[len(r.window):])
+		r.window = window[:len(r.window)+n]
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		r.err = err
+	}
+
+	n := copy(p, r.window)
+	if n < len(p) {
+		if r.err == nil {
+			return n, io.EOF
+		}
+		return n, r.err
+	}
+	return n, nil
+}
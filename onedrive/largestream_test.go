@@ -0,0 +1,77 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDriveItemsService_UploadLargeStream(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder-1:/stdin.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+	})
+	var content string
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		content += string(body)
+		if len(content) == 10 {
+			fmt.Fprint(w, `{"id":"1","name":"stdin.bin"}`)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"nextExpectedRanges":["%d-"]}`, len(content))
+	})
+
+	// The pipe is a stream which cannot be read at an offset.
+	reader, writer := io.Pipe()
+	go func() {
+		writer.Write([]byte("0123456789"))
+		writer.Close()
+	}()
+
+	file := LargeStream{Name: "stdin.bin", Size: 10, Data: reader}
+	item, err := client.DriveItems.UploadLargeStream(context.Background(), "folder-1", file, UploadLargeFileOpts{ChunkSize: 4})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadLargeStream returned error: %v", err)
+	}
+	if item.Id != "1" || content != "0123456789" {
+		t.Errorf("DriveItems.UploadLargeStream returned %+v after uploading %q", item, content)
+	}
+}
+
+func TestStreamReaderAt(t *testing.T) {
+	r := &streamReaderAt{reader: strings.NewReader("0123456789")}
+	read := func(offset int64, length int, want string, wantErr error) {
+		t.Helper()
+		p := make([]byte, length)
+		n, err := r.ReadAt(p, offset)
+		if string(p[:n]) != want || err != wantErr {
+			t.Errorf("ReadAt(%d, %d) = %q, %v, want %q, %v", offset, length, p[:n], err, want, wantErr)
+		}
+	}
+
+	read(0, 4, "0123", nil)
+	read(2, 4, "2345", nil) // The bytes of the last read can be read again.
+	read(8, 4, "89", io.EOF)
+
+	if _, err := r.ReadAt(make([]byte, 1), 1); err == nil {
+		t.Error("ReadAt returned no error for bytes which have been forgotten")
+	}
+}
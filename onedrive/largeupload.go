@@ -0,0 +1,182 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"sync"
+)
+
+// LargeUpload represents an upload started by StartLargeUpload, which can be paused,
+// resumed, canceled and shut down from other goroutines.
+type LargeUpload struct {
+	size   uint64
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	paused    bool
+	resumed   chan struct{} // Closed by Resume.
+	canceled  bool
+	suspended bool
+	uploadURL string
+	uploaded  uint64
+	item      *DriveItem
+	err       error
+}
+
+// StartLargeUpload starts uploading a file larger than 4 MiB to a drive of the authenticated
+// user, like UploadLargeFile, and returns without waiting for the upload to be over. The
+// returned LargeUpload controls the upload, and Wait returns its outcome.
+//
+// OneDrive API docs:
+// https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession
+func (s *DriveItemsService) StartLargeUpload(ctx context.Context, destinationParentFolderId string, file LargeFile, opts UploadLargeFileOpts) (*LargeUpload, error) {
+	upload, err := s.prepareLargeUpload(ctx, destinationParentFolderId, file, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	u := &LargeUpload{
+		size:   file.Size,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(u.done)
+		defer cancel()
+
+		item, err := upload(ctx, u.beforeChunk)
+
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		// An upload which was over when it got canceled is kept.
+		if err != nil && u.canceled {
+			err = ErrUploadCanceled
+		}
+		if err == nil {
+			u.uploaded = u.size
+		}
+		u.item, u.err = item, err
+	}()
+
+	return u, nil
+}
+
+// Pause pauses the upload once the chunk being uploaded, if any, is over. The upload
+// session expires if the upload stays paused for too long, usually a few days.
+func (u *LargeUpload) Pause() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.paused {
+		u.paused = true
+		u.resumed = make(chan struct{})
+	}
+}
+
+// Resume resumes a paused upload.
+func (u *LargeUpload) Resume() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.paused {
+		u.paused = false
+		close(u.resumed)
+	}
+}
+
+// Cancel stops the upload, interrupting the chunk being uploaded, if any, and deletes the
+// upload session, so the bytes already uploaded are discarded. It returns once the upload
+// is over. Canceling an upload which is already over has no effect.
+func (u *LargeUpload) Cancel() {
+	u.mu.Lock()
+	u.canceled = true
+	u.mu.Unlock()
+
+	u.cancel()
+	<-u.done
+}
+
+// Shutdown stops the upload once the chunk being uploaded, if any, is over, and keeps the
+// upload session, which is also kept in UploadLargeFileOpts.SessionStore, if any, so the
+// upload can be continued later with ResumeUpload and Session, even by another process.
+// Wait then returns ErrUploadSuspended. If ctx is done before the chunk is over, the upload
+// is canceled instead, see Cancel, and the error of ctx is returned.
+func (u *LargeUpload) Shutdown(ctx context.Context) error {
+	u.mu.Lock()
+	u.suspended = true
+	if u.paused {
+		u.paused = false
+		close(u.resumed)
+	}
+	u.mu.Unlock()
+
+	select {
+	case <-u.done:
+		return nil
+	case <-ctx.Done():
+		u.Cancel()
+		return ctx.Err()
+	}
+}
+
+// Session returns the upload session of the upload, or nil if it has not been created yet.
+func (u *LargeUpload) Session() *UploadSession {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.uploadURL == "" {
+		return nil
+	}
+	return &UploadSession{UploadUrl: u.uploadURL}
+}
+
+// Wait waits for the upload to be over, and returns the uploaded item. ErrUploadCanceled
+// is returned if the upload has been canceled.
+func (u *LargeUpload) Wait() (*DriveItem, error) {
+	<-u.done
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.item, u.err
+}
+
+// Uploaded returns the number of bytes received by OneDrive so far, e.g. to report the
+// progress of the upload.
+func (u *LargeUpload) Uploaded() uint64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.uploaded
+}
+
+// beforeChunk records the progress of the upload, blocks while it is paused, and suspends
+// it once it is shut down.
+func (u *LargeUpload) beforeChunk(ctx context.Context, uploadURL string, offset uint64) error {
+	u.mu.Lock()
+	u.uploadURL, u.uploaded = uploadURL, offset
+	for u.paused {
+		resumed := u.resumed
+		u.mu.Unlock()
+
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		u.mu.Lock()
+	}
+	defer u.mu.Unlock()
+
+	if u.suspended {
+		return ErrUploadSuspended
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// chunkServer serves an upload session of a 10 bytes file, signaling every chunk received.
+// The response to a chunk is only sent once a value is received from release.
+func chunkServer(t *testing.T, mux *http.ServeMux, serverURL string) (chunks chan string, release chan struct{}, deleted chan struct{}) {
+	chunks = make(chan string, 10)
+	release = make(chan struct{}, 10)
+	deleted = make(chan struct{}, 1)
+
+	mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+	})
+
+	var mu sync.Mutex
+	received := 0
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted <- struct{}{}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		received += len(body)
+		n := received
+		mu.Unlock()
+		chunks <- string(body)
+		<-release
+
+		if n == 10 {
+			fmt.Fprint(w, `{"id":"1","name":"big.bin"}`)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"nextExpectedRanges":["%d-"]}`, n)
+	})
+
+	return chunks, release, deleted
+}
+
+func TestDriveItemsService_StartLargeUpload_pause(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	chunks, release, _ := chunkServer(t, mux, serverURL)
+
+	file := LargeFile{Name: "big.bin", Size: 10, Data: strings.NewReader("0123456789")}
+	upload, err := client.DriveItems.StartLargeUpload(context.Background(), "folder-1", file, UploadLargeFileOpts{ChunkSize: 4})
+	if err != nil {
+		t.Fatalf("DriveItems.StartLargeUpload returned error: %v", err)
+	}
+
+	// The upload is paused while the first chunk is in flight.
+	if chunk := <-chunks; chunk != "0123" {
+		t.Errorf("The first chunk is %q", chunk)
+	}
+	upload.Pause()
+	release <- struct{}{}
+	select {
+	case chunk := <-chunks:
+		t.Errorf("The chunk %q was uploaded while the upload was paused", chunk)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := upload.Uploaded(); got != 4 {
+		t.Errorf("Uploaded returned %d while paused, want 4", got)
+	}
+
+	release <- struct{}{}
+	release <- struct{}{}
+	upload.Resume()
+	item, err := upload.Wait()
+	if err != nil {
+		t.Fatalf("LargeUpload.Wait returned error: %v", err)
+	}
+	if item.Id != "1" || upload.Uploaded() != 10 {
+		t.Errorf("LargeUpload.Wait returned %+v after %d bytes", item, upload.Uploaded())
+	}
+}
+
+func TestDriveItemsService_StartLargeUpload_cancel(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	chunks, release, deleted := chunkServer(t, mux, serverURL)
+
+	file := LargeFile{Name: "big.bin", Size: 10, Data: strings.NewReader("0123456789")}
+	upload, err := client.DriveItems.StartLargeUpload(context.Background(), "folder-1", file, UploadLargeFileOpts{ChunkSize: 4})
+	if err != nil {
+		t.Fatalf("DriveItems.StartLargeUpload returned error: %v", err)
+	}
+
+	<-chunks
+	upload.Pause()
+	release <- struct{}{}
+	for upload.Uploaded() != 4 {
+		time.Sleep(time.Millisecond)
+	}
+	upload.Cancel()
+
+	if _, err := upload.Wait(); err != ErrUploadCanceled {
+		t.Errorf("LargeUpload.Wait returned error %v, want %v", err, ErrUploadCanceled)
+	}
+	select {
+	case <-deleted:
+	default:
+		t.Error("The upload session has not been deleted")
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ContentType represents a content type of a SharePoint document library, i.e. a named set
+// of columns and behaviors its items conform to.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/resources/contenttype?view=graph-rest-1.0
+type ContentType struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Group       string `json:"group"`
+	Hidden      bool   `json:"hidden"`
+	ReadOnly    bool   `json:"readOnly"`
+	Sealed      bool   `json:"sealed"`
+}
+
+// OneDriveContentTypesResponse represents the JSON object containing the content types of a
+// document library returned by the Microsoft Graph API.
+type OneDriveContentTypesResponse struct {
+	ODataContext string         `json:"@odata.context"`
+	ContentTypes []*ContentType `json:"value"`
+}
+
+// ColumnDefinition represents a column of a SharePoint document library. Only the facet of
+// its type is set, see Type.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/resources/columndefinition?view=graph-rest-1.0
+type ColumnDefinition struct {
+	Id                  string `json:"id"`
+	Name                string `json:"name"`
+	DisplayName         string `json:"displayName"`
+	Description         string `json:"description"`
+	ColumnGroup         string `json:"columnGroup"`
+	Hidden              bool   `json:"hidden"`
+	Indexed             bool   `json:"indexed"`
+	ReadOnly            bool   `json:"readOnly"`
+	Required            bool   `json:"required"`
+	EnforceUniqueValues bool   `json:"enforceUniqueValues"`
+
+	Text          *TextColumn     `json:"text"`
+	Number        *NumberColumn   `json:"number"`
+	Choice        *ChoiceColumn   `json:"choice"`
+	Boolean       json.RawMessage `json:"boolean"`
+	DateTime      json.RawMessage `json:"dateTime"`
+	Currency      json.RawMessage `json:"currency"`
+	Lookup        json.RawMessage `json:"lookup"`
+	PersonOrGroup json.RawMessage `json:"personOrGroup"`
+	Calculated    json.RawMessage `json:"calculated"`
+}
+
+// TextColumn represents the constraints of a text column.
+type TextColumn struct {
+	AllowMultipleLines bool `json:"allowMultipleLines"`
+	MaxLength          int  `json:"maxLength"`
+}
+
+// NumberColumn represents the constraints of a number column. The zero minimum and maximum
+// mean unbounded.
+type NumberColumn struct {
+	DecimalPlaces string  `json:"decimalPlaces"`
+	Minimum       float64 `json:"minimum"`
+	Maximum       float64 `json:"maximum"`
+}
+
+// ChoiceColumn represents the values allowed in a choice column.
+type ChoiceColumn struct {
+	AllowTextEntry bool     `json:"allowTextEntry"`
+	Choices        []string `json:"choices"`
+}
+
+// Type returns the type of the column, e.g. "text", "choice" or "dateTime", or an empty
+// string if it is of a type not modeled by ColumnDefinition.
+func (column *ColumnDefinition) Type() string {
+	switch {
+	case column.Text != nil:
+		return "text"
+	case column.Number != nil:
+		return "number"
+	case column.Choice != nil:
+		return "choice"
+	case column.Boolean != nil:
+		return "boolean"
+	case column.DateTime != nil:
+		return "dateTime"
+	case column.Currency != nil:
+		return "currency"
+	case column.Lookup != nil:
+		return "lookup"
+	case column.PersonOrGroup != nil:
+		return "personOrGroup"
+	case column.Calculated != nil:
+		return "calculated"
+	}
+	return ""
+}
+
+// OneDriveColumnsResponse represents the JSON object containing the columns of a document
+// library returned by the Microsoft Graph API.
+type OneDriveColumnsResponse struct {
+	ODataContext string              `json:"@odata.context"`
+	Columns      []*ColumnDefinition `json:"value"`
+}
+
+// Column returns the column with the given internal name, or display name, if any.
+func (response *OneDriveColumnsResponse) Column(name string) *ColumnDefinition {
+	for _, column := range response.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+	for _, column := range response.Columns {
+		if column.DisplayName == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// ValidateFields checks the values of the fields of a list item against the columns of the
+// library before they are updated, e.g. by a migration tool: every field has to be a
+// writable column, the required columns cannot be cleared, texts must fit and choices must
+// be allowed. The fields are keyed by the internal names of the columns.
+func (response *OneDriveColumnsResponse) ValidateFields(fields map[string]interface{}) error {
+	var problems []string
+	for name, value := range fields {
+		column := response.Column(name)
+		if column == nil || column.Name != name {
+			problems = append(problems, fmt.Sprintf("%q is not a column of the library", name))
+			continue
+		}
+		if column.ReadOnly {
+			problems = append(problems, fmt.Sprintf("%q is read-only", name))
+			continue
+		}
+		if value == nil {
+			if column.Required {
+				problems = append(problems, fmt.Sprintf("%q is required", name))
+			}
+			continue
+		}
+
+		text, isText := value.(string)
+		switch {
+		case column.Text != nil && isText && column.Text.MaxLength > 0 && len([]rune(text)) > column.Text.MaxLength:
+			problems = append(problems, fmt.Sprintf("%q is longer than %d characters", name, column.Text.MaxLength))
+		case column.Choice != nil && isText && !column.Choice.AllowTextEntry && !containsString(column.Choice.Choices, text):
+			problems = append(problems, fmt.Sprintf("%q is not one of the choices of %q", text, name))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("The fields are not valid: %s.", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// ListContentTypes returns the content types of the document library of a drive.
+//
+// If driveId is empty, it means the selected drive will be the default drive of the
+// authenticated user. Drives of OneDrive personal have no document library;
+// ErrNotSupportedOnPersonal is returned instead.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/list-list-contenttypes?view=graph-rest-1.0
+func (s *DrivesService) ListContentTypes(ctx context.Context, driveId string) (*OneDriveContentTypesResponse, error) {
+	req, err := s.client.NewRequest("GET", libraryURL(driveId)+"/contentTypes", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *OneDriveContentTypesResponse
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, s.checkPersonalSupport(ctx, err)
+	}
+
+	return response, nil
+}
+
+// ListColumns returns the columns of the document library of a drive, see
+// OneDriveColumnsResponse.ValidateFields.
+//
+// If driveId is empty, it means the selected drive will be the default drive of the
+// authenticated user. Drives of OneDrive personal have no document library;
+// ErrNotSupportedOnPersonal is returned instead.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/list-list-columns?view=graph-rest-1.0
+func (s *DrivesService) ListColumns(ctx context.Context, driveId string) (*OneDriveColumnsResponse, error) {
+	req, err := s.client.NewRequest("GET", libraryURL(driveId)+"/columns", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *OneDriveColumnsResponse
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, s.checkPersonalSupport(ctx, err)
+	}
+
+	return response, nil
+}
+
+// libraryURL returns the relative URL of the document library of a drive.
+func libraryURL(driveId string) string {
+	if driveId == "" {
+		return "me/drive/list"
+	}
+	return "drives/" + url.PathEscape(driveId) + "/list"
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDrivesService_ListContentTypes(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/d/list/contentTypes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value":[{"id":"0x0101","name":"Document","group":"Document Content Types"},{"id":"0x0120","name":"Folder","sealed":true}]}`)
+	})
+
+	response, err := client.Drives.ListContentTypes(context.Background(), "d")
+	if err != nil {
+		t.Fatalf("Drives.ListContentTypes returned error: %v", err)
+	}
+	if len(response.ContentTypes) != 2 || response.ContentTypes[0].Name != "Document" || !response.ContentTypes[1].Sealed {
+		t.Errorf("Drives.ListContentTypes returned %+v", response.ContentTypes)
+	}
+}
+
+func TestDrivesService_ListColumns(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/list/columns", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value":[
+			{"name":"Title","displayName":"Title","required":true,"text":{"maxLength":5}},
+			{"name":"Status0","displayName":"Status","choice":{"choices":["Draft","Final"]}},
+			{"name":"Modified","displayName":"Modified","readOnly":true,"dateTime":{"format":"dateTime"}}
+		]}`)
+	})
+
+	columns, err := client.Drives.ListColumns(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Drives.ListColumns returned error: %v", err)
+	}
+
+	if column := columns.Column("Status"); column == nil || column.Name != "Status0" || column.Type() != "choice" {
+		t.Errorf("Column(%q) returned %+v", "Status", column)
+	}
+	if got, want := columns.Column("Modified").Type(), "dateTime"; got != want {
+		t.Errorf("Type() = %q, want %q", got, want)
+	}
+
+	if err := columns.ValidateFields(map[string]interface{}{"Title": "Memo", "Status0": "Final"}); err != nil {
+		t.Errorf("ValidateFields returned error for valid fields: %v", err)
+	}
+
+	err = columns.ValidateFields(map[string]interface{}{
+		"Title":    "Too long",
+		"Status0":  "Archived",
+		"Modified": "2021-01-01T00:00:00Z",
+		"Owner":    "Bob",
+	})
+	if err == nil {
+		t.Fatal("ValidateFields returned no error for invalid fields")
+	}
+	for _, problem := range []string{"longer than 5", `"Archived" is not one of the choices`, `"Modified" is read-only`, `"Owner" is not a column`} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("ValidateFields returned %q, want it to mention %q", err, problem)
+		}
+	}
+
+	if err := columns.ValidateFields(map[string]interface{}{"Title": nil}); err == nil {
+		t.Error("ValidateFields returned no error when clearing a required column")
+	}
+}
+
+func TestDrivesService_ListColumns_badRequestOnPersonalDrive(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/bad id/list/columns", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"code": "invalidRequest", "message": "Invalid drive id"}}`)
+	})
+	mux.HandleFunc("/me/drive", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, string(getTestDataFromFile(t, "fake_defaultDrive.json")))
+	})
+
+	_, err := client.Drives.ListColumns(context.Background(), "bad id")
+	if errors.Is(err, ErrNotSupportedOnPersonal) {
+		t.Errorf("Drives.ListColumns returned error %v for a bad request, want the error of the API", err)
+	}
+	if oneDriveError, ok := err.(*Error); !ok || oneDriveError.Code != "invalidRequest" {
+		t.Errorf("Drives.ListColumns returned error %v, want the invalidRequest error", err)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ListItemVersion represents a version of the list item of a drive item in a SharePoint
+// document library, with the values of its columns at that version. It is missing on
+// OneDrive personal.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/resources/listitemversion?view=graph-rest-1.0
+type ListItemVersion struct {
+	Id                   string                 `json:"id"`
+	LastModifiedDateTime time.Time              `json:"lastModifiedDateTime"`
+	LastModifiedBy       *Owner                 `json:"lastModifiedBy"`
+	Publication          *DriveItemPublication  `json:"publication"`
+	Fields               map[string]interface{} `json:"fields"`
+}
+
+// OneDriveListItemVersionsResponse represents the JSON object containing the versions of a
+// list item returned by the Microsoft Graph API.
+type OneDriveListItemVersionsResponse struct {
+	ODataContext string             `json:"@odata.context"`
+	Versions     []*ListItemVersion `json:"value"`
+	// NextLink is the URL of the next page of versions, if any.
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// FieldChange represents a change of the value of a column of a list item, as found by
+// FieldHistory.
+type FieldChange struct {
+	VersionId            string
+	LastModifiedDateTime time.Time
+	LastModifiedBy       *Owner
+	// Value is the value of the column from this version on; nil if it has been cleared.
+	Value interface{}
+}
+
+// ListItemVersions returns the versions of the list item of a drive item in a document
+// library, newest first, with the values of the columns at each version. Unlike the
+// versions of the file, they also track the changes of the custom columns.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/listitem-list-versions?view=graph-rest-1.0
+func (s *DriveItemsService) ListItemVersions(ctx context.Context, driveId string, itemId string) (*OneDriveListItemVersionsResponse, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/listItem/versions?$expand=fields"
+	if driveId != "" {
+		apiURL = "drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId) + "/listItem/versions?$expand=fields"
+	}
+
+	response := &OneDriveListItemVersionsResponse{}
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page *OneDriveListItemVersionsResponse
+		if err := s.client.doItem(ctx, itemId, req, &page); err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+
+		if response.ODataContext == "" {
+			response.ODataContext = page.ODataContext
+		}
+		response.Versions = append(response.Versions, page.Versions...)
+		apiURL = page.NextLink
+	}
+
+	return response, nil
+}
+
+// FieldHistory returns the changes of the value of a column of the list item of a drive
+// item in a document library, oldest first, e.g. to audit who changed the status of a
+// document and when. The versions which did not change the column are left out.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/listitem-list-versions?view=graph-rest-1.0
+func (s *DriveItemsService) FieldHistory(ctx context.Context, driveId string, itemId string, field string) ([]FieldChange, error) {
+	if field == "" {
+		return nil, errors.New("Please provide the name of the column.")
+	}
+
+	response, err := s.ListItemVersions(ctx, driveId, itemId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := response.Versions
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].LastModifiedDateTime.Before(versions[j].LastModifiedDateTime)
+	})
+
+	var changes []FieldChange
+	for i, version := range versions {
+		value := version.Fields[field]
+		if i > 0 && reflect.DeepEqual(value, versions[i-1].Fields[field]) {
+			continue
+		}
+		if i == 0 && value == nil {
+			continue
+		}
+
+		changes = append(changes, FieldChange{
+			VersionId:            version.Id,
+			LastModifiedDateTime: version.LastModifiedDateTime,
+			LastModifiedBy:       version.LastModifiedBy,
+			Value:                value,
+		})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_FieldHistory(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/d/items/1/listItem/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"value":[
+				{"id":"1.0","lastModifiedDateTime":"2021-01-01T00:00:00Z","fields":{"Title":"a"}}
+			]}`)
+			return
+		}
+		if got, want := r.URL.Query().Get("$expand"), "fields"; got != want {
+			t.Errorf("$expand = %q, want %q", got, want)
+		}
+		fmt.Fprintf(w, `{"value":[
+			{"id":"4.0","lastModifiedDateTime":"2021-01-04T00:00:00Z","lastModifiedBy":{"user":{"displayName":"Bob"}},"fields":{"Title":"a","Status":"Approved"}},
+			{"id":"3.0","lastModifiedDateTime":"2021-01-03T00:00:00Z","fields":{"Title":"b","Status":"Draft"}},
+			{"id":"2.0","lastModifiedDateTime":"2021-01-02T00:00:00Z","fields":{"Title":"a","Status":"Draft"}}
+		],"@odata.nextLink":"%s%s/drives/d/items/1/listItem/versions?page=2"}`, serverURL, baseURLPath)
+	})
+
+	changes, err := client.DriveItems.FieldHistory(context.Background(), "d", "1", "Status")
+	if err != nil {
+		t.Fatalf("DriveItems.FieldHistory returned error: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("DriveItems.FieldHistory returned %+v, want 2 changes", changes)
+	}
+	if changes[0].VersionId != "2.0" || changes[0].Value != "Draft" {
+		t.Errorf("The first change is %+v", changes[0])
+	}
+	if changes[1].VersionId != "4.0" || changes[1].Value != "Approved" || changes[1].LastModifiedBy.User.DisplayName != "Bob" {
+		t.Errorf("The second change is %+v", changes[1])
+	}
+}
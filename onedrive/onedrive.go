@@ -15,6 +15,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -36,19 +38,67 @@ type Client struct {
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
+	endpoints *Endpoints // See WithEndpoints.
+
+	defaultDriveMu sync.Mutex
+	defaultDrive   *Drive // Default drive of the authenticated user, once retrieved.
+
+	readOnly      bool              // Whether requests modifying a drive are refused, see WithReadOnly.
+	dryRunJournal *OperationJournal // Journal of the requests modifying a drive in dry-run mode, see WithDryRun.
+	rawJSON       bool              // Whether DriveItem.Raw is filled, see WithRawJSON.
+
+	strictDecoding  bool                       // Whether the responses are checked, see WithStrictDecoding.
+	onDecodingDrift func(*DecodingDrift) error // See WithStrictDecoding.
+
+	acceptLanguage string // Default Accept-Language header of the requests, see WithAcceptLanguage.
+
+	auditLog *auditLog // See WithAuditSink.
+
+	diagnostics    diagnosticsLog // See Diagnostics.
+	diagnosticsKey []byte         // Key signing the reports of Diagnostics, see WithDiagnosticsKey.
+
+	notFoundRetryWindow time.Duration // See WithNotFoundRetryAfterCreate.
+	createdItemsMu      sync.Mutex
+	createdItems        map[string]time.Time // Creation time of the items recently created by the client.
+
+	pathCacheMu sync.Mutex
+	pathCache   map[string]pathCacheEntry // IDs of the items by drive and path, see WithPathCache.
+
+	throttleMu     sync.Mutex
+	throttledUntil map[string]time.Time // End of the throttling of the drives, see WithThrottlingBackoff.
+
+	coalescingWindow time.Duration // See WithRequestCoalescing.
+	coalescingMu     sync.Mutex
+	coalesced        map[string]*coalescedRequest // Requests shared by identical requests by key.
+
+	thumbnailCache *ThumbnailCache // See WithThumbnailCache.
+
+	hasher Hasher // See WithHasher.
+
+	clock   Clock   // See WithClock.
+	sleeper Sleeper // See WithSleeper.
+
 	// Services used for talking to different parts of the OneDrive API.
 	Drives           *DrivesService
 	DriveItems       *DriveItemsService
 	DriveSearch      *DriveSearchService
 	DriveAsyncJob    *DriveAsyncJobService
 	DrivePermissions *PermissionService
+	Teams            *TeamsService
+	Sites            *SitesService
+	Insights         *InsightsService
+	Users            *UsersService
+	Subscriptions    *SubscriptionsService
+	Workbooks        *WorkbookService
 }
 
 // NewClient returns a new OneDrive API client. If a nil httpClient is
 // provided, a new http.Client will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
 // for you (such as that provided by the golang.org/x/oauth2 library).
-func NewClient(httpClient *http.Client) *Client {
+//
+// The behavior of the client can be customized further with options, such as WithReadOnly.
+func NewClient(httpClient *http.Client, opts ...ClientOption) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{}
 	}
@@ -63,6 +113,16 @@ func NewClient(httpClient *http.Client) *Client {
 	c.DriveSearch = (*DriveSearchService)(&c.common)
 	c.DriveAsyncJob = (*DriveAsyncJobService)(&c.common)
 	c.DrivePermissions = (*PermissionService)(&c.common)
+	c.Teams = (*TeamsService)(&c.common)
+	c.Sites = (*SitesService)(&c.common)
+	c.Insights = (*InsightsService)(&c.common)
+	c.Users = (*UsersService)(&c.common)
+	c.Subscriptions = (*SubscriptionsService)(&c.common)
+	c.Workbooks = (*WorkbookService)(&c.common)
+
+	for _, opt := range opts {
+		opt(c)
+	}
 
 	return c
 }
@@ -80,6 +140,11 @@ func (c *Client) NewRequest(method, relativeURL string, body interface{}) (*http
 		return nil, err
 	}
 
+	apiUrl, err = c.apiRequestURL(apiUrl)
+	if err != nil {
+		return nil, err
+	}
+
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 
@@ -171,53 +236,166 @@ func (c *Client) Do(ctx context.Context, req *http.Request, isUsingPlainHttpClie
 	}
 	req = req.WithContext(ctx)
 
-	var (
-		resp *http.Response
-		err  error
-	)
-	if isUsingPlainHttpClient {
-		httpClient := &http.Client{}
-		resp, err = httpClient.Do(req)
-	} else {
-		resp, err = c.client.Do(req)
+	if err := c.checkMutation(req); err != nil {
+		c.audit(ctx, req, c.now(), err)
+		return err
 	}
-	if err != nil {
-		return processHTTPError(ctx, err)
+
+	if c.dryRunJournal != nil && isMutation(req) {
+		if err := c.dryRunJournal.record(req); err != nil {
+			return err
+		}
+		if target == nil {
+			return nil
+		}
+		return json.Unmarshal([]byte("{}"), target)
 	}
-	defer resp.Body.Close()
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	start := c.now()
+	err := c.send(ctx, req, isUsingPlainHttpClient, target)
+	c.audit(ctx, req, start, err)
+	return err
+}
+
+// send sends an API request like Do, without checking whether the client is allowed to
+// send it.
+func (c *Client) send(ctx context.Context, req *http.Request, isUsingPlainHttpClient bool, target interface{}) error {
+	var err error
+	c.setAcceptLanguage(ctx, req)
+
+	var resp *receivedResponse
+	if key, ok := c.coalescingKey(req, isUsingPlainHttpClient); ok {
+		resp = c.coalesce(ctx, key, func() *receivedResponse {
+			return c.receive(ctx, req, isUsingPlainHttpClient)
+		})
+	} else {
+		resp = c.receive(ctx, req, isUsingPlainHttpClient)
+		if isMutation(req) {
+			c.forgetCoalesced()
+		}
+	}
+	if resp.err != nil {
+		return resp.err
 	}
+	responseBody := resp.body
 
-	locationHeader, isLocationHeaderExist := resp.Header["Location"]
+	locationHeader, isLocationHeaderExist := resp.header["Location"]
 
-	if resp.StatusCode == 202 && isLocationHeaderExist && len(responseBody) == 0 {
+	if resp.statusCode == 202 && isLocationHeaderExist && len(responseBody) == 0 {
 
 		var jsonStream = "{\"Location\": \"" + locationHeader[0] + "\"}"
 
 		err = json.NewDecoder(strings.NewReader(jsonStream)).Decode(target)
 
-	} else if resp.StatusCode != 204 {
+	} else if resp.statusCode != 204 && !(resp.statusCode < 300 && len(responseBody) == 0) {
 
-		responseBodyReader := bytes.NewReader(responseBody)
+		if err := responseError(resp.statusCode, responseBody); err != nil {
+			return err
+		}
+		if target == nil {
+			return nil
+		}
 
-		var oneDriveError *ErrorResponse
-		json.NewDecoder(responseBodyReader).Decode(&oneDriveError)
+		err = json.NewDecoder(bytes.NewReader(responseBody)).Decode(target)
 
-		if oneDriveError.Error != nil {
-			return oneDriveError.Error
+		if err == nil && c.rawJSON {
+			err = retainRawJSON(target, responseBody)
 		}
 
-		responseBodyReader = bytes.NewReader(responseBody)
-		err = json.NewDecoder(responseBodyReader).Decode(target)
+		if err == nil {
+			err = c.checkDecoding(req, target, responseBody)
+		}
 
 	}
 
 	return err
 }
 
+// responseError returns the error carried by the body of a response, if any. A response
+// with an error status and no error in its body gets an Error with the status text.
+func responseError(statusCode int, body []byte) error {
+	var oneDriveError *ErrorResponse
+	json.NewDecoder(bytes.NewReader(body)).Decode(&oneDriveError)
+
+	if oneDriveError != nil && oneDriveError.Error != nil {
+		oneDriveError.Error.StatusCode = statusCode
+		return oneDriveError.Error
+	}
+	if statusCode >= 400 {
+		return &Error{StatusCode: statusCode, Code: http.StatusText(statusCode)}
+	}
+
+	return nil
+}
+
+// receive sends an API request, once the drive it targets is no longer throttled, and reads
+// the response.
+func (c *Client) receive(ctx context.Context, req *http.Request, isUsingPlainHttpClient bool) *receivedResponse {
+	resp, err := c.open(ctx, req, isUsingPlainHttpClient)
+	if err != nil {
+		return &receivedResponse{err: err}
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &receivedResponse{err: err}
+	}
+
+	return &receivedResponse{statusCode: resp.StatusCode, header: resp.Header, body: responseBody}
+}
+
+// open sends an API request, once the drive it targets is no longer throttled, and returns
+// the response, recorded for Diagnostics and throttling, with its body left to be read.
+func (c *Client) open(ctx context.Context, req *http.Request, isUsingPlainHttpClient bool) (*http.Response, error) {
+	if err := c.waitThrottling(ctx, req); err != nil {
+		return nil, err
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	start := c.now()
+	if isUsingPlainHttpClient {
+		httpClient := &http.Client{}
+		resp, err = httpClient.Do(req)
+	} else {
+		resp, err = c.client.Do(req)
+	}
+	if err != nil {
+		err = processHTTPError(ctx, err)
+		c.recordDiagnostics(req, nil, start, err)
+		return nil, err
+	}
+
+	c.recordDiagnostics(req, resp, start, nil)
+
+	c.recordThrottling(req, resp)
+
+	return resp, nil
+}
+
+// checkMutation returns ErrReadOnlyClient if the request would modify a drive while the
+// client is read-only.
+func (c *Client) checkMutation(req *http.Request) error {
+	if c.readOnly && isMutation(req) {
+		return ErrReadOnlyClient
+	}
+
+	return nil
+}
+
+// isMutation reports whether the request may modify a drive.
+func isMutation(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return false
+	}
+
+	return true
+}
+
 func processHTTPError(ctx context.Context, err error) error {
 	// If we got an error, and the context has been canceled, the error from the context is probably more useful.
 	select {
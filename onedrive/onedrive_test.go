@@ -5,6 +5,7 @@
 package onedrive
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -83,3 +84,67 @@ func getTestDataFromFile(t *testing.T, fileName string) []byte {
 
 	return testData
 }
+
+func TestClient_WithReadOnly(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithReadOnly()(client)
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Request method %v reached the server of a read-only client", r.Method)
+		}
+
+		fmt.Fprint(w, `{"id": "1", "name": "Item"}`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.DriveItems.Get(ctx, "1"); err != nil {
+		t.Errorf("DriveItems.Get returned error: %v", err)
+	}
+
+	if err := client.DriveItems.Delete(ctx, "", "1"); err != ErrReadOnlyClient {
+		t.Errorf("DriveItems.Delete returned error %v, want %v", err, ErrReadOnlyClient)
+	}
+
+	if _, err := client.DriveItems.Rename(ctx, "", "1", "Renamed"); err != ErrReadOnlyClient {
+		t.Errorf("DriveItems.Rename returned error %v, want %v", err, ErrReadOnlyClient)
+	}
+}
+
+func TestClient_WithDryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	journal := &OperationJournal{}
+	WithDryRun(journal)(client)
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request %v %v reached the server of a dry-run client", r.Method, r.URL)
+	})
+
+	ctx := context.Background()
+	gotResponse, err := client.DriveItems.Rename(ctx, "", "1", "Renamed")
+	if err != nil {
+		t.Errorf("DriveItems.Rename returned error: %v", err)
+	}
+	if gotResponse == nil {
+		t.Errorf("DriveItems.Rename returned no response in dry-run mode")
+	}
+
+	operations := journal.Operations()
+	if len(operations) != 1 {
+		t.Fatalf("OperationJournal recorded %d operations, want 1", len(operations))
+	}
+
+	if got, want := operations[0].Method, "PATCH"; got != want {
+		t.Errorf("Operation method: %v, want %v", got, want)
+	}
+
+	if got, want := string(operations[0].Payload), `{"name":"Renamed"}`; got != want {
+		t.Errorf("Operation payload: %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// ClientOption customizes a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithReadOnly makes every request of the client which would modify a drive, such as
+// uploading, deleting, moving, renaming items or changing permissions, fail with
+// ErrReadOnlyClient without being sent. This is useful for verification or reporting
+// tools which must never modify a production drive.
+func WithReadOnly() ClientOption {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// WithDryRun makes every request of the client which would modify a drive be recorded
+// into journal instead of being sent. Such requests report success with an empty
+// response, so scripted migrations can be previewed before running them for real.
+func WithDryRun(journal *OperationJournal) ClientOption {
+	return func(c *Client) {
+		c.dryRunJournal = journal
+	}
+}
+
+// WithRawJSON makes the client retain the JSON object every DriveItem has been decoded
+// from into its Raw field, so the annotations and the properties which are not modeled
+// yet remain accessible.
+func WithRawJSON() ClientOption {
+	return func(c *Client) {
+		c.rawJSON = true
+	}
+}
+
+// WithAcceptLanguage sets the Accept-Language header of every request of the client, e.g.
+// "fr-FR", so that Error.LocalizedMessage is in the language of the user. It can be
+// overridden for a single request with ContextWithAcceptLanguage.
+func WithAcceptLanguage(language string) ClientOption {
+	return func(c *Client) {
+		c.acceptLanguage = language
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// ListOrderBy indicates the server-side sorting applied when listing the children of a folder.
+//
+// The children endpoint only supports ordering by name, size and last modified time,
+// so only those orderings are defined here.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/optional-query-parameters?view=odsp-graph-online#sorting-collections
+type ListOrderBy int
+
+const (
+	DefaultOrder ListOrderBy = iota
+	NameAsc
+	NameDesc
+	SizeAsc
+	SizeDesc
+	LastModifiedAsc
+	LastModifiedDesc
+)
+
+func (listOrderBy ListOrderBy) isValid() bool {
+	return listOrderBy >= DefaultOrder && listOrderBy <= LastModifiedDesc
+}
+
+func (listOrderBy ListOrderBy) toString() string {
+	return [...]string{"", "name asc", "name desc", "size asc", "size desc", "lastModifiedDateTime asc", "lastModifiedDateTime desc"}[listOrderBy]
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"strings"
+)
+
+// maxPathURLLength is the length above which a path-addressed relative URL is considered
+// too long to be accepted by OneDrive. Most servers reject URLs longer than 2048 characters;
+// the margin leaves room for the base URL, the action and the query.
+const maxPathURLLength = 1800
+
+// pathURL returns the relative URL of an item addressed by its path from the root of a
+// drive of the authenticated user, like rootPathURL. When that URL would be too long, e.g.
+// for deep trees or long unicode names, the leading folders of the path are resolved to
+// their IDs first, so the item is addressed relative to the deepest folder possible. The
+// IDs cached by WithPathCache are used, and the resolved ones are cached.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func (s *DriveItemsService) pathURL(ctx context.Context, driveId string, itemPath string) (string, error) {
+	segments := strings.Split(strings.Trim(itemPath, "/"), "/")
+
+	// Start from the deepest parent folder whose ID is cached, see WithPathCache.
+	folderId, resolved := "", 0
+	for i := len(segments) - 1; i > 0; i-- {
+		if itemId, ok := s.client.cachedPath(driveId, strings.Join(segments[:i], "/")); ok {
+			folderId, resolved = itemId, i
+			break
+		}
+	}
+
+	relativeURL := func(count int) string {
+		relativePath := strings.Join(segments[resolved:resolved+count], "/")
+		if folderId == "" {
+			return rootPathURL(driveId, relativePath)
+		}
+		return itemPathURL(driveId, folderId, relativePath)
+	}
+
+	for {
+		remaining := len(segments) - resolved
+		if apiURL := relativeURL(remaining); len(apiURL) <= maxPathURLLength || remaining == 1 {
+			return apiURL, nil
+		}
+
+		// Resolve the longest leading folders which fit, but at least one so that the
+		// resolution makes progress.
+		count := 1
+		for count < remaining-1 && len(relativeURL(count+1)) <= maxPathURLLength {
+			count++
+		}
+
+		req, err := s.client.NewRequest("GET", relativeURL(count)+"?$select=id,eTag", nil)
+		if err != nil {
+			return "", err
+		}
+
+		var folder *DriveItem
+		if err := s.client.Do(ctx, req, false, &folder); err != nil {
+			return "", err
+		}
+
+		folderId, resolved = folder.Id, resolved+count
+		s.client.cachePath(driveId, strings.Join(segments[:resolved], "/"), folderId, folder.ETag)
+	}
+}
+
+// doPath calls send with the relative URL of an item path, see pathURL. If send fails with
+// 404 Not Found while the URL is relative to a cached folder, e.g. because another client has
+// moved the folder, the cached IDs of the parent folders are forgotten and send is called once
+// more with the path resolved anew.
+func (s *DriveItemsService) doPath(ctx context.Context, driveId string, itemPath string, send func(apiURL string) error) error {
+	apiURL, err := s.pathURL(ctx, driveId, itemPath)
+	if err == nil {
+		err = send(apiURL)
+	}
+	if !isNotFound(err) || !s.client.forgetParentPaths(driveId, itemPath) {
+		return err
+	}
+
+	apiURL, err = s.pathURL(ctx, driveId, itemPath)
+	if err != nil {
+		return err
+	}
+
+	return send(apiURL)
+}
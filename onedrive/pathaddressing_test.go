@@ -0,0 +1,66 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDriveItemsService_GetByPath_longPath(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	// Every segment takes 600 characters once escaped.
+	segment := strings.Repeat("é", 100)
+	segments := []string{segment + "1", segment + "2", segment + "3", segment + "4", segment + "5"}
+
+	var gotPaths []string
+	resolved := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if len(r.URL.EscapedPath()) > maxPathURLLength {
+			t.Errorf("Requested URL is %d characters long, want at most %d", len(r.URL.EscapedPath()), maxPathURLLength)
+		}
+		gotPaths = append(gotPaths, r.URL.Path)
+
+		if r.URL.Query().Get("$select") == "id,eTag" {
+			resolved++
+			fmt.Fprintf(w, `{"id": "folder-%d"}`, resolved)
+			return
+		}
+
+		fmt.Fprint(w, `{"id": "item-1", "name": "Item"}`)
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveItems.GetByPath(ctx, strings.Join(segments, "/"))
+	if err != nil {
+		t.Fatalf("DriveItems.GetByPath returned error: %v", err)
+	}
+
+	if gotDriveItem.Id != "item-1" {
+		t.Errorf("DriveItems.GetByPath returned %+v, want %q", gotDriveItem, "item-1")
+	}
+
+	want := []string{
+		"/me/drive/root:/" + segments[0] + "/" + segments[1] + ":",
+		"/me/drive/items/folder-1:/" + segments[2] + "/" + segments[3] + ":",
+		"/me/drive/items/folder-2:/" + segments[4] + ":",
+	}
+	if len(gotPaths) != len(want) {
+		t.Fatalf("DriveItems.GetByPath sent %d requests, want %d", len(gotPaths), len(want))
+	}
+	for i := range want {
+		if gotPaths[i] != want[i] {
+			t.Errorf("Request %d path: %q, want %q", i, gotPaths[i], want[i])
+		}
+	}
+}
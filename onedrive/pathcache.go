@@ -0,0 +1,169 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// WithPathCache makes the client remember the IDs it resolves from item paths, so that
+// repeated path-addressed operations in the same tree, e.g. during large sync runs, do not
+// pay a resolution request each time.
+//
+// An entry is forgotten when the client deletes, moves or renames the item, or any of its
+// parent folders. The changes made by other clients are noticed through the eTag of the
+// item, which is kept with its ID: the delta pages listed by DeltaTracker drop the entries of
+// the items they report with another eTag, or as deleted. A path-addressed request relative
+// to a cached folder which fails with 404 Not Found is sent again once, after the path has
+// been resolved anew. ClearPathCache forgets everything, e.g. when no DeltaTracker is run.
+func WithPathCache() ClientOption {
+	return func(c *Client) {
+		c.pathCache = make(map[string]pathCacheEntry)
+	}
+}
+
+// pathCacheEntry represents an item path cached by WithPathCache.
+type pathCacheEntry struct {
+	itemId string
+	eTag   string // Empty if unknown, in which case any reported change drops the entry.
+}
+
+// ResolvePath returns the ID of an item addressed by its path from the root of a drive of
+// the authenticated user, e.g. "Reports/2024". The ID is cached if the client has been created
+// with WithPathCache.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
+func (s *DriveItemsService) ResolvePath(ctx context.Context, driveId string, itemPath string) (string, error) {
+	itemPath = strings.Trim(itemPath, "/")
+	if itemPath == "" {
+		return "", errors.New("Please provide the path of the item.")
+	}
+
+	if itemId, ok := s.client.cachedPath(driveId, itemPath); ok {
+		return itemId, nil
+	}
+
+	var driveItem *DriveItem
+	err := s.doPath(ctx, driveId, itemPath, func(apiURL string) error {
+		req, err := s.client.NewRequest("GET", apiURL+"?$select=id,eTag", nil)
+		if err != nil {
+			return err
+		}
+
+		return s.client.Do(ctx, req, false, &driveItem)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	s.client.cachePath(driveId, itemPath, driveItem.Id, driveItem.ETag)
+
+	return driveItem.Id, nil
+}
+
+// ClearPathCache forgets every ID cached by WithPathCache, e.g. after the tree has been
+// changed by another client.
+func (s *DriveItemsService) ClearPathCache() {
+	s.client.pathCacheMu.Lock()
+	defer s.client.pathCacheMu.Unlock()
+
+	if s.client.pathCache != nil {
+		s.client.pathCache = make(map[string]pathCacheEntry)
+	}
+}
+
+// pathCacheKey returns the key of an item path in the path cache. Paths are case-insensitive
+// on OneDrive.
+func pathCacheKey(driveId string, itemPath string) string {
+	return driveId + ":" + strings.ToLower(strings.Trim(itemPath, "/"))
+}
+
+// cachedPath returns the cached ID of an item path, if any.
+func (c *Client) cachedPath(driveId string, itemPath string) (string, bool) {
+	c.pathCacheMu.Lock()
+	defer c.pathCacheMu.Unlock()
+
+	entry, ok := c.pathCache[pathCacheKey(driveId, itemPath)]
+	return entry.itemId, ok
+}
+
+// cachePath remembers the ID and the eTag of an item path, when the path cache is enabled.
+func (c *Client) cachePath(driveId string, itemPath string, itemId string, eTag string) {
+	c.pathCacheMu.Lock()
+	defer c.pathCacheMu.Unlock()
+
+	if c.pathCache == nil || itemId == "" {
+		return
+	}
+
+	c.pathCache[pathCacheKey(driveId, itemPath)] = pathCacheEntry{itemId: itemId, eTag: eTag}
+}
+
+// forgetPath forgets the cached paths of an item and of everything under it, after the item
+// has been deleted, moved or renamed.
+func (c *Client) forgetPath(itemId string) {
+	c.pathCacheMu.Lock()
+	defer c.pathCacheMu.Unlock()
+
+	c.forgetPathsLocked(func(key string, entry pathCacheEntry) bool {
+		return entry.itemId == itemId
+	})
+}
+
+// checkPath forgets the cached paths of an item reported by a delta page, and of everything
+// under it, if the item has been deleted or has changed since its path was cached.
+func (c *Client) checkPath(item *DriveItem) {
+	c.pathCacheMu.Lock()
+	defer c.pathCacheMu.Unlock()
+
+	c.forgetPathsLocked(func(key string, entry pathCacheEntry) bool {
+		return entry.itemId == item.Id && (item.Deleted != nil || entry.eTag == "" || entry.eTag != item.ETag)
+	})
+}
+
+// forgetParentPaths forgets the cached IDs of the parent folders of an item path, and
+// everything under them, e.g. when a request relative to one of them has failed with 404 Not
+// Found. It returns whether any was cached.
+func (c *Client) forgetParentPaths(driveId string, itemPath string) bool {
+	c.pathCacheMu.Lock()
+	defer c.pathCacheMu.Unlock()
+
+	parents := make(map[string]bool)
+	segments := strings.Split(strings.Trim(itemPath, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		parents[pathCacheKey(driveId, strings.Join(segments[:i], "/"))] = true
+	}
+
+	return c.forgetPathsLocked(func(key string, entry pathCacheEntry) bool {
+		return parents[key]
+	})
+}
+
+// forgetPathsLocked forgets the cached paths matching forget, and every path under them. It
+// returns whether any matched. The caller must hold pathCacheMu.
+func (c *Client) forgetPathsLocked(forget func(key string, entry pathCacheEntry) bool) bool {
+	var prefixes []string
+	for key, entry := range c.pathCache {
+		if forget(key, entry) {
+			prefixes = append(prefixes, key)
+			delete(c.pathCache, key)
+		}
+	}
+
+	for _, prefix := range prefixes {
+		for key := range c.pathCache {
+			if strings.HasPrefix(key, prefix+"/") {
+				delete(c.pathCache, key)
+			}
+		}
+	}
+
+	return len(prefixes) > 0
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDriveItemsService_ResolvePath_withPathCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithPathCache()(client)
+
+	resolutions := 0
+	mux.HandleFunc("/me/drive/root:/Reports/2024:", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		resolutions++
+		fmt.Fprint(w, `{"id": "folder-2024"}`)
+	})
+
+	var gotChildPath string
+	mux.HandleFunc("/me/drive/items/folder-2024:/summary.pdf:", func(w http.ResponseWriter, r *http.Request) {
+		gotChildPath = r.URL.Path
+		fmt.Fprint(w, `{"id": "item-1", "name": "summary.pdf"}`)
+	})
+	mux.HandleFunc("/me/drive/items/folder-2024", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	for _, itemPath := range []string{"Reports/2024", "/reports/2024/"} {
+		gotId, err := client.DriveItems.ResolvePath(ctx, "", itemPath)
+		if err != nil {
+			t.Fatalf("DriveItems.ResolvePath returned error: %v", err)
+		}
+		if gotId != "folder-2024" {
+			t.Errorf("DriveItems.ResolvePath returned %q, want %q", gotId, "folder-2024")
+		}
+	}
+	if resolutions != 1 {
+		t.Errorf("DriveItems.ResolvePath sent %d requests, want 1", resolutions)
+	}
+
+	if _, err := client.DriveItems.GetByPath(ctx, "Reports/2024/summary.pdf"); err != nil {
+		t.Fatalf("DriveItems.GetByPath returned error: %v", err)
+	}
+	if gotChildPath == "" {
+		t.Errorf("DriveItems.GetByPath did not address the item relative to the cached folder")
+	}
+
+	if err := client.DriveItems.Delete(ctx, "", "folder-2024"); err != nil {
+		t.Fatalf("DriveItems.Delete returned error: %v", err)
+	}
+	if _, ok := client.cachedPath("", "Reports/2024/summary.pdf"); ok {
+		t.Errorf("Path of an item under a deleted folder is still cached")
+	}
+
+	client.DriveItems.ResolvePath(ctx, "", "Reports/2024")
+	if resolutions != 2 {
+		t.Errorf("DriveItems.ResolvePath sent %d requests after the folder was deleted, want 2", resolutions)
+	}
+}
+
+func TestDeltaTracker_Sync_withPathCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithPathCache()(client)
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	mux.HandleFunc("/me/drive/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"value": [
+			{"id": "unchanged", "eTag": "e1"},
+			{"id": "moved", "eTag": "e3"},
+			{"id": "deleted", "eTag": "e4", "deleted": {}}
+		], "@odata.deltaLink": "%sme/drive/root/delta?token=latest"}`, client.BaseURL)
+	})
+
+	client.cachePath("", "Photos", "unchanged", "e1")
+	client.cachePath("", "Reports", "moved", "e2")
+	client.cachePath("", "Reports/2024", "child", "e5")
+	client.cachePath("", "Old", "deleted", "e4")
+
+	tracker := NewDeltaTracker(client, "", &FileDeltaTokenStore{Path: filepath.Join(dir, "delta")})
+	if err := tracker.Sync(context.Background(), func(item *DriveItem) error { return nil }); err != nil {
+		t.Fatalf("DeltaTracker.Sync returned error: %v", err)
+	}
+
+	if _, ok := client.cachedPath("", "Photos"); !ok {
+		t.Errorf("Path of an item reported with the same eTag was forgotten")
+	}
+	for _, itemPath := range []string{"Reports", "Reports/2024", "Old"} {
+		if _, ok := client.cachedPath("", itemPath); ok {
+			t.Errorf("Path %q of a changed item is still cached", itemPath)
+		}
+	}
+}
+
+func TestDriveItemsService_GetByPath_staleCachedFolder(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithPathCache()(client)
+
+	// Another client has moved the cached folder away and created another one at its path.
+	client.cachePath("", "Reports", "moved-folder", "e1")
+	mux.HandleFunc("/me/drive/items/moved-folder:/summary.pdf:", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+	mux.HandleFunc("/me/drive/root:/Reports/summary.pdf:", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "item-1", "eTag": "e2", "name": "summary.pdf"}`)
+	})
+
+	item, err := client.DriveItems.GetByPath(context.Background(), "Reports/summary.pdf")
+	if err != nil {
+		t.Fatalf("DriveItems.GetByPath returned error: %v", err)
+	}
+	if item.Id != "item-1" {
+		t.Errorf("DriveItems.GetByPath returned %+v, want item-1", item)
+	}
+	if _, ok := client.cachedPath("", "Reports"); ok {
+		t.Errorf("Path of the moved folder is still cached")
+	}
+}
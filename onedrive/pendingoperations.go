@@ -0,0 +1,63 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// PendingOperations indicates that operations which may affect an item are in progress on
+// the server side, e.g. so that a sync client shows that the content of a file is still
+// being uploaded by another client. It is only returned when it is selected, see
+// GetPendingOperations.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/pendingoperations?view=odsp-graph-online
+type PendingOperations struct {
+	PendingContentUpdate *PendingContentUpdate `json:"pendingContentUpdate"`
+}
+
+// PendingContentUpdate indicates that an update of the content of an item is pending.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/pendingcontentupdate?view=odsp-graph-online
+type PendingContentUpdate struct {
+	// QueuedDateTime is when the update has been queued.
+	QueuedDateTime time.Time `json:"queuedDateTime"`
+}
+
+// HasPendingOperations reports whether operations are in progress for the drive item on the
+// server side, as far as its pendingOperations facet tells.
+func (item *DriveItem) HasPendingOperations() bool {
+	return item.PendingOperations != nil && item.PendingOperations.PendingContentUpdate != nil
+}
+
+// pendingOperationsSelect selects the properties of an item retrieved by
+// GetPendingOperations.
+const pendingOperationsSelect = "id,name,eTag,cTag,size,lastModifiedDateTime,pendingOperations"
+
+// GetPendingOperations retrieves an item of the default drive of the authenticated user
+// with its pendingOperations facet, which is not returned unless it is selected, along with
+// the properties telling whether its content has changed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/pendingoperations?view=odsp-graph-online
+func (s *DriveItemsService) GetPendingOperations(ctx context.Context, itemId string) (*DriveItem, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item.")
+	}
+
+	req, err := s.client.NewRequest("GET", "me/drive/items/"+url.PathEscape(itemId)+"?$select="+pendingOperationsSelect, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *DriveItem
+	if err := s.client.doItem(ctx, itemId, req, &item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
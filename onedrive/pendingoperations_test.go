@@ -0,0 +1,43 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_GetPendingOperations(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("$select"), pendingOperationsSelect; got != want {
+			t.Errorf("$select = %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"id":"1","name":"a.txt","pendingOperations":{"pendingContentUpdate":{"queuedDateTime":"2020-05-01T10:00:00Z"}}}`)
+	})
+
+	item, err := client.DriveItems.GetPendingOperations(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("DriveItems.GetPendingOperations returned error: %v", err)
+	}
+
+	if !item.HasPendingOperations() {
+		t.Fatal("DriveItem.HasPendingOperations returned false")
+	}
+	if want := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC); !item.PendingOperations.PendingContentUpdate.QueuedDateTime.Equal(want) {
+		t.Errorf("The update has been queued at %v, want %v", item.PendingOperations.PendingContentUpdate.QueuedDateTime, want)
+	}
+
+	if (&DriveItem{}).HasPendingOperations() {
+		t.Error("DriveItem.HasPendingOperations returned true for an item without the facet")
+	}
+}
@@ -14,10 +14,12 @@ type PermissionService service
 
 // Permission is the permission of a drive item.
 type Permission struct {
-	ID        string      `json:"id"`
-	GrantedTo interface{} `json:"grantedTo"`
-	Link      SharingLink `json:"link"`
-	Roles     []string    `json:"roles"`
+	ID            string             `json:"id"`
+	GrantedTo     interface{}        `json:"grantedTo"`
+	Link          SharingLink        `json:"link"`
+	Roles         []string           `json:"roles"`
+	Invitation    *SharingInvitation `json:"invitation"`
+	InheritedFrom *ParentReference   `json:"inheritedFrom"`
 }
 
 // CreateShareLinkRequest is the request for creating a share link.
@@ -34,6 +36,14 @@ type SharingLink struct {
 	URL   string `json:"webUrl"`
 }
 
+// SharingInvitation represents the invitation a permission has been granted by.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/sharinginvitation?view=odsp-graph-online
+type SharingInvitation struct {
+	Email          string `json:"email"`
+	SignInRequired bool   `json:"signInRequired"`
+}
+
 // CreateShareLink will create a new sharing link if the specified link type doesn't already exist for the calling application.
 // If a sharing link of the specified type already exists for the app, the existing sharing link will be returned.
 //
@@ -50,6 +60,9 @@ func (s *PermissionService) CreateShareLink(ctx context.Context, itemId string,
 	var oneDriveResponse *Permission
 	err = s.client.Do(ctx, req, false, &oneDriveResponse)
 	if err != nil {
+		if permissionScope == Organization {
+			return nil, s.client.Drives.checkPersonalSupport(ctx, err)
+		}
 		return nil, err
 	}
 
@@ -59,6 +72,13 @@ func (s *PermissionService) CreateShareLink(ctx context.Context, itemId string,
 // ListPermissionsResponse is the response of list permissions of a drive item
 type ListPermissionsResponse struct {
 	Value []Permission `json:"value"`
+	// NextLink is the URL of the next page of permissions, if any.
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// HasMore reports whether the permissions are followed by another page, see NextLink.
+func (response *ListPermissionsResponse) HasMore() bool {
+	return response.NextLink != ""
 }
 
 // List lists the effective sharing permissions of on a DriveItem.
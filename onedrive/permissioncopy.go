@@ -0,0 +1,199 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// InviteRequest is the request for granting access to a drive item to recipients.
+type InviteRequest struct {
+	Recipients     []InviteRecipient `json:"recipients"`
+	Roles          []string          `json:"roles"` // Either read or write.
+	RequireSignIn  bool              `json:"requireSignIn"`
+	SendInvitation bool              `json:"sendInvitation"`
+	Message        string            `json:"message,omitempty"`
+}
+
+// InviteRecipient represents a recipient of an invitation.
+type InviteRecipient struct {
+	Email string `json:"email"`
+}
+
+// Invite grants access to a drive item in the default drive of the authenticated user to
+// the recipients of the request, and returns the new permissions.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_invite?view=odsp-graph-online
+func (s *PermissionService) Invite(ctx context.Context, itemId string, request *InviteRequest) ([]Permission, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item to be shared.")
+	}
+
+	if request == nil || len(request.Recipients) == 0 || len(request.Roles) == 0 {
+		return nil, errors.New("Please provide the recipients and the roles of the invitation.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/invite"
+
+	req, err := s.client.NewRequest(http.MethodPost, apiURL, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *ListPermissionsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse.Value, nil
+}
+
+// CopyPermissionsOpts represents the options for replaying permissions by CopyPermissions.
+type CopyPermissionsOpts struct {
+	// SendInvitation makes OneDrive notify the recipients of the replayed invitations.
+	SendInvitation bool
+	// Message is included in the notifications, if any.
+	Message string
+}
+
+// CopyPermissionsResult represents the outcome of CopyPermissions.
+type CopyPermissionsResult struct {
+	// Created are the permissions created on the target item.
+	Created []Permission
+	// Skipped are the permissions of the source item which have not been replayed.
+	Skipped []SkippedPermission
+}
+
+// SkippedPermission represents a permission which CopyPermissions could not replay.
+type SkippedPermission struct {
+	Permission Permission
+	Reason     string
+}
+
+// CopyPermissions reads the sharing permissions of an item in the default drive of the
+// authenticated user and replays them on another item: the sharing links are created again
+// with the same type and scope, and the people who have been granted access are invited
+// with the same roles.
+//
+// Microsoft Graph does not allow every permission to be recreated, so the following ones
+// are reported as skipped: the inherited permissions, which the target gets from its own
+// parent folder, the owner, the links shared with specific people, the links whose scope is
+// not reported, which are never assumed to be anonymous, and the people whose email address
+// is not exposed by the permission. The new links have new URLs.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/permission?view=odsp-graph-online
+func (s *PermissionService) CopyPermissions(ctx context.Context, sourceItemId string, targetItemId string, opts CopyPermissionsOpts) (*CopyPermissionsResult, error) {
+	if sourceItemId == "" || targetItemId == "" {
+		return nil, errors.New("Please provide the Item IDs of the source and target items.")
+	}
+
+	permissions, err := s.List(ctx, sourceItemId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CopyPermissionsResult{}
+	skip := func(permission Permission, reason string) {
+		result.Skipped = append(result.Skipped, SkippedPermission{Permission: permission, Reason: reason})
+	}
+
+	for _, permission := range permissions {
+		switch {
+		case permission.InheritedFrom != nil:
+			skip(permission, "The permission is inherited.")
+		case hasRole(permission, "owner"):
+			skip(permission, "The owner cannot be changed.")
+		case permission.Link.Type != "":
+			linkType, ok := parseShareLinkType(permission.Link.Type)
+			if !ok {
+				skip(permission, "The link type "+permission.Link.Type+" is not supported.")
+				continue
+			}
+
+			// A link without scope, e.g. on OneDrive personal, is not assumed to be anonymous:
+			// a public link replayed on the target could widen the access to it.
+			if permission.Link.Scope == "" {
+				skip(permission, "The link scope is unknown.")
+				continue
+			}
+			linkScope, ok := parseShareLinkScope(permission.Link.Scope)
+			if !ok {
+				skip(permission, "The link scope "+permission.Link.Scope+" is not supported.")
+				continue
+			}
+
+			created, err := s.CreateShareLink(ctx, targetItemId, linkType, linkScope)
+			if err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, *created)
+		default:
+			email := grantedEmail(permission)
+			if email == "" {
+				skip(permission, "The email address of the grantee is unknown.")
+				continue
+			}
+
+			created, err := s.Invite(ctx, targetItemId, &InviteRequest{
+				Recipients:     []InviteRecipient{{Email: email}},
+				Roles:          permission.Roles,
+				RequireSignIn:  true,
+				SendInvitation: opts.SendInvitation,
+				Message:        opts.Message,
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, created...)
+		}
+	}
+
+	return result, nil
+}
+
+func hasRole(permission Permission, role string) bool {
+	for _, r := range permission.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func parseShareLinkType(value string) (ShareLinkType, bool) {
+	for _, linkType := range []ShareLinkType{View, Edit, Embed} {
+		if linkType.toString() == value {
+			return linkType, true
+		}
+	}
+	return View, false
+}
+
+func parseShareLinkScope(value string) (ShareLinkScope, bool) {
+	for _, linkScope := range []ShareLinkScope{Anonymous, Organization} {
+		if linkScope.toString() == value {
+			return linkScope, true
+		}
+	}
+	return Anonymous, false
+}
+
+// grantedEmail returns the email address of the grantee of a permission, from the
+// invitation or from the user the permission has been granted to.
+func grantedEmail(permission Permission) string {
+	if permission.Invitation != nil && permission.Invitation.Email != "" {
+		return permission.Invitation.Email
+	}
+
+	grantedTo, _ := permission.GrantedTo.(map[string]interface{})
+	user, _ := grantedTo["user"].(map[string]interface{})
+	email, _ := user["email"].(string)
+
+	return email
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPermissionService_CopyPermissions(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/source/permissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"value": [
+			{"id": "owner", "roles": ["owner"], "grantedTo": {"user": {"displayName": "Owner"}}},
+			{"id": "inherited", "roles": ["write"], "inheritedFrom": {"id": "parent"}},
+			{"id": "link", "roles": ["write"], "link": {"type": "edit", "scope": "organization"}},
+			{"id": "people-link", "roles": ["read"], "link": {"type": "view", "scope": "users"}},
+			{"id": "unscoped-link", "roles": ["read"], "link": {"type": "view"}},
+			{"id": "invited", "roles": ["read"], "invitation": {"email": "alex@contoso.com"}},
+			{"id": "user", "roles": ["write"], "grantedTo": {"user": {"displayName": "Sam", "email": "sam@contoso.com"}}},
+			{"id": "no-email", "roles": ["read"], "grantedTo": {"user": {"displayName": "Kim"}}}
+		]}`)
+	})
+	mux.HandleFunc("/me/drive/items/target/createLink", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var request CreateShareLinkRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		want := CreateShareLinkRequest{Type: "edit", Scope: "organization"}
+		if request != want {
+			t.Errorf("Request body = %+v, want %+v", request, want)
+		}
+
+		fmt.Fprint(w, `{"id": "new-link", "roles": ["write"], "link": {"type": "edit", "scope": "organization"}}`)
+	})
+	var invited []string
+	mux.HandleFunc("/me/drive/items/target/invite", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var request InviteRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.SendInvitation {
+			t.Errorf("Request sendInvitation = true, want false")
+		}
+		invited = append(invited, request.Recipients[0].Email+":"+request.Roles[0])
+
+		fmt.Fprintf(w, `{"value": [{"id": "new-%s", "roles": [%q]}]}`, request.Recipients[0].Email, request.Roles[0])
+	})
+
+	got, err := client.DrivePermissions.CopyPermissions(context.Background(), "source", "target", CopyPermissionsOpts{})
+	if err != nil {
+		t.Fatalf("DrivePermissions.CopyPermissions returned error: %v", err)
+	}
+
+	wantInvited := []string{"alex@contoso.com:read", "sam@contoso.com:write"}
+	if !reflect.DeepEqual(invited, wantInvited) {
+		t.Errorf("DrivePermissions.CopyPermissions invited %v, want %v", invited, wantInvited)
+	}
+
+	var gotCreated []string
+	for _, permission := range got.Created {
+		gotCreated = append(gotCreated, permission.ID)
+	}
+	wantCreated := []string{"new-link", "new-alex@contoso.com", "new-sam@contoso.com"}
+	if !reflect.DeepEqual(gotCreated, wantCreated) {
+		t.Errorf("DrivePermissions.CopyPermissions created %v, want %v", gotCreated, wantCreated)
+	}
+
+	var gotSkipped []string
+	for _, skipped := range got.Skipped {
+		gotSkipped = append(gotSkipped, skipped.Permission.ID)
+	}
+	wantSkipped := []string{"owner", "inherited", "people-link", "unscoped-link", "no-email"}
+	if !reflect.DeepEqual(gotSkipped, wantSkipped) {
+		t.Errorf("DrivePermissions.CopyPermissions skipped %v, want %v", gotSkipped, wantSkipped)
+	}
+}
+
+func TestPermissionService_Invite_noRecipient(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	_, err := client.DrivePermissions.Invite(context.Background(), "item", &InviteRequest{Roles: []string{"read"}})
+	if err == nil {
+		t.Errorf("DrivePermissions.Invite did not return an error without recipients")
+	}
+}
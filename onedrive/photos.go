@@ -0,0 +1,84 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// ListPhotosByDateRange lists the photos in the default drive of the authenticated user
+// taken from from, included, to to, excluded, according to their photo facet, e.g. to back
+// up a camera roll month by month. The photos are sorted by the time they were taken.
+//
+// The photos are first searched with a $filter on photo/takenDateTime. The drives which do
+// not support that filter are enumerated with a delta query instead, and the photos are
+// filtered on the client side; this is slow on large drives.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/photo?view=odsp-graph-online
+func (s *DriveItemsService) ListPhotosByDateRange(ctx context.Context, from time.Time, to time.Time) (*OneDriveDriveItemsResponse, error) {
+	if !from.Before(to) {
+		return nil, errors.New("Please provide a date range whose start is before its end.")
+	}
+
+	inRange := func(item *DriveItem) bool {
+		if item == nil || item.Deleted != nil || item.Photo == nil || item.Photo.TakenDateTime.IsZero() {
+			return false
+		}
+		takenDateTime := item.Photo.TakenDateTime
+		return !takenDateTime.Before(from) && takenDateTime.Before(to)
+	}
+
+	var photos []*DriveItem
+	filter := "photo/takenDateTime ge " + from.UTC().Format(time.RFC3339) + " and photo/takenDateTime lt " + to.UTC().Format(time.RFC3339)
+	response, err := s.searchPages(ctx, "me/drive/root/search(q='')?$filter="+url.PathEscape(filter), SearchOpts{})
+	switch {
+	case err == nil:
+		// The filter may be ignored rather than rejected, so the photos are checked anyway.
+		for _, item := range response.DriveItems {
+			if inRange(item) {
+				photos = append(photos, item)
+			}
+		}
+	case isStatus(err, http.StatusBadRequest) || isStatus(err, http.StatusNotImplemented):
+		link := ""
+		for {
+			page, err := s.Delta(ctx, "", link)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range page.DriveItems {
+				if inRange(item) {
+					photos = append(photos, item)
+				}
+			}
+
+			if !page.HasMore() {
+				break
+			}
+			link = page.NextLink
+		}
+	default:
+		return nil, err
+	}
+
+	sort.SliceStable(photos, func(i, j int) bool {
+		return photos[i].Photo.TakenDateTime.Before(photos[j].Photo.TakenDateTime)
+	})
+
+	return &OneDriveDriveItemsResponse{DriveItems: photos, Count: len(photos)}, nil
+}
+
+// isStatus reports whether err is an error returned by the API with the given HTTP
+// status code.
+func isStatus(err error, statusCode int) bool {
+	oneDriveError, ok := err.(*Error)
+	return ok && oneDriveError.StatusCode == statusCode
+}
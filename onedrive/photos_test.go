@@ -0,0 +1,97 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_ListPhotosByDateRange(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("$filter"), "photo/takenDateTime ge 2020-03-01T00:00:00Z and photo/takenDateTime lt 2020-04-01T00:00:00Z"; got != want {
+			t.Errorf("$filter = %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"value":[
+			{"id":"2","name":"b.jpg","photo":{"takenDateTime":"2020-03-20T10:00:00Z"}},
+			{"id":"1","name":"a.jpg","photo":{"takenDateTime":"2020-03-02T10:00:00Z"}},
+			{"id":"3","name":"c.jpg","photo":{"takenDateTime":"2020-04-01T00:00:00Z"}}
+		]}`)
+	})
+
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	photos, err := client.DriveItems.ListPhotosByDateRange(context.Background(), from, from.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("DriveItems.ListPhotosByDateRange returned error: %v", err)
+	}
+
+	var ids []string
+	for _, photo := range photos.DriveItems {
+		ids = append(ids, photo.Id)
+	}
+	if got, want := strings.Join(ids, ","), "1,2"; got != want || photos.Count != 2 {
+		t.Errorf("DriveItems.ListPhotosByDateRange returned %q (count %d), want %q", got, photos.Count, want)
+	}
+}
+
+func TestDriveItemsService_ListPhotosByDateRange_fallback(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='')", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"code":"invalidRequest","message":"Invalid filter clause"}}`)
+	})
+	mux.HandleFunc("/me/drive/root/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("token") == "" {
+			fmt.Fprintf(w, `{"value":[
+				{"id":"1","name":"a.jpg","photo":{"takenDateTime":"2020-03-20T10:00:00Z"}},
+				{"id":"2","name":"notes.txt","file":{}}
+			],"@odata.nextLink":"%s"}`, serverURL+baseURLPath+"/me/drive/root/delta?token=2")
+			return
+		}
+		fmt.Fprintf(w, `{"value":[
+			{"id":"3","name":"b.jpg","photo":{"takenDateTime":"2020-03-02T10:00:00Z"}},
+			{"id":"4","name":"d.jpg","deleted":{"state":"deleted"},"photo":{"takenDateTime":"2020-03-05T10:00:00Z"}},
+			{"id":"5","name":"e.jpg","photo":{"takenDateTime":"2020-02-29T10:00:00Z"}}
+		],"@odata.deltaLink":"%s"}`, serverURL+baseURLPath+"/me/drive/root/delta?token=3")
+	})
+
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	photos, err := client.DriveItems.ListPhotosByDateRange(context.Background(), from, from.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("DriveItems.ListPhotosByDateRange returned error: %v", err)
+	}
+
+	var ids []string
+	for _, photo := range photos.DriveItems {
+		ids = append(ids, photo.Id)
+	}
+	if got, want := strings.Join(ids, ","), "3,1"; got != want {
+		t.Errorf("DriveItems.ListPhotosByDateRange returned %q, want %q", got, want)
+	}
+}
+
+func TestDriveItemsService_ListPhotosByDateRange_invalidRange(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := client.DriveItems.ListPhotosByDateRange(context.Background(), from, from); err == nil {
+		t.Error("DriveItems.ListPhotosByDateRange returned no error for an empty range")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package preflight checks a local directory for the files and folders which cannot be
+// migrated to OneDrive as they are, before the migration starts:
+//
+//	issues, err := preflight.Scan("/srv/share", preflight.Options{RemotePath: "Migrated/share"})
+//
+// Every issue found is reported, so they can all be fixed at once instead of making the
+// migration fail one item at a time.
+package preflight
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxPathLength is the maximum length of the decoded path of an item in OneDrive and
+// SharePoint, from the root of the drive.
+//
+// OneDrive API docs: https://support.microsoft.com/en-us/office/restrictions-and-limitations-in-onedrive-and-sharepoint-64883a5d-228e-48f5-b3d2-eb39e07630fa
+const DefaultMaxPathLength = 400
+
+// IssueKind represents the kind of problem found on a local file or folder.
+type IssueKind string
+
+const (
+	// NameCollision is reported when names differing only by case are in the same folder.
+	// OneDrive is case-insensitive, so only one of them can be migrated.
+	NameCollision IssueKind = "nameCollision"
+	// InvalidCharacters is reported when a name contains characters OneDrive does not allow.
+	InvalidCharacters IssueKind = "invalidCharacters"
+	// InvalidName is reported when a name is reserved or otherwise not allowed by OneDrive.
+	InvalidName IssueKind = "invalidName"
+	// PathTooLong is reported when the path of an item in OneDrive would be too long.
+	PathTooLong IssueKind = "pathTooLong"
+	// EmptyFile is reported for the files of zero bytes, which some tools fail to upload
+	// and which are often left-overs.
+	EmptyFile IssueKind = "emptyFile"
+	// LockedFile is reported for the files and folders which cannot be read, e.g. because
+	// another process holds a lock on them or permissions deny access.
+	LockedFile IssueKind = "lockedFile"
+)
+
+// Issue represents a problem found on a local file or folder.
+type Issue struct {
+	// Path is the path of the file or folder, relative to the scanned directory and with
+	// slashes as separators.
+	Path   string
+	Kind   IssueKind
+	Detail string
+}
+
+// Options represents the options of a scan.
+type Options struct {
+	// RemotePath is the path of the destination folder from the root of the drive, e.g.
+	// "Migrated/share". It counts towards the length of the paths in OneDrive.
+	RemotePath string
+	// MaxPathLength is the maximum length of a path in OneDrive. By default, it is
+	// DefaultMaxPathLength.
+	MaxPathLength int
+	// SkipEmptyFiles makes the scan not report the files of zero bytes.
+	SkipEmptyFiles bool
+}
+
+// invalidCharacters are the characters OneDrive does not allow in names.
+const invalidCharacters = `"*:<>?/\|`
+
+// reservedNames are the names OneDrive does not allow, compared case-insensitively.
+var reservedNames = map[string]bool{
+	".lock": true, "con": true, "prn": true, "aux": true, "nul": true, "desktop.ini": true,
+	"com0": true, "com1": true, "com2": true, "com3": true, "com4": true,
+	"com5": true, "com6": true, "com7": true, "com8": true, "com9": true,
+	"lpt0": true, "lpt1": true, "lpt2": true, "lpt3": true, "lpt4": true,
+	"lpt5": true, "lpt6": true, "lpt7": true, "lpt8": true, "lpt9": true,
+}
+
+// Scan walks a local directory and reports the issues which would prevent its files and
+// folders from being migrated to OneDrive. An error is only returned when the directory
+// itself cannot be walked.
+func Scan(localDir string, opts Options) ([]Issue, error) {
+	if localDir == "" {
+		return nil, errors.New("Please provide the local directory to scan.")
+	}
+
+	maxPathLength := opts.MaxPathLength
+	if maxPathLength <= 0 {
+		maxPathLength = DefaultMaxPathLength
+	}
+	remotePath := strings.Trim(opts.RemotePath, "/")
+
+	var issues []Issue
+	namesByFolder := make(map[string]map[string]string)
+	err := filepath.Walk(localDir, func(localPath string, fileInfo os.FileInfo, err error) error {
+		if localPath == localDir {
+			return err
+		}
+
+		relativePath, relErr := filepath.Rel(localDir, localPath)
+		if relErr != nil {
+			return relErr
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		if err != nil {
+			issues = append(issues, Issue{Path: relativePath, Kind: LockedFile, Detail: err.Error()})
+			if fileInfo != nil && fileInfo.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		folder, name := path.Split(relativePath)
+		names := namesByFolder[folder]
+		if names == nil {
+			names = make(map[string]string)
+			namesByFolder[folder] = names
+		}
+		if other, ok := names[strings.ToLower(name)]; ok {
+			issues = append(issues, Issue{Path: relativePath, Kind: NameCollision, Detail: "Differs only by case from " + other + "."})
+		} else {
+			names[strings.ToLower(name)] = relativePath
+		}
+
+		issues = append(issues, checkName(relativePath, name)...)
+
+		fullPath := path.Join(remotePath, relativePath)
+		if length := utf8.RuneCountInString(fullPath); length > maxPathLength {
+			issues = append(issues, Issue{Path: relativePath, Kind: PathTooLong, Detail: "The path in OneDrive would be " + strconv.Itoa(length) + " characters long."})
+		}
+
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		if fileInfo.Size() == 0 && !opts.SkipEmptyFiles {
+			issues = append(issues, Issue{Path: relativePath, Kind: EmptyFile, Detail: "The file is empty."})
+		}
+
+		file, err := os.Open(localPath)
+		if err != nil {
+			issues = append(issues, Issue{Path: relativePath, Kind: LockedFile, Detail: err.Error()})
+			return nil
+		}
+		file.Close()
+
+		return nil
+	})
+
+	return issues, err
+}
+
+// checkName reports the issues of the name of a file or folder.
+func checkName(relativePath string, name string) []Issue {
+	var issues []Issue
+
+	if i := strings.IndexAny(name, invalidCharacters); i >= 0 {
+		issues = append(issues, Issue{Path: relativePath, Kind: InvalidCharacters, Detail: "The name contains " + string(name[i]) + "."})
+	}
+
+	lowerName := strings.ToLower(name)
+	switch {
+	case reservedNames[lowerName]:
+		issues = append(issues, Issue{Path: relativePath, Kind: InvalidName, Detail: "The name is reserved."})
+	case strings.HasPrefix(lowerName, "~$"):
+		issues = append(issues, Issue{Path: relativePath, Kind: InvalidName, Detail: "The name starts with ~$."})
+	case strings.Contains(lowerName, "_vti_"):
+		issues = append(issues, Issue{Path: relativePath, Kind: InvalidName, Detail: "The name contains _vti_."})
+	case strings.HasPrefix(name, " ") || strings.HasSuffix(name, " ") || strings.HasSuffix(name, "."):
+		issues = append(issues, Issue{Path: relativePath, Kind: InvalidName, Detail: "The name starts or ends with a space, or ends with a period."})
+	}
+
+	return issues
+}
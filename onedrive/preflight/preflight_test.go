@@ -0,0 +1,94 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package preflight
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	localDir, err := ioutil.TempDir("", "preflight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(localDir)
+
+	files := map[string]string{
+		"Report.txt":                      "content",
+		"report.TXT":                      "content",
+		"a:b.txt":                         "content",
+		"docs/con":                        "content",
+		"docs/~$draft.docx":               "content",
+		"docs/empty.txt":                  "",
+		"docs/trailing.":                  "content",
+		"docs/" + strings.Repeat("x", 30): "content",
+		"fine/photo.jpg":                  "content",
+	}
+	for relativePath, content := range files {
+		localPath := filepath.Join(localDir, filepath.FromSlash(relativePath))
+		if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(localPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issues, err := Scan(localDir, Options{RemotePath: "/Migrated/", MaxPathLength: 40})
+	if err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+
+	got := make(map[string]IssueKind)
+	for _, issue := range issues {
+		if kind, ok := got[issue.Path]; ok {
+			t.Errorf("Scan reported %s and %s for %s, want a single issue", kind, issue.Kind, issue.Path)
+		}
+		got[issue.Path] = issue.Kind
+	}
+	want := map[string]IssueKind{
+		"report.TXT":                      NameCollision,
+		"a:b.txt":                         InvalidCharacters,
+		"docs/con":                        InvalidName,
+		"docs/~$draft.docx":               InvalidName,
+		"docs/empty.txt":                  EmptyFile,
+		"docs/trailing.":                  InvalidName,
+		"docs/" + strings.Repeat("x", 30): PathTooLong,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan returned %v, want %v", got, want)
+	}
+}
+
+func TestScan_skipEmptyFiles(t *testing.T) {
+	localDir, err := ioutil.TempDir("", "preflight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(localDir)
+
+	if err := ioutil.WriteFile(filepath.Join(localDir, "empty.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := Scan(localDir, Options{SkipEmptyFiles: true})
+	if err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Scan returned %v, want no issue", issues)
+	}
+}
+
+func TestScan_missingDirectory(t *testing.T) {
+	if _, err := Scan(filepath.Join(os.TempDir(), "preflight-missing"), Options{}); !os.IsNotExist(err) {
+		t.Errorf("Scan returned error %v, want not exist", err)
+	}
+}
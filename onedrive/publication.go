@@ -0,0 +1,123 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// DriveItemPublication represents the publishing status of a drive item in a SharePoint
+// document library with versioning enabled. It is missing on OneDrive personal.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/publicationfacet?view=odsp-graph-online
+type DriveItemPublication struct {
+	// Level is "published" or "checkout".
+	Level     string `json:"level"`
+	VersionId string `json:"versionId"`
+}
+
+// IsPublished reports whether the current version of the drive item is published, i.e.
+// visible to the users who can only see major versions.
+func (item *DriveItem) IsPublished() bool {
+	return item.Publication != nil && item.Publication.Level == "published"
+}
+
+// Publish checks in a checked out item of a document library as a major version, which
+// makes it visible to the users who can only see published versions. In libraries with
+// content approval, the version is submitted for approval instead.
+//
+// The version is withdrawn with Unpublish.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/driveitem-checkin?view=graph-rest-1.0
+func (s *DriveItemsService) Publish(ctx context.Context, driveId string, itemId string, comment string) error {
+	if itemId == "" {
+		return errors.New("Please provide the Item ID of the item to be published.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/checkin"
+	if driveId != "" {
+		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId) + "/checkin"
+	}
+
+	checkIn := struct {
+		CheckInAs string `json:"checkInAs"`
+		Comment   string `json:"comment,omitempty"`
+	}{
+		CheckInAs: "published",
+		Comment:   comment,
+	}
+
+	req, err := s.client.NewRequest("POST", apiURL, checkIn)
+	if err != nil {
+		return err
+	}
+
+	return s.client.doItem(ctx, itemId, req, nil)
+}
+
+// Unpublish withdraws the published major version of an item of a document library, which
+// turns it back into a minor version, hidden from the users who can only see published
+// versions, e.g. version 2.0 becomes 1.1.
+//
+// Microsoft Graph has no action to unpublish a version, so the item is unpublished through
+// the REST API of the SharePoint site holding it, with the HTTP client of the client: its
+// credentials must be accepted by SharePoint too, e.g. a token issued for the site rather
+// than for Microsoft Graph only.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// SharePoint API docs: https://docs.microsoft.com/en-us/sharepoint/dev/sp-add-ins/working-with-folders-and-files-with-rest
+func (s *DriveItemsService) Unpublish(ctx context.Context, driveId string, itemId string, comment string) error {
+	if itemId == "" {
+		return errors.New("Please provide the Item ID of the item to be unpublished.")
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "?$select=sharepointIds"
+	if driveId != "" {
+		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId) + "?$select=sharepointIds"
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return err
+	}
+
+	var item struct {
+		SharepointIds *struct {
+			ListItemUniqueId string `json:"listItemUniqueId"`
+			SiteUrl          string `json:"siteUrl"`
+		} `json:"sharepointIds"`
+	}
+	if err := s.client.doItem(ctx, itemId, req, &item); err != nil {
+		return err
+	}
+
+	ids := item.SharepointIds
+	if ids == nil || ids.ListItemUniqueId == "" || ids.SiteUrl == "" {
+		return errors.New("Please provide an item of a SharePoint document library.")
+	}
+
+	// The arguments of SharePoint REST methods are OData string literals, whose quotes are doubled.
+	quote := func(value string) string {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	unpublishURL := strings.TrimSuffix(ids.SiteUrl, "/") + "/_api/web/GetFileById(" + quote(ids.ListItemUniqueId) + ")/UnPublish(" + url.PathEscape(quote(comment)) + ")"
+
+	req, err = s.client.NewRequest("POST", unpublishURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json;odata=nometadata")
+
+	var response interface{}
+	return s.client.Do(ctx, req, false, &response)
+}
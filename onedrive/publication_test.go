@@ -0,0 +1,89 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_Publish(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/library-1/items/item-1/checkin", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Cannot decode the request body: %v", err)
+		}
+
+		if body["checkInAs"] != "published" || body["comment"] != "Final" {
+			t.Errorf("Request body: %v, want a published check-in with comment", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/me/drive/items/item-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "item-1", "publication": {"level": "published", "versionId": "2.0"}}`)
+	})
+
+	ctx := context.Background()
+	if err := client.DriveItems.Publish(ctx, "library-1", "item-1", "Final"); err != nil {
+		t.Fatalf("DriveItems.Publish returned error: %v", err)
+	}
+
+	gotDriveItem, err := client.DriveItems.Get(ctx, "item-1")
+	if err != nil {
+		t.Fatalf("DriveItems.Get returned error: %v", err)
+	}
+
+	if !gotDriveItem.IsPublished() || gotDriveItem.Publication.VersionId != "2.0" {
+		t.Errorf("DriveItem publication: %+v, want published version 2.0", gotDriveItem.Publication)
+	}
+}
+
+func TestDriveItemsService_Unpublish(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drives/library-1/items/item-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"sharepointIds": {"listItemUniqueId": "5f1c", "siteUrl": "%s%s/sites/team"}}`, serverURL, baseURLPath)
+	})
+	unpublished := false
+	mux.HandleFunc("/sites/team/_api/web/GetFileById('5f1c')/UnPublish('Author''s mistake')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		unpublished = true
+		fmt.Fprint(w, `{"odata.null": true}`)
+	})
+
+	if err := client.DriveItems.Unpublish(context.Background(), "library-1", "item-1", "Author's mistake"); err != nil {
+		t.Fatalf("DriveItems.Unpublish returned error: %v", err)
+	}
+	if !unpublished {
+		t.Errorf("DriveItems.Unpublish did not unpublish the file")
+	}
+}
+
+func TestDriveItemsService_Unpublish_notInLibrary(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/item-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	if err := client.DriveItems.Unpublish(context.Background(), "", "item-1", ""); err == nil {
+		t.Errorf("DriveItems.Unpublish returned no error for an item outside of a document library")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The relationships of a drive item which can be expanded, see QueryOptions.Expand.
+const (
+	ExpandChildren   = "children"
+	ExpandThumbnails = "thumbnails"
+)
+
+// QueryOptions represents the OData query options of the requests reading items, so the
+// responses can be trimmed and sorted on the server side. The zero value of an option leaves
+// it out of the request.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/query-parameters
+type QueryOptions struct {
+	// Select lists the properties returned for every item, e.g. "id" and "name".
+	Select []string
+	// Expand lists the relationships returned along with every item, e.g. ExpandChildren.
+	Expand []string
+	// Top is the maximum number of items per page.
+	Top int
+	// OrderBy sorts the items, e.g. "name desc".
+	OrderBy string
+	// Filter keeps the items matching an OData expression, e.g. "file ne null". Not every
+	// filter is supported by every kind of drive.
+	Filter string
+	// Count asks for the total number of items, see OneDriveDriveItemsResponse.Count.
+	Count bool
+}
+
+// encode returns the query string of the options, with its leading "?", or an empty string
+// when no option is set.
+func (opts QueryOptions) encode() (string, error) {
+	if opts.Top < 0 {
+		return "", errors.New("Please provide a top which is not negative.")
+	}
+
+	var query []string
+	if len(opts.Select) > 0 {
+		query = append(query, "$select="+escapeList(opts.Select))
+	}
+	if len(opts.Expand) > 0 {
+		query = append(query, "$expand="+escapeList(opts.Expand))
+	}
+	if opts.Top > 0 {
+		query = append(query, "$top="+strconv.Itoa(opts.Top))
+	}
+	if opts.OrderBy != "" {
+		query = append(query, "$orderby="+url.PathEscape(opts.OrderBy))
+	}
+	if opts.Filter != "" {
+		query = append(query, "$filter="+url.PathEscape(opts.Filter))
+	}
+	if opts.Count {
+		query = append(query, "$count=true")
+	}
+
+	if len(query) == 0 {
+		return "", nil
+	}
+	return "?" + strings.Join(query, "&"), nil
+}
+
+// escapeList escapes the values of a comma-separated query option one by one.
+func escapeList(values []string) string {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = url.PathEscape(value)
+	}
+	return strings.Join(escaped, ",")
+}
+
+// GetWithQuery gets an item in the default drive of the authenticated user with OData query
+// options.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
+func (s *DriveItemsService) GetWithQuery(ctx context.Context, itemId string, opts QueryOptions) (*DriveItem, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item.")
+	}
+
+	query, err := opts.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", "me/drive/items/"+url.PathEscape(itemId)+query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.doItem(ctx, itemId, req, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
+// GetByPathWithQuery gets an item in the default drive of the authenticated user by its path
+// with OData query options, like GetByPath.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
+func (s *DriveItemsService) GetByPathWithQuery(ctx context.Context, itemPath string, opts QueryOptions) (*DriveItem, error) {
+	if itemPath == "" {
+		return nil, errors.New("Please provide the path of the item.")
+	}
+
+	query, err := opts.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.doPath(ctx, "", itemPath, func(apiURL string) error {
+		req, err := s.client.NewRequest("GET", apiURL+query, nil)
+		if err != nil {
+			return err
+		}
+
+		return s.client.Do(ctx, req, false, &driveItem)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The ID is not returned when it has not been selected, nor the eTag.
+	if driveItem.Id != "" {
+		s.client.cachePath("", itemPath, driveItem.Id, driveItem.ETag)
+	}
+
+	return driveItem, nil
+}
+
+// ListWithQuery lists a page of the items of a folder in the default drive of the
+// authenticated user with OData query options. The next pages are listed by ListPage with
+// the NextLink of the response.
+//
+// If folderId is empty, it means the items at the root of the default drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ListWithQuery(ctx context.Context, folderId string, opts QueryOptions) (*OneDriveDriveItemsResponse, error) {
+	query, err := opts.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(folderId) + "/children"
+	if folderId == "" {
+		apiURL = "me/drive/root/children"
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL+query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// SearchWithQuery searches the items in the default drive of the authenticated user with
+// OData query options, going through all the pages of results like Search. Top is the size
+// of the pages.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *DriveItemsService) SearchWithQuery(ctx context.Context, query string, opts QueryOptions) (*OneDriveDriveItemsResponse, error) {
+	queryString, err := opts.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL, err := searchURL("me/drive/root", query, SearchOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.searchPages(ctx, apiURL+queryString, SearchOpts{})
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestQueryOptions_encode(t *testing.T) {
+	tests := []struct {
+		opts QueryOptions
+		want string
+	}{
+		{QueryOptions{}, ""},
+		{QueryOptions{Select: []string{"id", "name"}, Top: 10}, "?$select=id,name&$top=10"},
+		{QueryOptions{Expand: []string{"children"}, OrderBy: "name desc", Filter: "file ne null", Count: true},
+			"?$expand=children&$orderby=name%20desc&$filter=file%20ne%20null&$count=true"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.opts.encode()
+		if err != nil {
+			t.Fatalf("encode of %+v returned error: %v", tt.opts, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("encode of %+v returned %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+
+	if _, err := (QueryOptions{Top: -1}).encode(); err == nil {
+		t.Error("encode of a negative top returned no error")
+	}
+}
+
+func TestDriveItemsService_GetWithQuery(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/item-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.Query().Get("$select"), "id,name"; got != want {
+			t.Errorf("Request query $select is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"id": "item-1", "name": "a.txt"}`)
+	})
+
+	got, err := client.DriveItems.GetWithQuery(context.Background(), "item-1", QueryOptions{Select: []string{"id", "name"}})
+	if err != nil {
+		t.Fatalf("DriveItems.GetWithQuery returned error: %v", err)
+	}
+
+	if got.Id != "item-1" || got.Name != "a.txt" {
+		t.Errorf("DriveItems.GetWithQuery returned %+v", got)
+	}
+}
+
+func TestDriveItemsService_GetByPathWithQuery(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root:/Documents/a.txt:", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.Query().Get("$select"), "name"; got != want {
+			t.Errorf("Request query $select is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"name": "a.txt"}`)
+	})
+
+	got, err := client.DriveItems.GetByPathWithQuery(context.Background(), "Documents/a.txt", QueryOptions{Select: []string{"name"}})
+	if err != nil {
+		t.Fatalf("DriveItems.GetByPathWithQuery returned error: %v", err)
+	}
+
+	if got.Name != "a.txt" {
+		t.Errorf("DriveItems.GetByPathWithQuery returned %+v", got)
+	}
+}
+
+func TestDriveItemsService_ListWithQuery(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		query := r.URL.Query()
+		if query.Get("$orderby") != "size desc" || query.Get("$filter") != "folder ne null" || query.Get("$count") != "true" {
+			t.Errorf("Request query is %v", query)
+		}
+
+		fmt.Fprint(w, `{"@odata.count": 1, "value": [{"id": "1", "folder": {}}]}`)
+	})
+
+	got, err := client.DriveItems.ListWithQuery(context.Background(), "", QueryOptions{OrderBy: "size desc", Filter: "folder ne null", Count: true})
+	if err != nil {
+		t.Fatalf("DriveItems.ListWithQuery returned error: %v", err)
+	}
+
+	if got.Count != 1 || len(got.DriveItems) != 1 {
+		t.Errorf("DriveItems.ListWithQuery returned %+v", got)
+	}
+}
+
+func TestDriveItemsService_SearchWithQuery(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='report')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.Query().Get("$top"), "5"; got != want {
+			t.Errorf("Request query $top is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"value": [{"id": "1"}]}`)
+	})
+
+	got, err := client.DriveItems.SearchWithQuery(context.Background(), "report", QueryOptions{Top: 5})
+	if err != nil {
+		t.Fatalf("DriveItems.SearchWithQuery returned error: %v", err)
+	}
+
+	if len(got.DriveItems) != 1 {
+		t.Errorf("DriveItems.SearchWithQuery returned %+v", got)
+	}
+}
+
+func TestDriveItemsService_GetWithQuery_expand(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.Query().Get("$expand"), "children,thumbnails"; got != want {
+			t.Errorf("Request query $expand is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"id": "folder", "folder": {"childCount": 201},
+			"children": [{"id": "child-1", "name": "a.jpg"}],
+			"children@odata.nextLink": "https://graph.microsoft.com/v1.0/me/drive/items/folder/children?$skiptoken=abc",
+			"thumbnails": [{"id": "0", "small": {"width": 96, "height": 96, "url": "https://example.com/small"}}]}`)
+	})
+
+	got, err := client.DriveItems.GetWithQuery(context.Background(), "folder", QueryOptions{Expand: []string{ExpandChildren, ExpandThumbnails}})
+	if err != nil {
+		t.Fatalf("DriveItems.GetWithQuery returned error: %v", err)
+	}
+
+	if len(got.Children) != 1 || got.Children[0].Id != "child-1" || got.ChildrenNextLink == "" {
+		t.Errorf("DriveItems.GetWithQuery returned the children %+v and the next link %q", got.Children, got.ChildrenNextLink)
+	}
+	if len(got.Thumbnails) != 1 || got.Thumbnails[0].Small == nil || got.Thumbnails[0].Small.Width != 96 || got.Thumbnails[0].Medium != nil {
+		t.Errorf("DriveItems.GetWithQuery returned the thumbnails %+v", got.Thumbnails)
+	}
+}
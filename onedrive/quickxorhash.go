@@ -0,0 +1,134 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+)
+
+const (
+	quickXorWidthInBits = 160
+	quickXorShift       = 11
+	quickXorSize        = (quickXorWidthInBits-1)/8 + 1
+	quickXorCells       = (quickXorWidthInBits-1)/64 + 1
+)
+
+// quickXorHash implements the QuickXorHash algorithm used by OneDrive for Business and
+// OneDrive personal to expose file hashes.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/code-snippets/quickxorhash?view=odsp-graph-online
+type quickXorHash struct {
+	data        [quickXorCells]uint64
+	shiftSoFar  int
+	lengthSoFar int64
+}
+
+// NewQuickXorHash returns a new hash.Hash computing the QuickXorHash checksum. The
+// checksum is reported by OneDrive in base64 encoding, see QuickXorHashFile.
+func NewQuickXorHash() hash.Hash {
+	return &quickXorHash{}
+}
+
+func (h *quickXorHash) Write(p []byte) (int, error) {
+	currentShift := h.shiftSoFar
+	vectorArrayIndex := currentShift / 64
+	vectorOffset := currentShift % 64
+
+	iterations := len(p)
+	if iterations > quickXorWidthInBits {
+		iterations = quickXorWidthInBits
+	}
+
+	for i := 0; i < iterations; i++ {
+		isLastCell := vectorArrayIndex == quickXorCells-1
+		bitsInVectorCell := 64
+		if isLastCell {
+			bitsInVectorCell = quickXorWidthInBits % 64
+		}
+
+		if vectorOffset <= bitsInVectorCell-8 {
+			for j := i; j < len(p); j += quickXorWidthInBits {
+				h.data[vectorArrayIndex] ^= uint64(p[j]) << uint(vectorOffset)
+			}
+		} else {
+			index1 := vectorArrayIndex
+			index2 := vectorArrayIndex + 1
+			if isLastCell {
+				index2 = 0
+			}
+			low := uint(bitsInVectorCell - vectorOffset)
+
+			var xoredByte byte
+			for j := i; j < len(p); j += quickXorWidthInBits {
+				xoredByte ^= p[j]
+			}
+			h.data[index1] ^= uint64(xoredByte) << uint(vectorOffset)
+			h.data[index2] ^= uint64(xoredByte) >> low
+		}
+
+		vectorOffset += quickXorShift
+		for vectorOffset >= bitsInVectorCell {
+			if isLastCell {
+				vectorArrayIndex = 0
+			} else {
+				vectorArrayIndex++
+			}
+			vectorOffset -= bitsInVectorCell
+		}
+	}
+
+	h.shiftSoFar = (h.shiftSoFar + quickXorShift*(len(p)%quickXorWidthInBits)) % quickXorWidthInBits
+	h.lengthSoFar += int64(len(p))
+
+	return len(p), nil
+}
+
+func (h *quickXorHash) Sum(b []byte) []byte {
+	var rgb [quickXorSize + 4]byte
+	for i := 0; i < quickXorCells; i++ {
+		binary.LittleEndian.PutUint64(rgb[i*8:], h.data[i])
+	}
+
+	var lengthBytes [8]byte
+	binary.LittleEndian.PutUint64(lengthBytes[:], uint64(h.lengthSoFar))
+	for i := 0; i < 8; i++ {
+		rgb[quickXorWidthInBits/8-8+i] ^= lengthBytes[i]
+	}
+
+	return append(b, rgb[:quickXorSize]...)
+}
+
+func (h *quickXorHash) Reset() {
+	*h = quickXorHash{}
+}
+
+func (h *quickXorHash) Size() int {
+	return quickXorSize
+}
+
+func (h *quickXorHash) BlockSize() int {
+	return 64
+}
+
+// QuickXorHashFile computes the QuickXorHash of a local file, encoded in base64 the same
+// way as DriveItemHashes.QuickXorHash.
+func QuickXorHashFile(localFilePath string) (string, error) {
+	file, err := os.Open(localFilePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := NewQuickXorHash()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
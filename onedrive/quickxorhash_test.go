@@ -0,0 +1,75 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+// naiveQuickXorHash xors every byte into a 160-bit circular buffer at bit position
+// (index * 11) mod 160, which is the definition the optimized implementation follows.
+func naiveQuickXorHash(data []byte) []byte {
+	var bits [quickXorWidthInBits]byte
+	for i, b := range data {
+		start := (i * quickXorShift) % quickXorWidthInBits
+		for bit := 0; bit < 8; bit++ {
+			bits[(start+bit)%quickXorWidthInBits] ^= (b >> uint(bit)) & 1
+		}
+	}
+
+	result := make([]byte, quickXorSize)
+	for i, bit := range bits {
+		result[i/8] |= bit << uint(i%8)
+	}
+
+	var lengthBytes [8]byte
+	binary.LittleEndian.PutUint64(lengthBytes[:], uint64(len(data)))
+	for i := 0; i < 8; i++ {
+		result[quickXorSize-8+i] ^= lengthBytes[i]
+	}
+	return result
+}
+
+func TestQuickXorHash(t *testing.T) {
+	h := NewQuickXorHash()
+	if got, want := base64.StdEncoding.EncodeToString(h.Sum(nil)), "AAAAAAAAAAAAAAAAAAAAAAAAAAA="; got != want {
+		t.Errorf("QuickXorHash of empty input returned %q, want %q", got, want)
+	}
+
+	h.Write([]byte("J"))
+	if got, want := base64.StdEncoding.EncodeToString(h.Sum(nil)), "SgAAAAAAAAAAAAAAAQAAAAAAAAA="; got != want {
+		t.Errorf("QuickXorHash of %q returned %q, want %q", "J", got, want)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for _, size := range []int{1, 20, 159, 160, 161, 1000, 100000} {
+		data := make([]byte, size)
+		random.Read(data)
+
+		want := naiveQuickXorHash(data)
+
+		h.Reset()
+		h.Write(data)
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("QuickXorHash of %d bytes returned %x, want %x", size, got, want)
+		}
+
+		h.Reset()
+		for offset := 0; offset < size; offset += 7 {
+			end := offset + 7
+			if end > size {
+				end = size
+			}
+			h.Write(data[offset:end])
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("QuickXorHash of %d bytes in small writes returned %x, want %x", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// rawJSONRetainer is implemented by the responses which retain the JSON they have been
+// decoded from, see WithRawJSON.
+type rawJSONRetainer interface {
+	retainRawJSON(data []byte) error
+}
+
+// retainRawJSON gives data, the JSON target has been decoded from, to target if it retains
+// it. target may be a pointer to a pointer, as usually given to Client.Do.
+func retainRawJSON(target interface{}, data []byte) error {
+	v := reflect.ValueOf(target)
+	for v.IsValid() && v.Kind() == reflect.Ptr && !v.IsNil() {
+		if retainer, ok := v.Interface().(rawJSONRetainer); ok {
+			return retainer.retainRawJSON(data)
+		}
+		v = v.Elem()
+	}
+
+	return nil
+}
+
+func (item *DriveItem) retainRawJSON(data []byte) error {
+	item.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (response *OneDriveDriveItemsResponse) retainRawJSON(data []byte) error {
+	var rawResponse struct {
+		DriveItems []json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &rawResponse); err != nil {
+		return err
+	}
+
+	for i, item := range response.DriveItems {
+		if item != nil && i < len(rawResponse.DriveItems) {
+			item.Raw = rawResponse.DriveItems[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_WithRawJSON(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithRawJSON()(client)
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "1", "name": "Item", "@microsoft.graph.conflictBehavior": "rename"}`)
+	})
+	mux.HandleFunc("/me/drive/items/1/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "2", "malware": {"description": "Virus"}}]}`)
+	})
+
+	ctx := context.Background()
+	gotItem, err := client.DriveItems.Get(ctx, "1")
+	if err != nil {
+		t.Fatalf("DriveItems.Get returned error: %v", err)
+	}
+
+	var annotations map[string]interface{}
+	if err := json.Unmarshal(gotItem.Raw, &annotations); err != nil {
+		t.Fatalf("Cannot decode the raw JSON of the item: %v", err)
+	}
+	if got, want := annotations["@microsoft.graph.conflictBehavior"], "rename"; got != want {
+		t.Errorf("Raw annotation: %v, want %v", got, want)
+	}
+
+	gotResponse, err := client.DriveItems.List(ctx, "1")
+	if err != nil {
+		t.Fatalf("DriveItems.List returned error: %v", err)
+	}
+	if got, want := string(gotResponse.DriveItems[0].Raw), `{"id": "2", "malware": {"description": "Virus"}}`; got != want {
+		t.Errorf("Raw JSON of the listed item: %v, want %v", got, want)
+	}
+
+	err = client.DriveItems.ListFunc(ctx, "1", ListOpts{}, func(item *DriveItem) error {
+		if len(item.Raw) == 0 {
+			t.Errorf("Raw JSON of the streamed item is empty")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("DriveItems.ListFunc returned error: %v", err)
+	}
+}
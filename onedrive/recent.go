@@ -0,0 +1,29 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+)
+
+// ListRecent lists the items recently used by the authenticated user, most recent first.
+// The items stored in other drives, e.g. files shared with the user, have their RemoteItem
+// filled with the information needed to reach them.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_recent?view=odsp-graph-online
+func (s *DriveItemsService) ListRecent(ctx context.Context) (*OneDriveDriveItemsResponse, error) {
+	req, err := s.client.NewRequest("GET", "me/drive/recent", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
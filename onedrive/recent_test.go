@@ -0,0 +1,48 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_ListRecent(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/recent", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"value": [
+			{"id": "1", "name": "mine.docx", "file": {}},
+			{"id": "2", "name": "theirs.xlsx", "remoteItem": {
+				"id": "remote-2",
+				"name": "theirs.xlsx",
+				"size": 1024,
+				"file": {"mimeType": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+				"parentReference": {"driveId": "other-drive", "id": "other-folder"}
+			}}
+		]}`)
+	})
+
+	got, err := client.DriveItems.ListRecent(context.Background())
+	if err != nil {
+		t.Fatalf("DriveItems.ListRecent returned error: %v", err)
+	}
+
+	if len(got.DriveItems) != 2 || got.DriveItems[0].RemoteItem != nil {
+		t.Fatalf("DriveItems.ListRecent returned %+v, want a local and a remote item", got.DriveItems)
+	}
+
+	remoteItem := got.DriveItems[1].RemoteItem
+	if remoteItem == nil || remoteItem.Id != "remote-2" || remoteItem.Size != 1024 || remoteItem.File == nil ||
+		remoteItem.ParentReference == nil || remoteItem.ParentReference.DriveId != "other-drive" {
+		t.Errorf("DriveItems.ListRecent returned the remote item %+v", remoteItem)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// RecycleBinItem represents an item in the recycle bin of a SharePoint site, which includes
+// the OneDrive for Business of a user.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/recyclebinitem?view=graph-rest-1.0
+type RecycleBinItem struct {
+	Id                  string    `json:"id"`
+	Name                string    `json:"name"`
+	Size                int64     `json:"size"`
+	DeletedDateTime     time.Time `json:"deletedDateTime"`
+	DeletedFromLocation string    `json:"deletedFromLocation"`
+}
+
+// RecycleBinFailure represents an item of the recycle bin which could not be deleted.
+type RecycleBinFailure struct {
+	Item *RecycleBinItem
+	Err  error
+}
+
+// PurgeRecycleBinResult represents the outcome of PurgeRecycleBin.
+type PurgeRecycleBinResult struct {
+	// Deleted are the items permanently deleted.
+	Deleted []*RecycleBinItem
+	// Failed are the items which could not be deleted.
+	Failed []RecycleBinFailure
+}
+
+// ListRecycleBin lists all the items in the recycle bin of a SharePoint site.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/recyclebin-list-items?view=graph-rest-1.0
+func (s *SitesService) ListRecycleBin(ctx context.Context, siteId string) ([]*RecycleBinItem, error) {
+	if siteId == "" {
+		return nil, errors.New("Please provide the ID of the site.")
+	}
+
+	var items []*RecycleBinItem
+	apiURL := "sites/" + url.PathEscape(siteId) + "/recycleBin/items"
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		apiURL, err = s.client.stream(ctx, req, func(decode func(target interface{}) error) error {
+			var item *RecycleBinItem
+			if err := decode(&item); err != nil {
+				return err
+			}
+			if item != nil {
+				items = append(items, item)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// PurgeRecycleBin permanently deletes the items which have been in the recycle bin of a
+// SharePoint site for longer than olderThan, e.g. to enforce a retention policy shorter
+// than the one of the tenant. The deletions are combined into JSON batches; the items which
+// could not be deleted are reported in the result.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/recyclebin?view=graph-rest-1.0
+func (s *SitesService) PurgeRecycleBin(ctx context.Context, siteId string, olderThan time.Duration) (*PurgeRecycleBinResult, error) {
+	if olderThan < 0 {
+		return nil, errors.New("Please provide a retention duration which is not negative.")
+	}
+
+	items, err := s.ListRecycleBin(ctx, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedBefore := s.client.now().Add(-olderThan)
+
+	var expired []*RecycleBinItem
+	var requests []batchRequest
+	for _, item := range items {
+		if !item.DeletedDateTime.Before(deletedBefore) {
+			continue
+		}
+
+		expired = append(expired, item)
+		requests = append(requests, batchRequest{
+			Method: "DELETE",
+			URL:    "sites/" + url.PathEscape(siteId) + "/recycleBin/items/" + url.PathEscape(item.Id),
+		})
+	}
+
+	result := &PurgeRecycleBinResult{}
+	if len(requests) == 0 {
+		return result, nil
+	}
+
+	responses, err := s.client.batch(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, response := range responses {
+		if err := response.decode(nil); err != nil {
+			result.Failed = append(result.Failed, RecycleBinFailure{Item: expired[i], Err: err})
+			continue
+		}
+		result.Deleted = append(result.Deleted, expired[i])
+	}
+
+	return result, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSitesService_PurgeRecycleBin(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	old := time.Now().Add(-60 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	mux.HandleFunc("/sites/site-1/recycleBin/items", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprintf(w, `{"value": [
+			{"id": "old-1", "name": "a.txt", "deletedDateTime": %q},
+			{"id": "recent", "name": "b.txt", "deletedDateTime": %q},
+			{"id": "old-2", "name": "c.txt", "deletedDateTime": %q}
+		]}`, old, recent, old)
+	})
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		if len(payload.Requests) != 2 || payload.Requests[0].Method != "DELETE" || payload.Requests[0].URL != "/sites/site-1/recycleBin/items/old-1" {
+			t.Errorf("Batch requests: %+v, want the deletion of the old items", payload.Requests)
+		}
+
+		fmt.Fprintf(w, `{"responses": [
+			{"id": %q, "status": 204},
+			{"id": %q, "status": 404, "body": {"error": {"code": "itemNotFound", "message": "Item not found"}}}
+		]}`, payload.Requests[0].Id, payload.Requests[1].Id)
+	})
+
+	result, err := client.Sites.PurgeRecycleBin(context.Background(), "site-1", 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Sites.PurgeRecycleBin returned error: %v", err)
+	}
+
+	if len(result.Deleted) != 1 || result.Deleted[0].Id != "old-1" {
+		t.Errorf("Sites.PurgeRecycleBin deleted %+v, want only %q", result.Deleted, "old-1")
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Item.Id != "old-2" || !isNotFound(result.Failed[0].Err) {
+		t.Errorf("Sites.PurgeRecycleBin failed %+v, want %q not found", result.Failed, "old-2")
+	}
+}
+
+func TestSitesService_PurgeRecycleBin_nothingExpired(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/sites/site-1/recycleBin/items", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": []}`)
+	})
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Sites.PurgeRecycleBin sent a batch without expired items")
+	})
+
+	result, err := client.Sites.PurgeRecycleBin(context.Background(), "site-1", time.Hour)
+	if err != nil || len(result.Deleted) != 0 {
+		t.Errorf("Sites.PurgeRecycleBin returned %+v, %v, want nothing deleted", result, err)
+	}
+}
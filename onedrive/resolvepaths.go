@@ -0,0 +1,81 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ResolvePaths retrieves many items addressed by their paths from the root of the default
+// drive of the authenticated user, e.g. "Reports/2024/summary.pdf", with JSON batches of
+// requests, so a sync planner can hydrate thousands of paths in a few round trips. The items
+// are returned by path, as given; the paths which do not exist are absent from the map.
+//
+// The IDs of the items are cached if the client has been created with WithPathCache.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
+func (s *DriveItemsService) ResolvePaths(ctx context.Context, paths []string) (map[string]*DriveItem, error) {
+	var requests []batchRequest
+	var requestPaths []string
+	requested := make(map[string]bool)
+	for _, itemPath := range paths {
+		if strings.Trim(itemPath, "/") == "" {
+			return nil, errors.New("Please provide the paths of the items.")
+		}
+
+		if requested[itemPath] {
+			continue
+		}
+		requested[itemPath] = true
+
+		apiURL, err := s.pathURL(ctx, "", itemPath)
+		if isNotFound(err) && s.client.forgetParentPaths("", itemPath) {
+			apiURL, err = s.pathURL(ctx, "", itemPath)
+		}
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		requests = append(requests, batchRequest{Method: "GET", URL: apiURL})
+		requestPaths = append(requestPaths, itemPath)
+	}
+
+	items := make(map[string]*DriveItem, len(requests))
+	if len(requests) == 0 {
+		return items, nil
+	}
+
+	responses, err := s.client.batch(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, response := range responses {
+		var item *DriveItem
+		err := response.decode(&item)
+		if isNotFound(err) && s.client.forgetParentPaths("", requestPaths[i]) {
+			// The path was relative to a cached folder, which may have been moved since.
+			item, err = s.GetByPath(ctx, requestPaths[i])
+		}
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if item != nil {
+			s.client.cachePath("", requestPaths[i], item.Id, item.ETag)
+			items[requestPaths[i]] = item
+		}
+	}
+
+	return items, nil
+}
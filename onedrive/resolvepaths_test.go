@@ -0,0 +1,113 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_ResolvePaths(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithPathCache()(client)
+
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Cannot decode the batch: %v", err)
+		}
+
+		if len(payload.Requests) != 2 {
+			t.Fatalf("The batch has %d requests, want 2", len(payload.Requests))
+		}
+		if got, want := payload.Requests[0].URL, "/me/drive/root:/Reports/2024:"; got != want {
+			t.Errorf("The first request URL is %q, want %q", got, want)
+		}
+
+		fmt.Fprintf(w, `{"responses": [
+			{"id": %q, "status": 200, "body": {"id": "reports-2024", "name": "2024"}},
+			{"id": %q, "status": 404, "body": {"error": {"code": "itemNotFound", "message": "Item not found"}}}
+		]}`, payload.Requests[0].Id, payload.Requests[1].Id)
+	})
+
+	got, err := client.DriveItems.ResolvePaths(context.Background(), []string{"Reports/2024", "Missing", "Reports/2024"})
+	if err != nil {
+		t.Fatalf("DriveItems.ResolvePaths returned error: %v", err)
+	}
+
+	if len(got) != 1 || got["Reports/2024"] == nil || got["Reports/2024"].Id != "reports-2024" {
+		t.Errorf("DriveItems.ResolvePaths returned %+v, want only Reports/2024", got)
+	}
+
+	if itemId, ok := client.cachedPath("", "Reports/2024"); !ok || itemId != "reports-2024" {
+		t.Errorf("The cached ID of Reports/2024 is %q, want %q", itemId, "reports-2024")
+	}
+}
+
+func TestDriveItemsService_ResolvePaths_throttled(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	WithClock(clock)(client)
+	WithSleeper(clock)(client)
+	WithThrottlingBackoff()(client)
+
+	var batches [][]string
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Cannot decode the batch: %v", err)
+		}
+
+		var urls []string
+		for _, request := range payload.Requests {
+			urls = append(urls, request.URL)
+		}
+		batches = append(batches, urls)
+
+		if len(batches) == 1 {
+			fmt.Fprintf(w, `{"responses": [
+				{"id": %q, "status": 200, "body": {"id": "a", "name": "A"}},
+				{"id": %q, "status": 429, "headers": {"Retry-After": "2"}, "body": {"error": {"code": "activityLimitReached"}}}
+			]}`, payload.Requests[0].Id, payload.Requests[1].Id)
+			return
+		}
+		fmt.Fprintf(w, `{"responses": [{"id": %q, "status": 200, "body": {"id": "b", "name": "B"}}]}`, payload.Requests[0].Id)
+	})
+
+	got, err := client.DriveItems.ResolvePaths(context.Background(), []string{"A", "B"})
+	if err != nil {
+		t.Fatalf("DriveItems.ResolvePaths returned error: %v", err)
+	}
+
+	if len(got) != 2 || got["A"] == nil || got["B"] == nil || got["B"].Id != "b" {
+		t.Errorf("DriveItems.ResolvePaths returned %+v, want A and B", got)
+	}
+	if len(batches) != 2 || len(batches[1]) != 1 {
+		t.Errorf("The batches contain %q, want only the throttled request sent again", batches)
+	}
+	if want := []time.Duration{2 * time.Second}; !reflect.DeepEqual(clock.sleeps, want) {
+		t.Errorf("DriveItems.ResolvePaths slept %v, want %v", clock.sleeps, want)
+	}
+	if until := client.throttledUntil["me/drive"]; !until.Equal(time.Date(2021, 1, 1, 0, 0, 2, 0, time.UTC)) {
+		t.Errorf("The default drive is throttled until %v", until)
+	}
+}
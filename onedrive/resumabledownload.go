@@ -0,0 +1,202 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DownloadResumableOpts represents the options for downloading a file by
+// DownloadToFileResumable.
+type DownloadResumableOpts struct {
+	// MaxRetries is the maximum number of times the download continues after a network
+	// failure. Default is 5.
+	MaxRetries int
+}
+
+// partialDownload represents the file a partial download is for, recorded next to the
+// partial local file.
+type partialDownload struct {
+	ItemId string `json:"itemId"`
+	Tag    string `json:"tag"` // cTag of the item, or its eTag if it has no cTag.
+	Size   int64  `json:"size"`
+}
+
+// DownloadToFileResumable downloads the content of a file in a drive of the authenticated
+// user into a local file, like DownloadToFile, without restarting from the first byte after
+// an interruption.
+//
+// The content is written into localFilePath + ".partial", along with a record of the item
+// it comes from in localFilePath + ".partial.json". After a network failure, the download
+// continues with a Range request from the end of the partial file, with an exponential
+// backoff. A partial file left by an earlier call, e.g. by a process which has crashed, is
+// continued too, as long as the item has not changed since. The partial file replaces the
+// local file once complete.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online#partial-range-downloads
+func (s *DriveItemsService) DownloadToFileResumable(ctx context.Context, item *DriveItem, localFilePath string, opts DownloadResumableOpts) error {
+	if localFilePath == "" {
+		return errors.New("Please provide the path to the file on local.")
+	}
+
+	maxRetries := opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 5
+	}
+
+	item, err := s.downloadableItem(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(localFilePath), 0755); err != nil {
+		return err
+	}
+
+	partialPath := localFilePath + ".partial"
+	recordPath := partialPath + ".json"
+	file, err := openPartialDownload(item, partialPath, recordPath)
+	if err != nil {
+		return err
+	}
+
+	delay := time.Second
+	for retries := 0; ; retries++ {
+		err = s.continueDownload(ctx, item, file)
+		if err == nil {
+			break
+		}
+
+		if ctx.Err() != nil || retries >= maxRetries {
+			file.Close()
+			return err
+		}
+
+		if oneDriveError, ok := err.(*Error); ok {
+			// The download URL of the item has expired.
+			if oneDriveError.StatusCode != http.StatusUnauthorized && oneDriveError.StatusCode != http.StatusForbidden {
+				file.Close()
+				return err
+			}
+			refreshed, err := s.Get(ctx, item.Id)
+			if err != nil {
+				file.Close()
+				return err
+			}
+			if partialTag(refreshed) != partialTag(item) {
+				file.Close()
+				return fmt.Errorf("The item %q has changed during the download.", item.Id)
+			}
+			item = refreshed
+		}
+
+		if err := s.client.sleep(ctx, delay); err != nil {
+			file.Close()
+			return err
+		}
+		if delay < 30*time.Second {
+			delay *= 2
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(partialPath, localFilePath); err != nil {
+		return err
+	}
+	os.Remove(recordPath)
+
+	if item.LastModifiedDateTime.IsZero() {
+		return nil
+	}
+	return os.Chtimes(localFilePath, item.LastModifiedDateTime, item.LastModifiedDateTime)
+}
+
+// openPartialDownload opens the partial local file of an item for appending, truncating
+// it unless it has been downloaded from the same version of the item.
+func openPartialDownload(item *DriveItem, partialPath string, recordPath string) (*os.File, error) {
+	record := partialDownload{ItemId: item.Id, Tag: partialTag(item), Size: item.Size}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	var previous partialDownload
+	data, err := ioutil.ReadFile(recordPath)
+	if err != nil || json.Unmarshal(data, &previous) != nil || previous != record || record.Tag == "" {
+		flags |= os.O_TRUNC
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(recordPath, data, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if fileInfo, err := file.Stat(); err == nil && fileInfo.Size() > item.Size {
+		if err := file.Truncate(0); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	return file, nil
+}
+
+// continueDownload appends the content of an item missing from a partial local file.
+func (s *DriveItemsService) continueDownload(ctx context.Context, item *DriveItem, file *os.File) error {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	offset := fileInfo.Size()
+	if offset >= item.Size && item.Size > 0 {
+		return nil
+	}
+
+	byteRange := ""
+	if offset > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-", offset)
+	}
+
+	content, err := s.openDownloadRange(ctx, item.DownloadURL, byteRange, offset, 0)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if _, err := io.Copy(file, content); err != nil {
+		return processHTTPError(ctx, err)
+	}
+
+	if fileInfo, err := file.Stat(); err == nil && fileInfo.Size() < item.Size {
+		return io.ErrUnexpectedEOF
+	}
+
+	return nil
+}
+
+func partialTag(item *DriveItem) string {
+	if item.CTag != "" {
+		return item.CTag
+	}
+	return item.ETag
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_DownloadToFileResumable(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	WithSleeper(clock)(client)
+
+	var ranges []string
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			// The connection breaks after the first bytes.
+			w.Header().Set("Content-Length", "10")
+			w.Write([]byte("0123"))
+			return
+		}
+		http.ServeContent(w, r, "a.txt", time.Time{}, strings.NewReader("0123456789"))
+	})
+
+	dir, err := ioutil.TempDir("", "resumable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "a.txt")
+	item := &DriveItem{Id: "1", CTag: "c1", Size: 10, File: &DriveItemFile{}, DownloadURL: serverURL + baseURLPath + "/download/1"}
+	if err := client.DriveItems.DownloadToFileResumable(context.Background(), item, localPath, DownloadResumableOpts{}); err != nil {
+		t.Fatalf("DriveItems.DownloadToFileResumable returned error: %v", err)
+	}
+
+	if got, _ := ioutil.ReadFile(localPath); string(got) != "0123456789" {
+		t.Errorf("The local file contains %q, want %q", got, "0123456789")
+	}
+	if want := []string{"", "bytes=4-"}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("The ranges requested are %q, want %q", ranges, want)
+	}
+	if want := []time.Duration{time.Second}; !reflect.DeepEqual(clock.sleeps, want) {
+		t.Errorf("DriveItems.DownloadToFileResumable slept %v, want %v", clock.sleeps, want)
+	}
+	for _, leftover := range []string{localPath + ".partial", localPath + ".partial.json"} {
+		if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+			t.Errorf("%s has not been removed", leftover)
+		}
+	}
+}
+
+func TestDriveItemsService_DownloadToFileResumable_partialFile(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	var ranges []string
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "a.txt", time.Time{}, strings.NewReader("0123456789"))
+	})
+
+	dir, err := ioutil.TempDir("", "resumable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "a.txt")
+	ioutil.WriteFile(localPath+".partial", []byte("01234"), 0644)
+	ioutil.WriteFile(localPath+".partial.json", []byte(`{"itemId":"1","tag":"c1","size":10}`), 0644)
+
+	item := &DriveItem{Id: "1", CTag: "c1", Size: 10, File: &DriveItemFile{}, DownloadURL: serverURL + baseURLPath + "/download/1"}
+	if err := client.DriveItems.DownloadToFileResumable(context.Background(), item, localPath, DownloadResumableOpts{}); err != nil {
+		t.Fatalf("DriveItems.DownloadToFileResumable returned error: %v", err)
+	}
+
+	if got, _ := ioutil.ReadFile(localPath); string(got) != "0123456789" {
+		t.Errorf("The local file contains %q, want %q", got, "0123456789")
+	}
+	if want := []string{"bytes=5-"}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("The ranges requested are %q, want %q", ranges, want)
+	}
+
+	// The partial file of another version of the item is discarded.
+	ranges = nil
+	ioutil.WriteFile(localPath+".partial", []byte("xxxxx"), 0644)
+	ioutil.WriteFile(localPath+".partial.json", []byte(`{"itemId":"1","tag":"c0","size":10}`), 0644)
+	if err := client.DriveItems.DownloadToFileResumable(context.Background(), item, localPath, DownloadResumableOpts{}); err != nil {
+		t.Fatalf("DriveItems.DownloadToFileResumable returned error: %v", err)
+	}
+	if got, _ := ioutil.ReadFile(localPath); string(got) != "0123456789" {
+		t.Errorf("The local file contains %q, want %q", got, "0123456789")
+	}
+	if want := []string{""}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("The ranges requested are %q, want %q", ranges, want)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CreateUploadSessionOpts represents the options for creating an upload session by
+// CreateUploadSession.
+type CreateUploadSessionOpts struct {
+	DriveID string
+	// ConflictBehavior customizes the conflict resolution behavior. By default,
+	// existing item will be replaced. Possible values are "fail", "replace", or
+	// "rename".
+	ConflictBehavior string
+	// FileSystemInfo, if any, holds the times of the file on the local file system, which
+	// are set on the item when the upload completes instead of the time of the upload.
+	FileSystemInfo *FileSystemInfo
+	// Description, if any, is set on the item when the upload completes.
+	Description string
+	// DeferCommit makes the session wait, once all the bytes have been uploaded, for
+	// CommitUploadSession to create the item. ResumeUpload commits the session itself.
+	DeferCommit bool
+}
+
+// uploadSessionRequest represents the body of a request creating an upload session, whose
+// item properties are set atomically when the upload completes.
+type uploadSessionRequest struct {
+	Item        uploadSessionItem `json:"item"`
+	DeferCommit bool              `json:"deferCommit,omitempty"`
+}
+
+// uploadSessionItem represents the properties of the item of an upload session.
+type uploadSessionItem struct {
+	ConflictBehavior string          `json:"@microsoft.graph.conflictBehavior,omitempty"`
+	Name             string          `json:"name,omitempty"`
+	Description      string          `json:"description,omitempty"`
+	FileSystemInfo   *FileSystemInfo `json:"fileSystemInfo,omitempty"`
+}
+
+// newUploadSessionRequest returns the body of a request creating an upload session for a
+// file with the given properties, or nil when the session needs no body, since the name
+// and the conflict behavior are already in the URL.
+func newUploadSessionRequest(name string, conflictBehavior string, description string, fileSystemInfo *FileSystemInfo, deferCommit bool) *uploadSessionRequest {
+	if description == "" && fileSystemInfo == nil && !deferCommit {
+		return nil
+	}
+
+	return &uploadSessionRequest{
+		Item: uploadSessionItem{
+			ConflictBehavior: conflictBehavior,
+			Name:             name,
+			Description:      description,
+			FileSystemInfo:   fileSystemInfo,
+		},
+		DeferCommit: deferCommit,
+	}
+}
+
+// CreateUploadSession creates an upload session for a new file in a folder of a drive of
+// the authenticated user, without uploading anything. Unlike UploadLargeFile, the session
+// is left to the caller, who can persist it, upload the file with ResumeUpload, continue
+// after an interruption, even in another process, and cancel it with CancelUploadSession.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online
+func (s *DriveItemsService) CreateUploadSession(ctx context.Context, destinationParentFolderId string, fileName string, opts CreateUploadSessionOpts) (*UploadSession, error) {
+	if destinationParentFolderId == "" {
+		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for this new item.")
+	}
+
+	if fileName == "" {
+		return nil, errors.New("Please provide the file name.")
+	}
+
+	apiURL := itemPathURL(opts.DriveID, destinationParentFolderId, fileName) + "/createUploadSession"
+	if opts.ConflictBehavior != "" {
+		apiURL += "?@microsoft.graph.conflictBehavior=" + opts.ConflictBehavior
+	}
+
+	return s.createUploadSession(ctx, apiURL, newUploadSessionRequest(fileName, opts.ConflictBehavior, opts.Description, opts.FileSystemInfo, opts.DeferCommit))
+}
+
+// UploadSessionStatus returns the status of an upload session, i.e. its expiration and the
+// ranges of the file which are still to be uploaded. The upload URL can be the one of an
+// UploadSession or of an UploadSessionRecord.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online#resuming-an-in-progress-upload
+func (s *DriveItemsService) UploadSessionStatus(ctx context.Context, uploadURL string) (*UploadSession, error) {
+	if uploadURL == "" {
+		return nil, errors.New("Please provide the upload URL of the session.")
+	}
+
+	req, err := http.NewRequest("GET", s.client.uploadURL(uploadURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The upload URL is pre-authenticated.
+	var session *UploadSession
+	if err := s.client.Do(ctx, req, true, &session); err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errors.New("The upload session has no status.")
+	}
+	session.UploadUrl = uploadURL
+
+	return session, nil
+}
+
+// ResumeUpload uploads the parts of a file which the upload session is still expecting,
+// and returns the item once the file is complete. It can be called for a new session as
+// well as for a session interrupted by a failure or a crash, in which case the status of
+// the session is retrieved first, so only the missing bytes are sent. A session created
+// with DeferCommit is committed once all the bytes have been uploaded.
+//
+// The session is not cancelled when the upload fails, so it can be resumed again; see
+// CancelUploadSession.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online#resuming-an-in-progress-upload
+func (s *DriveItemsService) ResumeUpload(ctx context.Context, session *UploadSession, file LargeFile) (*DriveItem, error) {
+	if session == nil || session.UploadUrl == "" {
+		return nil, errors.New("Please provide the upload session.")
+	}
+
+	if file.Size == 0 {
+		return nil, errors.New("Please provide the file size.")
+	}
+	if file.Data == nil {
+		return nil, errors.New("Please provide the file reader.")
+	}
+
+	status, err := s.UploadSessionStatus(ctx, session.UploadUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *DriveItem
+	if len(status.NextExpectedRanges) < 1 {
+		// All the bytes have been uploaded, the session is waiting for its commit.
+		response, err = s.commitUploadSession(ctx, session.UploadUrl)
+	} else {
+		var chunkSize uint64 = 4 * 1024 * 1024
+		var offset, length uint64
+		offset, length, err = nextChunk(status.NextExpectedRanges[0], chunkSize, file.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = s.uploadChunks(ctx, session.UploadUrl, make([]byte, chunkSize), offset, length, file, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.markCreated(response)
+
+	return response, nil
+}
+
+// CommitUploadSession creates the item of an upload session created with DeferCommit, once
+// all the bytes of the file have been uploaded, and returns it.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online#completing-a-file
+func (s *DriveItemsService) CommitUploadSession(ctx context.Context, session *UploadSession) (*DriveItem, error) {
+	if session == nil || session.UploadUrl == "" {
+		return nil, errors.New("Please provide the upload session.")
+	}
+
+	response, err := s.commitUploadSession(ctx, session.UploadUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.markCreated(response)
+
+	return response, nil
+}
+
+// commitUploadSession commits an upload session which has received all the bytes of the
+// file, with an empty POST to its upload URL.
+func (s *DriveItemsService) commitUploadSession(ctx context.Context, uploadURL string) (*DriveItem, error) {
+	req, err := http.NewRequest("POST", s.client.uploadURL(uploadURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Length", "0")
+
+	// The upload URL is pre-authenticated.
+	var response *DriveItem
+	if err := s.client.Do(ctx, req, true, &response); err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, errors.New("The upload session has not returned the item.")
+	}
+
+	return response, nil
+}
+
+// CancelUploadSession cancels an upload session, so the bytes already uploaded are
+// discarded. A session which is already gone is not an error.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online#cancel-the-upload-session
+func (s *DriveItemsService) CancelUploadSession(ctx context.Context, session *UploadSession) error {
+	if session == nil || session.UploadUrl == "" {
+		return errors.New("Please provide the upload session.")
+	}
+
+	return s.cancelUploadSession(ctx, session.UploadUrl)
+}
+
+// createUploadSession creates an upload session with the given createUploadSession URL and
+// body, if any, see newUploadSessionRequest.
+func (s *DriveItemsService) createUploadSession(ctx context.Context, apiURL string, request *uploadSessionRequest) (*UploadSession, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+
+	req, err := s.client.NewRequest("POST", apiURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var session UploadSession
+	err = s.client.Do(ctx, req, false, &session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// nextExpectedOffset returns the first byte of a range of the nextExpectedRanges of an
+// upload session, e.g. "26-" or "26-99".
+func nextExpectedOffset(expectedRange string) (uint64, error) {
+	start := expectedRange
+	if i := strings.Index(expectedRange, "-"); i >= 0 {
+		start = expectedRange[:i]
+	}
+
+	offset, err := strconv.ParseUint(start, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("The expected range %q of the upload session is not valid.", expectedRange)
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDriveItemsService_ResumeUpload(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	uploadURL := serverURL + baseURLPath + "/upload/1"
+	mux.HandleFunc("/me/drive/items/folder-1:/a.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got, want := r.URL.Query().Get("@microsoft.graph.conflictBehavior"), "fail"; got != want {
+			t.Errorf("conflictBehavior = %q, want %q", got, want)
+		}
+		fmt.Fprintf(w, `{"uploadUrl":%q,"expirationDateTime":"2021-01-01T00:00:00Z","nextExpectedRanges":["0-"]}`, uploadURL)
+	})
+
+	deleted := false
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			// The first 5 bytes were uploaded before the interruption.
+			fmt.Fprint(w, `{"expirationDateTime":"2021-01-01T00:00:00Z","nextExpectedRanges":["5-9"]}`)
+		case "PUT":
+			if got, want := r.Header.Get("Content-Range"), "bytes 5-9/10"; got != want {
+				t.Errorf("Content-Range = %q, want %q", got, want)
+			}
+			if body, _ := ioutil.ReadAll(r.Body); string(body) != "56789" {
+				t.Errorf("The body is %q, want %q", body, "56789")
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"1","name":"a.bin","size":10}`)
+		case "DELETE":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	session, err := client.DriveItems.CreateUploadSession(ctx, "folder-1", "a.bin", CreateUploadSessionOpts{ConflictBehavior: "fail"})
+	if err != nil {
+		t.Fatalf("DriveItems.CreateUploadSession returned error: %v", err)
+	}
+	if session.UploadUrl != uploadURL {
+		t.Errorf("DriveItems.CreateUploadSession returned %+v", session)
+	}
+
+	status, err := client.DriveItems.UploadSessionStatus(ctx, session.UploadUrl)
+	if err != nil {
+		t.Fatalf("DriveItems.UploadSessionStatus returned error: %v", err)
+	}
+	if len(status.NextExpectedRanges) != 1 || status.NextExpectedRanges[0] != "5-9" || status.UploadUrl != uploadURL {
+		t.Errorf("DriveItems.UploadSessionStatus returned %+v", status)
+	}
+
+	item, err := client.DriveItems.ResumeUpload(ctx, session, LargeFile{Name: "a.bin", Size: 10, Data: strings.NewReader("0123456789")})
+	if err != nil {
+		t.Fatalf("DriveItems.ResumeUpload returned error: %v", err)
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.ResumeUpload returned %+v", item)
+	}
+	if deleted {
+		t.Error("DriveItems.ResumeUpload cancelled the upload session")
+	}
+
+	if err := client.DriveItems.CancelUploadSession(ctx, session); err != nil {
+		t.Fatalf("DriveItems.CancelUploadSession returned error: %v", err)
+	}
+	if !deleted {
+		t.Error("DriveItems.CancelUploadSession did not delete the upload session")
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_deferCommit(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder-1:/report.pdf:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		want := `{"item":{"@microsoft.graph.conflictBehavior":"fail","name":"report.pdf","description":"Q3 report"},"deferCommit":true}`
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != want {
+			t.Errorf("The body is %s, want %s", body, want)
+		}
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+	})
+	committed := false
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			// The session keeps the file until it is committed.
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"nextExpectedRanges":[]}`)
+		case "POST":
+			if r.ContentLength > 0 {
+				t.Errorf("Content-Length = %d, want 0", r.ContentLength)
+			}
+			committed = true
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"1","name":"report.pdf","description":"Q3 report"}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	file := LargeFile{Name: "report.pdf", Size: 10, Data: strings.NewReader("0123456789")}
+	item, err := client.DriveItems.UploadLargeFile(context.Background(), "folder-1", file, UploadLargeFileOpts{
+		ConflictBehavior: "fail",
+		Description:      "Q3 report",
+		DeferCommit:      true,
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadLargeFile returned error: %v", err)
+	}
+	if !committed {
+		t.Error("The upload session has not been committed")
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.UploadLargeFile returned %+v", item)
+	}
+}
+
+func TestDriveItemsService_ResumeUpload_commit(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			// All the bytes were uploaded before the interruption.
+			fmt.Fprint(w, `{"expirationDateTime":"2021-01-01T00:00:00Z","nextExpectedRanges":[]}`)
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"1","name":"a.bin","size":10}`)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	session := &UploadSession{UploadUrl: serverURL + baseURLPath + "/upload/1"}
+	item, err := client.DriveItems.ResumeUpload(context.Background(), session, LargeFile{Name: "a.bin", Size: 10, Data: strings.NewReader("0123456789")})
+	if err != nil {
+		t.Fatalf("DriveItems.ResumeUpload returned error: %v", err)
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.ResumeUpload returned %+v", item)
+	}
+
+	if _, err := client.DriveItems.CommitUploadSession(context.Background(), nil); err == nil {
+		t.Error("DriveItems.CommitUploadSession returned no error for a nil session")
+	}
+}
+
+func TestNextExpectedOffset(t *testing.T) {
+	tests := map[string]uint64{"0-": 0, "26-": 26, "26-99": 26, "77": 77}
+	for expectedRange, want := range tests {
+		got, err := nextExpectedOffset(expectedRange)
+		if err != nil || got != want {
+			t.Errorf("nextExpectedOffset(%q) = %d, %v, want %d", expectedRange, got, err, want)
+		}
+	}
+
+	if _, err := nextExpectedOffset("-"); err == nil {
+		t.Error("nextExpectedOffset returned no error for an invalid range")
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// defaultReaderRetries is the number of times RetryableReader reopens its source in a row
+// before giving up.
+const defaultReaderRetries = 3
+
+// RetryableReader turns a source which can be reopened at any offset, e.g. an HTTP download
+// supporting Range requests, into the io.Reader and io.ReaderAt of known size the upload
+// methods need. When reading from the source fails, the source is reopened at the offset
+// reached and the read goes on, so a transfer from another network service survives the
+// hiccups of that service.
+//
+// Sequential reads share a single open source. It is safe for concurrent use.
+type RetryableReader struct {
+	// MaxRetries is the number of times the source is reopened in a row before the error
+	// is returned. By default, it is 3.
+	MaxRetries int
+
+	open func(offset int64) (io.ReadCloser, error)
+	size int64
+
+	mu       sync.Mutex
+	source   io.ReadCloser
+	position int64 // Offset of the next byte read from source.
+	offset   int64 // Offset of the next byte returned by Read.
+}
+
+// NewRetryableReader returns a RetryableReader of size bytes reading from the sources
+// returned by open, which must return the content of the source starting at offset.
+func NewRetryableReader(size int64, open func(offset int64) (io.ReadCloser, error)) *RetryableReader {
+	return &RetryableReader{open: open, size: size}
+}
+
+// Size returns the size of the content, so the upload methods can tell it.
+func (r *RetryableReader) Size() int64 {
+	return r.size
+}
+
+// Read implements io.Reader.
+func (r *RetryableReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	offset := r.offset
+	r.mu.Unlock()
+
+	n, err := r.ReadAt(p, offset)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+
+	r.mu.Lock()
+	r.offset = offset + int64(n)
+	r.mu.Unlock()
+
+	return n, err
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *RetryableReader) ReadAt(p []byte, offset int64) (int, error) {
+	if r.open == nil {
+		return 0, errors.New("Please provide the function opening the source.")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+
+	if offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if remaining := r.size - offset; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	maxRetries := r.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultReaderRetries
+	}
+
+	read, failures := 0, 0
+	for read < len(p) {
+		err := r.seek(offset + int64(read))
+		if err == nil {
+			var n int
+			n, err = io.ReadFull(r.source, p[read:])
+			read += n
+			r.position += int64(n)
+			if n > 0 {
+				failures = 0
+			}
+		}
+		if err == nil {
+			break
+		}
+
+		r.closeSource()
+		failures++
+		if failures > maxRetries {
+			return read, err
+		}
+	}
+
+	var err error
+	if offset+int64(read) >= r.size {
+		err = io.EOF
+	}
+
+	return read, err
+}
+
+// seek makes the open source start at the given offset, reopening it if needed.
+func (r *RetryableReader) seek(offset int64) error {
+	if r.source != nil && r.position == offset {
+		return nil
+	}
+
+	r.closeSource()
+
+	source, err := r.open(offset)
+	if err != nil {
+		return err
+	}
+
+	r.source, r.position = source, offset
+	return nil
+}
+
+func (r *RetryableReader) closeSource() {
+	if r.source != nil {
+		r.source.Close()
+		r.source = nil
+	}
+}
+
+// Close closes the open source, if any.
+func (r *RetryableReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closeSource()
+	return nil
+}
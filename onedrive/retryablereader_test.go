@@ -0,0 +1,94 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// flakySource serves content, failing after failAfter bytes once per open.
+type flakySource struct {
+	content   string
+	failAfter int
+	opens     []int64
+}
+
+func (s *flakySource) open(offset int64) (io.ReadCloser, error) {
+	s.opens = append(s.opens, offset)
+	reader := io.Reader(strings.NewReader(s.content[offset:]))
+	if s.failAfter > 0 {
+		reader = io.MultiReader(io.LimitReader(reader, int64(s.failAfter)), &errorReader{})
+	}
+	return ioutil.NopCloser(reader), nil
+}
+
+type errorReader struct{}
+
+func (*errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+func TestRetryableReader_Read(t *testing.T) {
+	source := &flakySource{content: "hello, world", failAfter: 5}
+	reader := NewRetryableReader(int64(len(source.content)), source.open)
+
+	got, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("RetryableReader.Read returned error: %v", err)
+	}
+	if string(got) != source.content {
+		t.Errorf("RetryableReader.Read returned %q, want %q", got, source.content)
+	}
+
+	wantOpens := []int64{0, 5, 10}
+	if len(source.opens) != len(wantOpens) || source.opens[1] != 5 || source.opens[2] != 10 {
+		t.Errorf("RetryableReader opened the source at %v, want %v", source.opens, wantOpens)
+	}
+}
+
+func TestRetryableReader_ReadAt(t *testing.T) {
+	source := &flakySource{content: "0123456789"}
+	reader := NewRetryableReader(int64(len(source.content)), source.open)
+	defer reader.Close()
+
+	buffer := make([]byte, 4)
+	for _, offset := range []int64{0, 4} {
+		if n, err := reader.ReadAt(buffer, offset); n != 4 || err != nil {
+			t.Fatalf("RetryableReader.ReadAt returned %d, %v, want 4, nil", n, err)
+		}
+	}
+	if len(source.opens) != 1 {
+		t.Errorf("RetryableReader opened the source %d times for sequential reads, want 1", len(source.opens))
+	}
+
+	n, err := reader.ReadAt(buffer, 8)
+	if n != 2 || err != io.EOF || string(buffer[:n]) != "89" {
+		t.Errorf("RetryableReader.ReadAt returned %d, %v, want 2, io.EOF", n, err)
+	}
+
+	if _, _, ok := readerAtSize(reader); !ok {
+		t.Errorf("readerAtSize did not recognize RetryableReader")
+	}
+}
+
+func TestRetryableReader_maxRetries(t *testing.T) {
+	opens := 0
+	reader := NewRetryableReader(10, func(offset int64) (io.ReadCloser, error) {
+		opens++
+		return nil, errors.New("service unavailable")
+	})
+	reader.MaxRetries = 2
+
+	if _, err := reader.ReadAt(make([]byte, 4), 0); err == nil {
+		t.Errorf("RetryableReader.ReadAt did not return an error")
+	}
+	if opens != 3 {
+		t.Errorf("RetryableReader opened the source %d times, want 3", opens)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchOpts represents the options for searching the items by Search.
+type SearchOpts struct {
+	// PageSize is the maximum number of items per page of results. By default, the page
+	// size is decided by OneDrive.
+	PageSize int
+	// MaxResults stops the search once that many items have been found. By default, all
+	// the pages of results are retrieved.
+	MaxResults int
+}
+
+// Search searches the items in the default drive of the authenticated user whose name,
+// metadata or content match the query, going through all the pages of results, so a file
+// can be found by its name without listing every folder.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *DriveItemsService) Search(ctx context.Context, query string, opts SearchOpts) (*OneDriveDriveItemsResponse, error) {
+	apiURL, err := searchURL("me/drive/root", query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.searchPages(ctx, apiURL, opts)
+}
+
+// SearchIn searches the items beneath a folder in the default drive of the authenticated
+// user, like Search, for apps which manage a dedicated folder.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *DriveItemsService) SearchIn(ctx context.Context, folderId string, query string, opts SearchOpts) (*OneDriveDriveItemsResponse, error) {
+	if folderId == "" {
+		return nil, errors.New("Please provide the Item ID of the folder.")
+	}
+
+	apiURL, err := searchURL("me/drive/items/"+url.PathEscape(folderId), query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.searchPages(ctx, apiURL, opts)
+}
+
+// SearchInPath searches the items beneath a folder addressed by its path from the root of
+// the default drive of the authenticated user, e.g. "Apps/Invoices", like SearchIn.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *DriveItemsService) SearchInPath(ctx context.Context, folderPath string, query string, opts SearchOpts) (*OneDriveDriveItemsResponse, error) {
+	if strings.Trim(folderPath, "/") == "" {
+		return nil, errors.New("Please provide the path of the folder.")
+	}
+
+	var response *OneDriveDriveItemsResponse
+	err := s.doPath(ctx, "", folderPath, func(folderURL string) error {
+		apiURL, err := searchURL(folderURL, query, opts)
+		if err != nil {
+			return err
+		}
+
+		response, err = s.searchPages(ctx, apiURL, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// searchURL returns the relative URL of the first page of results of a search beneath the
+// folder at itemURL.
+func searchURL(itemURL string, query string, opts SearchOpts) (string, error) {
+	if query == "" {
+		return "", errors.New("Please provide the search query.")
+	}
+
+	if opts.PageSize < 0 || opts.MaxResults < 0 {
+		return "", errors.New("Please provide a page size and a maximum number of results which are not negative.")
+	}
+
+	// See DriveSearchService.Search about the quotes.
+	query = strings.Replace(query, "'", "''", -1)
+	apiURL := fmt.Sprintf("%s/search(q='%s')", itemURL, url.PathEscape(query))
+
+	if opts.PageSize > 0 {
+		apiURL += "?$top=" + strconv.Itoa(opts.PageSize)
+	}
+
+	return apiURL, nil
+}
+
+// searchPages retrieves the pages of results of a search, starting at apiURL, until the last
+// one or until opts.MaxResults items have been found.
+func (s *DriveItemsService) searchPages(ctx context.Context, apiURL string, opts SearchOpts) (*OneDriveDriveItemsResponse, error) {
+	response := &OneDriveDriveItemsResponse{}
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page *OneDriveDriveItemsResponse
+		if err := s.client.Do(ctx, req, false, &page); err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+
+		if response.ODataContext == "" {
+			response.ODataContext = page.ODataContext
+		}
+		response.DriveItems = append(response.DriveItems, page.DriveItems...)
+
+		if opts.MaxResults > 0 && len(response.DriveItems) >= opts.MaxResults {
+			response.DriveItems = response.DriveItems[:opts.MaxResults]
+			break
+		}
+
+		apiURL = page.NextLink
+	}
+
+	response.Count = len(response.DriveItems)
+	return response, nil
+}
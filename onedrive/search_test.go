@@ -0,0 +1,116 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDriveItemsService_Search(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='Bob''s report')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			if got, want := r.URL.Query().Get("$top"), "2"; got != want {
+				t.Errorf("Request query $top is %q, want %q", got, want)
+			}
+			fmt.Fprintf(w, `{"value": [{"id": "1"}, {"id": "2"}], "@odata.nextLink": "%sme/drive/root/search(q='Bob''s%%20report')?$top=2&page=2"}`, client.BaseURL)
+		case "2":
+			fmt.Fprint(w, `{"value": [{"id": "3"}]}`)
+		}
+	})
+
+	tests := []struct {
+		opts SearchOpts
+		want []string
+	}{
+		{SearchOpts{PageSize: 2}, []string{"1", "2", "3"}},
+		{SearchOpts{PageSize: 2, MaxResults: 2}, []string{"1", "2"}},
+	}
+
+	for _, tt := range tests {
+		got, err := client.DriveItems.Search(context.Background(), "Bob's report", tt.opts)
+		if err != nil {
+			t.Fatalf("DriveItems.Search returned error: %v", err)
+		}
+
+		var ids []string
+		for _, item := range got.DriveItems {
+			ids = append(ids, item.Id)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("DriveItems.Search with %+v returned %v, want %v", tt.opts, ids, tt.want)
+		}
+	}
+}
+
+func TestDriveItemsService_Search_emptyQuery(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	if _, err := client.DriveItems.Search(context.Background(), "", SearchOpts{}); err == nil {
+		t.Error("DriveItems.Search with an empty query returned no error")
+	}
+}
+
+func TestDriveItemsService_SearchIn(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/invoices/search(q='2024')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			if got, want := r.URL.Query().Get("$top"), "1"; got != want {
+				t.Errorf("Request query $top is %q, want %q", got, want)
+			}
+			fmt.Fprintf(w, `{"value": [{"id": "1"}], "@odata.nextLink": "%sme/drive/items/invoices/search(q='2024')?$top=1&page=2"}`, client.BaseURL)
+		case "2":
+			fmt.Fprint(w, `{"value": [{"id": "2"}]}`)
+		}
+	})
+
+	got, err := client.DriveItems.SearchIn(context.Background(), "invoices", "2024", SearchOpts{PageSize: 1})
+	if err != nil {
+		t.Fatalf("DriveItems.SearchIn returned error: %v", err)
+	}
+
+	if len(got.DriveItems) != 2 || got.DriveItems[0].Id != "1" || got.DriveItems[1].Id != "2" {
+		t.Errorf("DriveItems.SearchIn returned %+v, want items 1 and 2", got.DriveItems)
+	}
+}
+
+func TestDriveItemsService_SearchInPath(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root:/Apps/Invoices:/search(q='2024')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"value": [{"id": "1"}]}`)
+	})
+
+	got, err := client.DriveItems.SearchInPath(context.Background(), "/Apps/Invoices/", "2024", SearchOpts{})
+	if err != nil {
+		t.Fatalf("DriveItems.SearchInPath returned error: %v", err)
+	}
+
+	if len(got.DriveItems) != 1 || got.DriveItems[0].Id != "1" {
+		t.Errorf("DriveItems.SearchInPath returned %+v, want item 1", got.DriveItems)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// SearchImages searches the images and photos in the default drive of the authenticated user
+// matching the query. If query is empty, all the images and photos are listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *DriveSearchService) SearchImages(ctx context.Context, query string) (*OneDriveDriveSearchResponse, error) {
+	return s.searchFiltered(ctx, imagesSearch(query))
+}
+
+// SearchByExtension searches the files with the given extension, e.g. ".pdf", in the default
+// drive of the authenticated user. The comparison is case-insensitive.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *DriveSearchService) SearchByExtension(ctx context.Context, extension string) (*OneDriveDriveSearchResponse, error) {
+	extension = strings.ToLower(strings.TrimPrefix(extension, "."))
+	if extension == "" {
+		return nil, errors.New("Please provide the file extension.")
+	}
+
+	return s.searchFiltered(ctx, extensionSearch(extension))
+}
+
+// SearchModifiedSince lists the items in the default drive of the authenticated user
+// modified after the given time.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *DriveSearchService) SearchModifiedSince(ctx context.Context, since time.Time) (*OneDriveDriveSearchResponse, error) {
+	return s.searchFiltered(ctx, modifiedSinceSearch(since))
+}
+
+// filteredSearch represents a search composed by the search helpers: the query and the
+// $filter sent to the server, and keep, which filters the items on the client side like
+// the $filter, for the drives rejecting it.
+type filteredSearch struct {
+	query  string
+	filter string
+	keep   func(*DriveItem) bool
+}
+
+// imagesSearch returns the search of the images and photos matching query.
+func imagesSearch(query string) filteredSearch {
+	return filteredSearch{
+		query:  query,
+		filter: "image ne null or photo ne null",
+		keep: func(item *DriveItem) bool {
+			return item.Image != nil || item.Photo != nil
+		},
+	}
+}
+
+// extensionSearch returns the search of the files with the given extension, lower case and
+// without its dot.
+func extensionSearch(extension string) filteredSearch {
+	return filteredSearch{
+		query:  extension,
+		filter: "file ne null and endswith(name,'." + strings.Replace(extension, "'", "''", -1) + "')",
+		keep: func(item *DriveItem) bool {
+			return item.File != nil && strings.ToLower(path.Ext(item.Name)) == "."+extension
+		},
+	}
+}
+
+// modifiedSinceSearch returns the search of the items modified after since.
+func modifiedSinceSearch(since time.Time) filteredSearch {
+	return filteredSearch{
+		filter: "lastModifiedDateTime gt " + since.UTC().Format(time.RFC3339),
+		keep: func(item *DriveItem) bool {
+			return item.LastModifiedDateTime.After(since)
+		},
+	}
+}
+
+// searchFiltered runs a filtered search in the default drive of the authenticated user,
+// going through all the pages of results.
+//
+// The search endpoint does not support $filter consistently across OneDrive personal and
+// OneDrive for Business, so the drives rejecting the $filter are searched without it, and
+// the items are filtered on the client side instead.
+func (s *DriveSearchService) searchFiltered(ctx context.Context, search filteredSearch) (*OneDriveDriveSearchResponse, error) {
+	// See Search about the quotes.
+	query := strings.Replace(search.query, "'", "''", -1)
+	apiURL := fmt.Sprintf("me/drive/root/search(q='%v')", url.PathEscape(query))
+
+	response, err := s.searchKept(ctx, apiURL+"?$filter="+url.PathEscape(search.filter), nil)
+	if isStatus(err, http.StatusBadRequest) || isStatus(err, http.StatusNotImplemented) {
+		response, err = s.searchKept(ctx, apiURL, search.keep)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// searchKept goes through the pages of search results starting at apiURL, and keeps the
+// items for which keep returns true, or all of them if keep is nil.
+func (s *DriveSearchService) searchKept(ctx context.Context, apiURL string, keep func(*DriveItem) bool) (*OneDriveDriveSearchResponse, error) {
+	response := &OneDriveDriveSearchResponse{}
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		apiURL, err = s.client.stream(ctx, req, func(decode func(target interface{}) error) error {
+			var item *DriveItem
+			if err := decode(&item); err != nil {
+				return err
+			}
+
+			if item != nil && (keep == nil || keep(item)) {
+				response.DriveItems = append(response.DriveItems, item)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDriveSearchService_SearchByExtension(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='pdf')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("$filter"), "file ne null and endswith(name,'.pdf')"; got != want {
+			t.Errorf("Request $filter = %q, want %q", got, want)
+		}
+
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"value": [{"id": "3", "name": "Scan.PDF", "file": {}}]}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"value": [{"id": "1", "name": "report.pdf", "file": {}}], "@odata.nextLink": "%s/me/drive/root/search(q='pdf')?$filter=file+ne+null+and+endswith(name,'.pdf')&page=2"}`, serverURL+baseURLPath)
+	})
+
+	ctx := context.Background()
+	gotResponse, err := client.DriveSearch.SearchByExtension(ctx, ".PDF")
+	if err != nil {
+		t.Fatalf("DriveSearch.SearchByExtension returned error: %v", err)
+	}
+
+	if len(gotResponse.DriveItems) != 2 || gotResponse.DriveItems[0].Id != "1" || gotResponse.DriveItems[1].Id != "3" {
+		t.Errorf("DriveSearch.SearchByExtension returned %+v, want items 1 and 3", gotResponse.DriveItems)
+	}
+}
+
+func TestDriveSearchService_SearchByExtension_filterRejected(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='pdf')", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$filter") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": "invalidRequest", "message": "Invalid filter clause"}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"value": [{"id": "1", "name": "report.pdf", "file": {}}, {"id": "2", "name": "pdf notes.docx", "file": {}}, {"id": "4", "name": "pdf", "folder": {}}]}`)
+	})
+
+	gotResponse, err := client.DriveSearch.SearchByExtension(context.Background(), "pdf")
+	if err != nil {
+		t.Fatalf("DriveSearch.SearchByExtension returned error: %v", err)
+	}
+
+	if len(gotResponse.DriveItems) != 1 || gotResponse.DriveItems[0].Id != "1" {
+		t.Errorf("DriveSearch.SearchByExtension returned %+v, want item 1", gotResponse.DriveItems)
+	}
+}
+
+func TestDriveSearchService_SearchImages(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='')", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("$filter"), "image ne null or photo ne null"; got != want {
+			t.Errorf("Request $filter = %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"value": [{"id": "1", "name": "beach.jpg", "image": {}}, {"id": "3", "name": "sunset.heic", "photo": {}}]}`)
+	})
+
+	gotImages, err := client.DriveSearch.SearchImages(context.Background(), "")
+	if err != nil {
+		t.Fatalf("DriveSearch.SearchImages returned error: %v", err)
+	}
+	if len(gotImages.DriveItems) != 2 || gotImages.DriveItems[0].Id != "1" || gotImages.DriveItems[1].Id != "3" {
+		t.Errorf("DriveSearch.SearchImages returned %+v, want items 1 and 3", gotImages.DriveItems)
+	}
+}
+
+func TestDriveSearchService_SearchModifiedSince(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/search(q='')", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$filter") != "" {
+			w.WriteHeader(http.StatusNotImplemented)
+			fmt.Fprint(w, `{"error": {"code": "notSupported", "message": "Filter is not supported."}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"value": [
+			{"id": "1", "name": "beach.jpg", "image": {}, "lastModifiedDateTime": "2020-06-01T00:00:00Z"},
+			{"id": "2", "name": "plan.docx", "file": {}, "lastModifiedDateTime": "2020-08-01T00:00:00Z"},
+			{"id": "3", "name": "sunset.heic", "photo": {}, "lastModifiedDateTime": "2019-06-01T00:00:00Z"}
+		]}`)
+	})
+
+	gotRecent, err := client.DriveSearch.SearchModifiedSince(context.Background(), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("DriveSearch.SearchModifiedSince returned error: %v", err)
+	}
+	if len(gotRecent.DriveItems) != 2 || gotRecent.DriveItems[0].Id != "1" || gotRecent.DriveItems[1].Id != "2" {
+		t.Errorf("DriveSearch.SearchModifiedSince returned %+v, want items 1 and 2", gotRecent.DriveItems)
+	}
+}
+
+func TestModifiedSinceSearch(t *testing.T) {
+	since := time.Date(2020, 1, 1, 8, 0, 0, 0, time.FixedZone("CET", 3600))
+	if got, want := modifiedSinceSearch(since).filter, "lastModifiedDateTime gt 2020-01-01T07:00:00Z"; got != want {
+		t.Errorf("modifiedSinceSearch returned the filter %q, want %q", got, want)
+	}
+}
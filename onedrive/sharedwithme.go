@@ -0,0 +1,29 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+)
+
+// ListSharedWithMe lists the items other users have shared with the authenticated user. The
+// items are stored in the drives of their owners: their RemoteItem tells the drive and the ID
+// to use to reach them, e.g. with Handle.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_sharedwithme?view=odsp-graph-online
+func (s *DriveItemsService) ListSharedWithMe(ctx context.Context) (*OneDriveDriveItemsResponse, error) {
+	req, err := s.client.NewRequest("GET", "me/drive/sharedWithMe", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_ListSharedWithMe(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/sharedWithMe", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"value": [
+			{"id": "1", "name": "Team Folder", "remoteItem": {
+				"id": "remote-1",
+				"name": "Team Folder",
+				"folder": {"childCount": 4},
+				"parentReference": {"driveId": "their-drive"}
+			}}
+		]}`)
+	})
+
+	got, err := client.DriveItems.ListSharedWithMe(context.Background())
+	if err != nil {
+		t.Fatalf("DriveItems.ListSharedWithMe returned error: %v", err)
+	}
+
+	if len(got.DriveItems) != 1 {
+		t.Fatalf("DriveItems.ListSharedWithMe returned %d items, want 1", len(got.DriveItems))
+	}
+
+	remoteItem := got.DriveItems[0].RemoteItem
+	if remoteItem == nil || remoteItem.Id != "remote-1" || remoteItem.Folder == nil || remoteItem.Folder.ChildCount != 4 ||
+		remoteItem.ParentReference == nil || remoteItem.ParentReference.DriveId != "their-drive" {
+		t.Errorf("DriveItems.ListSharedWithMe returned the remote item %+v", remoteItem)
+	}
+}
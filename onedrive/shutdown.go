@@ -0,0 +1,45 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"sync"
+)
+
+// Shutdowner is implemented by the components working in the background, such as
+// LargeUpload, FolderWatcher, and the Handler and Manager of the webhook package, so a
+// service embedding them can stop them all cleanly, e.g. with ShutdownAll.
+//
+// Shutdown stops the component once the work in flight is over and its state has been
+// kept, e.g. the upload session of a LargeUpload. If ctx is done before, the component
+// stops right away and the error of ctx is returned. Shutting down a component twice has
+// no further effect.
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// ShutdownAll shuts the components down concurrently, and returns the first error, if any,
+// once they have all stopped.
+func ShutdownAll(ctx context.Context, components ...Shutdowner) error {
+	errs := make([]error, len(components))
+
+	var wg sync.WaitGroup
+	for i, component := range components {
+		wg.Add(1)
+		go func(i int, component Shutdowner) {
+			defer wg.Done()
+			errs[i] = component.Shutdown(ctx)
+		}(i, component)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
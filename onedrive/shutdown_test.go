@@ -0,0 +1,82 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShutdownAll(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "shutdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := &FileUploadSessionStore{Path: filepath.Join(dir, "sessions.json")}
+
+	chunks, release, deleted := chunkServer(t, mux, serverURL)
+	mux.HandleFunc("/me/drive/items/folder-1/delta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[],"@odata.deltaLink":"link"}`)
+	})
+
+	ctx := context.Background()
+	file := LargeFile{Name: "big.bin", Size: 10, Data: strings.NewReader("0123456789")}
+	upload, err := client.DriveItems.StartLargeUpload(ctx, "folder-1", file, UploadLargeFileOpts{ChunkSize: 4, SessionStore: store})
+	if err != nil {
+		t.Fatalf("DriveItems.StartLargeUpload returned error: %v", err)
+	}
+	watcher, err := client.DriveItems.WatchFolder(ctx, "folder-1", WatchFolderOpts{PollInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("DriveItems.WatchFolder returned error: %v", err)
+	}
+
+	// The upload is shut down while its first chunk is in flight.
+	<-chunks
+	shutdown := make(chan error)
+	go func() {
+		shutdown <- ShutdownAll(ctx, upload, watcher)
+	}()
+	for suspended := false; !suspended; time.Sleep(time.Millisecond) {
+		upload.mu.Lock()
+		suspended = upload.suspended
+		upload.mu.Unlock()
+	}
+	release <- struct{}{}
+
+	if err := <-shutdown; err != nil {
+		t.Fatalf("ShutdownAll returned error: %v", err)
+	}
+
+	if _, err := upload.Wait(); !errors.Is(err, ErrUploadSuspended) {
+		t.Errorf("LargeUpload.Wait returned error %v, want %v", err, ErrUploadSuspended)
+	}
+	if got := upload.Uploaded(); got != 4 {
+		t.Errorf("LargeUpload.Uploaded returned %d, want 4", got)
+	}
+	select {
+	case <-deleted:
+		t.Error("The upload session of the suspended upload has been deleted")
+	default:
+	}
+	if records, _ := store.List(ctx); len(records) != 1 || records[0].UploadURL != upload.Session().UploadUrl {
+		t.Errorf("The store holds %+v, want the session of the suspended upload", records)
+	}
+	if _, ok := <-watcher.Changes(); ok {
+		t.Error("The changes are still sent after the watcher has been shut down")
+	}
+}
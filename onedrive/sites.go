@@ -0,0 +1,53 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import "context"
+
+// SitesService handles communication with the SharePoint sites related methods of the Microsoft Graph API.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/site?view=graph-rest-1.0
+type SitesService service
+
+// OneDriveSitesResponse represents the JSON object containing site list returned by the Microsoft Graph API.
+type OneDriveSitesResponse struct {
+	ODataContext string  `json:"@odata.context"`
+	Sites        []*Site `json:"value"`
+	// NextLink is the URL of the next page of sites, if any.
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// HasMore reports whether the sites are followed by another page, see NextLink.
+func (response *OneDriveSitesResponse) HasMore() bool {
+	return response.NextLink != ""
+}
+
+// Site represents a SharePoint site.
+type Site struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	WebURL      string `json:"webUrl"`
+}
+
+// ListFollowed lists the SharePoint sites followed by the authenticated user.
+// ErrNotSupportedOnPersonal is returned for users of OneDrive personal.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/sites-list-followed?view=graph-rest-1.0
+func (s *SitesService) ListFollowed(ctx context.Context) (*OneDriveSitesResponse, error) {
+	req, err := s.client.NewRequest("GET", "me/followedSites", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveSitesResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, s.client.Drives.checkPersonalSupport(ctx, err)
+	}
+
+	return oneDriveResponse, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSitesService_ListFollowed_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/followedSites", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		jsonData := getTestDataFromFile(t, "fake_followedSites.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Sites.ListFollowed(ctx)
+	if err != nil {
+		t.Errorf("Sites.ListFollowed returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveSitesResponse
+	json.Unmarshal(getTestDataFromFile(t, "fake_followedSites.json"), &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Sites.ListFollowed returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+}
+
+func TestSitesService_ListFollowed_personalDrive(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/followedSites", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"code": "BadRequest", "message": "Unsupported request."}}`)
+	})
+	mux.HandleFunc("/me/drive", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		jsonData := getTestDataFromFile(t, "fake_defaultDrive.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	_, err := client.Sites.ListFollowed(ctx)
+	if !errors.Is(err, ErrNotSupportedOnPersonal) {
+		t.Errorf("Sites.ListFollowed returned error %v, want %v", err, ErrNotSupportedOnPersonal)
+	}
+
+	var oneDriveError *Error
+	if !errors.As(err, &oneDriveError) || oneDriveError.StatusCode != http.StatusBadRequest {
+		t.Errorf("Sites.ListFollowed returned error %v, want it to wrap the 400 error of the API", err)
+	}
+}
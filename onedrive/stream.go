@@ -0,0 +1,197 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+)
+
+// ListFunc lists the items of a folder in the default drive of the authenticated user with
+// options, and calls fn for every item as soon as it is decoded, following all the pages of
+// the listing. Unlike ListWithOpts, the pages are never held in memory as a whole, which
+// keeps the memory usage low for folders with a huge number of items.
+//
+// If folderId is empty, it means the items at the root of the default drive will be listed.
+// The listing stops at the first error returned by fn, which is then returned.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ListFunc(ctx context.Context, folderId string, opts ListOpts, fn func(*DriveItem) error) error {
+	if fn == nil {
+		return errors.New("Please provide the function to call for every item.")
+	}
+
+	apiURL, err := listURL(folderId, opts)
+	if err != nil {
+		return err
+	}
+
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return err
+		}
+
+		apiURL, err = s.client.stream(ctx, req, func(decode func(target interface{}) error) error {
+			var item *DriveItem
+			if err := decode(&item); err != nil {
+				return err
+			}
+
+			return fn(item)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stream sends an API request for a collection, and calls decodeValue for every element of
+// the "value" array of the response, with the function decoding the element into a target,
+// like Do decodes a response: the raw JSON is retained and the element is compared with the
+// target, see WithRawJSON and WithStrictDecoding. The "@odata.nextLink" of the response, if
+// any, is returned.
+func (c *Client) stream(ctx context.Context, req *http.Request, decodeValue func(decode func(target interface{}) error) error) (string, error) {
+	nextLink, _, err := c.streamSize(ctx, req, decodeValue)
+	return nextLink, err
+}
+
+// streamSize streams a collection like stream, and also returns the size of the response
+// body, in bytes.
+func (c *Client) streamSize(ctx context.Context, req *http.Request, decodeValue func(decode func(target interface{}) error) error) (string, int64, error) {
+	if ctx == nil {
+		return "", 0, errors.New("context must be non-nil")
+	}
+	req = req.WithContext(ctx)
+	c.setAcceptLanguage(ctx, req)
+
+	// A coalesced response is shared, so it is read as a whole, like by Do.
+	var (
+		statusCode int
+		body       io.Reader
+	)
+	if key, ok := c.coalescingKey(req, false); ok {
+		resp := c.coalesce(ctx, key, func() *receivedResponse {
+			return c.receive(ctx, req, false)
+		})
+		if resp.err != nil {
+			return "", 0, resp.err
+		}
+		statusCode, body = resp.statusCode, bytes.NewReader(resp.body)
+	} else {
+		resp, err := c.open(ctx, req, false)
+		if err != nil {
+			return "", 0, err
+		}
+		defer resp.Body.Close()
+		statusCode, body = resp.StatusCode, resp.Body
+	}
+
+	if statusCode >= 300 {
+		responseBody, err := ioutil.ReadAll(body)
+		if err != nil {
+			return "", 0, err
+		}
+		if err := responseError(statusCode, responseBody); err != nil {
+			return "", 0, err
+		}
+
+		return "", 0, fmt.Errorf("unexpected response status %v", statusCode)
+	}
+
+	decoder := json.NewDecoder(body)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return "", 0, err
+	}
+
+	// The drifts of the elements are reported once for the page.
+	unknown, missing := make(map[string]bool), make(map[string]bool)
+	decode := func(target interface{}) error {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return err
+		}
+		if c.rawJSON {
+			if err := retainRawJSON(target, raw); err != nil {
+				return err
+			}
+		}
+		if c.strictDecoding {
+			var data interface{}
+			if err := json.Unmarshal(raw, &data); err == nil {
+				compareSchema(reflect.TypeOf(target), data, "value[]", unknown, missing)
+			}
+		}
+		return nil
+	}
+
+	var nextLink string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", 0, err
+		}
+
+		switch token {
+		case "value":
+			if err := expectDelim(decoder, '['); err != nil {
+				return "", 0, err
+			}
+			for decoder.More() {
+				if err := decodeValue(decode); err != nil {
+					return "", 0, err
+				}
+			}
+			if err := expectDelim(decoder, ']'); err != nil {
+				return "", 0, err
+			}
+		case "@odata.nextLink":
+			if err := decoder.Decode(&nextLink); err != nil {
+				return "", 0, err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return "", 0, err
+			}
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return "", 0, err
+	}
+
+	if err := c.reportDrift(req, unknown, missing); err != nil {
+		return "", 0, err
+	}
+
+	return nextLink, decoder.InputOffset(), nil
+}
+
+// expectDelim reads the next token of the decoder, which must be the given delimiter.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("unexpected JSON token %v, want %v", token, delim)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_ListFunc(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"value": [{"id": "3", "name": "C"}]}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"@odata.context": "context", "value": [{"id": "1", "name": "A"}, {"id": "2", "name": "B"}], "@odata.nextLink": "%s/me/drive/root/children?page=2"}`, serverURL+baseURLPath)
+	})
+
+	var gotIds []string
+	ctx := context.Background()
+	err := client.DriveItems.ListFunc(ctx, "", ListOpts{}, func(item *DriveItem) error {
+		gotIds = append(gotIds, item.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.ListFunc returned error: %v", err)
+	}
+
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(gotIds, want) {
+		t.Errorf("DriveItems.ListFunc listed %v, want %v", gotIds, want)
+	}
+
+	stop := errors.New("stop")
+	err = client.DriveItems.ListFunc(ctx, "", ListOpts{}, func(item *DriveItem) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("DriveItems.ListFunc returned error %v, want %v", err, stop)
+	}
+}
+
+func TestDriveItemsService_ListFunc_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/unknown/children", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "Item not found"}}`)
+	})
+
+	err := client.DriveItems.ListFunc(context.Background(), "unknown", ListOpts{}, func(item *DriveItem) error {
+		return nil
+	})
+
+	var oneDriveError *Error
+	if !errors.As(err, &oneDriveError) || oneDriveError.StatusCode != http.StatusNotFound {
+		t.Errorf("DriveItems.ListFunc returned error %v, want a 404 error", err)
+	}
+}
+
+func TestDriveItemsService_ListFunc_pipeline(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithRequestCoalescing(time.Minute)(client)
+
+	var requests int
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"value": [{"id": "1", "name": "A"}]}`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		err := client.DriveItems.ListFunc(ctx, "", ListOpts{}, func(item *DriveItem) error {
+			if item.Id != "1" {
+				t.Errorf("DriveItems.ListFunc listed %+v, want item 1", item)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("DriveItems.ListFunc returned error: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("DriveItems.ListFunc sent %d requests, want 1 coalesced request", requests)
+	}
+	if got := len(client.Diagnostics().Requests); got != 1 {
+		t.Errorf("Diagnostics holds %d requests, want 1", got)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithStrictDecoding makes the client compare every response decoded by Do, and every item
+// of the listings which are streamed, such as by ListFunc, with the type it is decoded into,
+// so a change of the Microsoft Graph schema is noticed early. A drift is found when the
+// response has properties which are not modeled, or lacks the properties which are always
+// expected, such as the ID of a DriveItem. The annotations, such as "@odata.context", are
+// not compared. The drifts of the items of a page of a streamed listing are reported at
+// once, like for a page decoded by Do.
+//
+// If onDrift is nil, the request fails with the *DecodingDrift. Otherwise, onDrift is called
+// with it, e.g. to log it, and the request fails only if onDrift returns an error. The
+// response is decoded in any case.
+func WithStrictDecoding(onDrift func(drift *DecodingDrift) error) ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+		c.onDecodingDrift = onDrift
+	}
+}
+
+// DecodingDrift describes how a response differs from the type it is decoded into, see
+// WithStrictDecoding. The properties are given by their path in the response, e.g.
+// "value[].shared".
+type DecodingDrift struct {
+	Method  string
+	URL     string
+	Unknown []string // Properties of the response which are not modeled.
+	Missing []string // Required properties absent from the response.
+}
+
+func (d *DecodingDrift) Error() string {
+	var details []string
+	if len(d.Unknown) > 0 {
+		details = append(details, "unknown properties "+strings.Join(d.Unknown, ", "))
+	}
+	if len(d.Missing) > 0 {
+		details = append(details, "missing properties "+strings.Join(d.Missing, ", "))
+	}
+
+	return "The response to " + d.Method + " " + d.URL + " has " + strings.Join(details, " and ") + "."
+}
+
+// checkDecoding compares a response with the type of target, see WithStrictDecoding.
+func (c *Client) checkDecoding(req *http.Request, target interface{}, responseBody []byte) error {
+	if !c.strictDecoding || target == nil {
+		return nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(responseBody, &data); err != nil {
+		return nil
+	}
+
+	unknown, missing := make(map[string]bool), make(map[string]bool)
+	compareSchema(reflect.TypeOf(target), data, "", unknown, missing)
+	return c.reportDrift(req, unknown, missing)
+}
+
+// reportDrift reports the properties found by compareSchema, if any, see WithStrictDecoding.
+func (c *Client) reportDrift(req *http.Request, unknown map[string]bool, missing map[string]bool) error {
+	if len(unknown) == 0 && len(missing) == 0 {
+		return nil
+	}
+
+	drift := &DecodingDrift{
+		Method:  req.Method,
+		URL:     sanitizeURL(req.URL).Path,
+		Unknown: sortedKeys(unknown),
+		Missing: sortedKeys(missing),
+	}
+	if c.onDecodingDrift != nil {
+		return c.onDecodingDrift(drift)
+	}
+	return drift
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// requiredProperties are the properties every response decoded into a type is expected to
+// have, by type.
+var requiredProperties = map[reflect.Type][]string{
+	reflect.TypeOf(DriveItem{}):  {"id"},
+	reflect.TypeOf(Drive{}):      {"id"},
+	reflect.TypeOf(Permission{}): {"id"},
+}
+
+// compareSchema records the properties of data which t does not model into unknown, and the
+// required properties of t which data lacks into missing.
+func compareSchema(t reflect.Type, data interface{}, path string, unknown map[string]bool, missing map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// The types decoding themselves, such as time.Time, are trusted.
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		values, ok := data.([]interface{})
+		if !ok {
+			return
+		}
+		for _, value := range values {
+			compareSchema(t.Elem(), value, path+"[]", unknown, missing)
+		}
+	case reflect.Struct:
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		fields := make(map[string]reflect.StructField)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if name, ok := jsonFieldName(field); ok {
+				fields[strings.ToLower(name)] = field
+			}
+		}
+
+		for _, name := range requiredProperties[t] {
+			if _, present := object[name]; !present {
+				missing[joinSchemaPath(path, name)] = true
+			}
+		}
+
+		for name, value := range object {
+			field, ok := fields[strings.ToLower(name)]
+			switch {
+			case ok:
+				compareSchema(field.Type, value, joinSchemaPath(path, name), unknown, missing)
+			case !strings.Contains(name, "@"):
+				unknown[joinSchemaPath(path, name)] = true
+			}
+		}
+	}
+}
+
+// jsonFieldName returns the name of the JSON property of a struct field, or false if the
+// field is not decoded.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+func joinSchemaPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
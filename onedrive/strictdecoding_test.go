@@ -0,0 +1,118 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWithStrictDecoding(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/known", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"@odata.context": "ctx", "@microsoft.graph.downloadUrl": "https://example.com", "id": "known", "name": "a.txt",
+			"lastModifiedDateTime": "2020-01-01T00:00:00Z", "file": {"hashes": {"quickXorHash": "abc"}}}`)
+	})
+	mux.HandleFunc("/me/drive/items/drifted", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "a.txt", "shared": {}, "file": {"hashes": {"quickXorHash": "abc", "newHash": "def"}}}`)
+	})
+
+	WithStrictDecoding(nil)(client)
+
+	ctx := context.Background()
+	if _, err := client.DriveItems.Get(ctx, "known"); err != nil {
+		t.Errorf("DriveItems.Get of a modeled item returned error: %v", err)
+	}
+
+	_, err := client.DriveItems.Get(ctx, "drifted")
+	drift, ok := err.(*DecodingDrift)
+	if !ok {
+		t.Fatalf("DriveItems.Get of a drifted item returned error %v, want a *DecodingDrift", err)
+	}
+
+	want := &DecodingDrift{
+		Method:  "GET",
+		URL:     baseURLPath + "/me/drive/items/drifted",
+		Unknown: []string{"file.hashes.newHash", "shared"},
+		Missing: []string{"id"},
+	}
+	if !reflect.DeepEqual(drift, want) {
+		t.Errorf("DriveItems.Get returned drift %+v, want %+v", drift, want)
+	}
+}
+
+func TestWithStrictDecoding_report(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "1", "shared": {}}, {"id": "2", "shared": {}}]}`)
+	})
+
+	var drifts []*DecodingDrift
+	WithStrictDecoding(func(drift *DecodingDrift) error {
+		drifts = append(drifts, drift)
+		return nil
+	})(client)
+
+	got, err := client.DriveItems.List(context.Background(), "")
+	if err != nil {
+		t.Fatalf("DriveItems.List returned error: %v", err)
+	}
+
+	if len(got.DriveItems) != 2 {
+		t.Errorf("DriveItems.List returned %d items, want 2", len(got.DriveItems))
+	}
+	if len(drifts) != 1 || !reflect.DeepEqual(drifts[0].Unknown, []string{"value[].shared"}) {
+		t.Errorf("The reported drifts are %+v, want value[].shared once", drifts)
+	}
+}
+
+func TestWithStrictDecoding_stream(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "1", "shared": {}}, {"name": "b.txt"}]}`)
+	})
+
+	var drifts []*DecodingDrift
+	WithStrictDecoding(func(drift *DecodingDrift) error {
+		drifts = append(drifts, drift)
+		return nil
+	})(client)
+
+	var items []*DriveItem
+	err := client.DriveItems.ListFunc(context.Background(), "", ListOpts{}, func(item *DriveItem) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.ListFunc returned error: %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Errorf("DriveItems.ListFunc listed %d items, want 2", len(items))
+	}
+	if len(drifts) != 1 || !reflect.DeepEqual(drifts[0].Unknown, []string{"value[].shared"}) || !reflect.DeepEqual(drifts[0].Missing, []string{"value[].id"}) {
+		t.Errorf("The reported drifts are %+v, want value[].shared and value[].id once", drifts)
+	}
+
+	WithStrictDecoding(nil)(client)
+	err = client.DriveItems.ListFunc(context.Background(), "", ListOpts{}, func(item *DriveItem) error {
+		return nil
+	})
+	if _, ok := err.(*DecodingDrift); !ok {
+		t.Errorf("DriveItems.ListFunc returned error %v, want a *DecodingDrift", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// SubscriptionsService handles communication with the change notification subscriptions related
+// methods of the Microsoft Graph API. The notifications are delivered to a webhook, such as the
+// one provided by the webhook package.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/using-webhooks?view=odsp-graph-online
+type SubscriptionsService service
+
+// Subscription represents a subscription to the change notifications of a resource.
+type Subscription struct {
+	Id                       string    `json:"id,omitempty"`
+	Resource                 string    `json:"resource,omitempty"`
+	ChangeType               string    `json:"changeType,omitempty"`
+	NotificationURL          string    `json:"notificationUrl,omitempty"`
+	LifecycleNotificationURL string    `json:"lifecycleNotificationUrl,omitempty"`
+	ClientState              string    `json:"clientState,omitempty"`
+	ExpirationDateTime       time.Time `json:"expirationDateTime"`
+}
+
+// Create a subscription to the change notifications of a resource, e.g. "me/drive/root".
+// Microsoft Graph validates the notification URL before the subscription is created.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/subscription_create?view=odsp-graph-online
+func (s *SubscriptionsService) Create(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	if subscription == nil || subscription.Resource == "" || subscription.NotificationURL == "" {
+		return nil, errors.New("Please provide the resource and the notification URL of the subscription.")
+	}
+
+	if subscription.ChangeType == "" {
+		subscription.ChangeType = "updated"
+	}
+
+	req, err := s.client.NewRequest("POST", "subscriptions", subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *Subscription
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Renew a subscription by extending its expiration time.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/subscription_update?view=odsp-graph-online
+func (s *SubscriptionsService) Renew(ctx context.Context, subscriptionId string, expirationDateTime time.Time) (*Subscription, error) {
+	if subscriptionId == "" {
+		return nil, errors.New("Please provide the ID of the subscription.")
+	}
+
+	req, err := s.client.NewRequest("PATCH", "subscriptions/"+url.PathEscape(subscriptionId), &Subscription{ExpirationDateTime: expirationDateTime})
+	if err != nil {
+		return nil, err
+	}
+
+	var response *Subscription
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Delete a subscription, so that no more notification is delivered for it.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/subscription_delete?view=odsp-graph-online
+func (s *SubscriptionsService) Delete(ctx context.Context, subscriptionId string) error {
+	if subscriptionId == "" {
+		return errors.New("Please provide the ID of the subscription.")
+	}
+
+	req, err := s.client.NewRequest("DELETE", "subscriptions/"+url.PathEscape(subscriptionId), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Do(ctx, req, false, nil)
+}
+
+// Reauthorize a subscription after Microsoft Graph sent a reauthorizationRequired lifecycle
+// notification for it, so that the delivery of its change notifications goes on.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/subscription-reauthorize?view=graph-rest-1.0
+func (s *SubscriptionsService) Reauthorize(ctx context.Context, subscriptionId string) error {
+	if subscriptionId == "" {
+		return errors.New("Please provide the ID of the subscription.")
+	}
+
+	req, err := s.client.NewRequest("POST", "subscriptions/"+url.PathEscape(subscriptionId)+"/reauthorize", nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Do(ctx, req, false, nil)
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionsService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body Subscription
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Cannot decode the request body: %v", err)
+		}
+
+		if got, want := body.ChangeType, "updated"; got != want {
+			t.Errorf("Subscription change type: %v, want %v", got, want)
+		}
+
+		body.Id = "subscription-1"
+		json.NewEncoder(w).Encode(body)
+	})
+
+	ctx := context.Background()
+	gotSubscription, err := client.Subscriptions.Create(ctx, &Subscription{
+		Resource:           "me/drive/root",
+		NotificationURL:    "https://example.com/webhook",
+		ClientState:        "secret",
+		ExpirationDateTime: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Subscriptions.Create returned error: %v", err)
+	}
+
+	if got, want := gotSubscription.Id, "subscription-1"; got != want {
+		t.Errorf("Subscriptions.Create returned ID %v, want %v", got, want)
+	}
+}
+
+func TestSubscriptionsService_Renew(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	expiration := time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	mux.HandleFunc("/subscriptions/subscription-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Cannot decode the request body: %v", err)
+		}
+
+		if len(body) != 1 || body["expirationDateTime"] != "2020-01-03T00:00:00Z" {
+			t.Errorf("Request body: %v, want only the expiration time", body)
+		}
+
+		fmt.Fprint(w, `{"id": "subscription-1", "expirationDateTime": "2020-01-03T00:00:00Z"}`)
+	})
+
+	ctx := context.Background()
+	gotSubscription, err := client.Subscriptions.Renew(ctx, "subscription-1", expiration)
+	if err != nil {
+		t.Fatalf("Subscriptions.Renew returned error: %v", err)
+	}
+
+	if !gotSubscription.ExpirationDateTime.Equal(expiration) {
+		t.Errorf("Subscriptions.Renew returned expiration %v, want %v", gotSubscription.ExpirationDateTime, expiration)
+	}
+}
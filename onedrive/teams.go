@@ -0,0 +1,57 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// TeamsService handles communication with the Microsoft Teams related methods of the Microsoft Graph API
+// which give access to the files of a team.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/channel?view=graph-rest-1.0
+type TeamsService service
+
+// GetChannelFilesFolder gets the folder where the files of a Teams channel are stored, together
+// with the drive (a SharePoint document library) backing it. The returned folder and drive can
+// then be used with the other DriveItems operations, e.g. to upload files into the channel.
+// ErrNotSupportedOnPersonal is returned for users of OneDrive personal.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/channel-get-filesfolder?view=graph-rest-1.0
+func (s *TeamsService) GetChannelFilesFolder(ctx context.Context, teamId string, channelId string) (*DriveItem, *Drive, error) {
+	if teamId == "" {
+		return nil, nil, errors.New("Please provide the ID of the team.")
+	}
+
+	if channelId == "" {
+		return nil, nil, errors.New("Please provide the ID of the channel.")
+	}
+
+	apiURL := "teams/" + url.PathEscape(teamId) + "/channels/" + url.PathEscape(channelId) + "/filesFolder"
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var filesFolder *DriveItem
+	err = s.client.Do(ctx, req, false, &filesFolder)
+	if err != nil {
+		return nil, nil, s.client.Drives.checkPersonalSupport(ctx, err)
+	}
+
+	if filesFolder.ParentReference == nil || filesFolder.ParentReference.DriveId == "" {
+		return nil, nil, errors.New("The files folder of the channel does not refer to its drive.")
+	}
+
+	drive, err := s.client.Drives.Get(ctx, filesFolder.ParentReference.DriveId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return filesFolder, drive, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestTeamsService_GetChannelFilesFolder(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/teams/team-1/channels/channel-1/filesFolder", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "folder-1", "name": "General", "folder": {"childCount": 0}, "parentReference": {"driveId": "drive-1"}}`)
+	})
+	mux.HandleFunc("/drives/drive-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "drive-1", "driveType": "documentLibrary"}`)
+	})
+
+	ctx := context.Background()
+	gotFolder, gotDrive, err := client.Teams.GetChannelFilesFolder(ctx, "team-1", "channel-1")
+	if err != nil {
+		t.Errorf("Teams.GetChannelFilesFolder returned error: %v", err)
+	}
+
+	if gotFolder == nil || gotFolder.Id != "folder-1" {
+		t.Errorf("Teams.GetChannelFilesFolder returned folder %+v, want %q", gotFolder, "folder-1")
+	}
+
+	if gotDrive == nil || gotDrive.Id != "drive-1" {
+		t.Errorf("Teams.GetChannelFilesFolder returned drive %+v, want %q", gotDrive, "drive-1")
+	}
+}
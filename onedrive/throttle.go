@@ -0,0 +1,121 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultThrottlingDelay is the delay waited after a throttled response without Retry-After.
+const defaultThrottlingDelay = 5 * time.Second
+
+// WithThrottlingBackoff makes the client honor the Retry-After header of the responses
+// throttled by OneDrive (429 Too Many Requests and 503 Service Unavailable): the following
+// requests to the same drive wait until the delay has passed before being sent.
+//
+// Microsoft Graph throttles per user, tenant and drive, so the state is kept per target
+// drive: a throttled business drive does not stall the requests to another drive handled by
+// the same client. The throttled response itself is still returned to the caller.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/scan-guidance?view=odsp-graph-online#what-happens-when-you-get-throttled
+func WithThrottlingBackoff() ClientOption {
+	return func(c *Client) {
+		c.throttledUntil = make(map[string]time.Time)
+	}
+}
+
+// waitThrottling blocks until the drive targeted by the request is no longer throttled.
+func (c *Client) waitThrottling(ctx context.Context, req *http.Request) error {
+	c.throttleMu.Lock()
+	until, ok := c.throttledUntil[throttlingKey(req)]
+	c.throttleMu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	delay := until.Sub(c.now())
+	if delay <= 0 {
+		return nil
+	}
+
+	return c.sleep(ctx, delay)
+}
+
+// recordThrottling remembers until when the drive targeted by the request is throttled, if
+// the response says it is.
+func (c *Client) recordThrottling(req *http.Request, resp *http.Response) {
+	if c.throttledUntil == nil {
+		return
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return
+	}
+
+	now := c.now()
+	c.throttle(throttlingKey(req), now.Add(retryAfter(resp.Header.Get("Retry-After"), now)))
+}
+
+// throttle remembers that a drive, see throttlingKey, is throttled until the given time.
+func (c *Client) throttle(key string, until time.Time) {
+	if c.throttledUntil == nil {
+		return
+	}
+
+	c.throttleMu.Lock()
+	defer c.throttleMu.Unlock()
+
+	if until.After(c.throttledUntil[key]) {
+		c.throttledUntil[key] = until
+	}
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a number of seconds
+// or an HTTP date.
+func retryAfter(value string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now)
+	}
+
+	return defaultThrottlingDelay
+}
+
+// throttlingKey returns the drive targeted by a request, e.g. "drives/b!xyz" or "me/drive",
+// or an empty string for the requests which do not target a drive.
+func throttlingKey(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "root:") || strings.HasPrefix(segment, "items") {
+			break
+		}
+
+		switch segment {
+		case "drives":
+			if i+1 < len(segments) {
+				return "drives/" + segments[i+1]
+			}
+		case "drive":
+			// The owner of the drive, e.g. "me" or "users/{id}".
+			start := i - 2
+			if start < 0 || segments[i-1] == "me" {
+				start = i - 1
+			}
+			if start >= 0 {
+				return strings.Join(segments[start:i+1], "/")
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestClient_WithThrottlingBackoff(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithThrottlingBackoff()(client)
+
+	busyRequests := 0
+	mux.HandleFunc("/me/drives/busy/items/1", func(w http.ResponseWriter, r *http.Request) {
+		busyRequests++
+
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error": {"code": "activityLimitReached", "message": "Throttled"}}`)
+	})
+	mux.HandleFunc("/me/drives/idle/items/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if err := client.DriveItems.Delete(ctx, "busy", "1"); !isStatus(err, http.StatusTooManyRequests) {
+		t.Fatalf("DriveItems.Delete returned error %v, want a 429 error", err)
+	}
+
+	// The other drive is not throttled.
+	start := time.Now()
+	if err := client.DriveItems.Delete(ctx, "idle", "1"); err != nil {
+		t.Errorf("DriveItems.Delete returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Request to another drive waited %v", elapsed)
+	}
+
+	// The throttled drive is not requested again before Retry-After.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := client.DriveItems.Delete(timeoutCtx, "busy", "1"); err != context.DeadlineExceeded {
+		t.Errorf("DriveItems.Delete returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if busyRequests != 1 {
+		t.Errorf("Throttled drive received %d requests, want 1", busyRequests)
+	}
+}
+
+func TestThrottlingKey(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://graph.microsoft.com/v1.0/me/drive/items/1", "me/drive"},
+		{"https://graph.microsoft.com/v1.0/me/drive/root:/drive/file.txt:/content", "me/drive"},
+		{"https://graph.microsoft.com/v1.0/me/drives/b!xyz/items/1", "drives/b!xyz"},
+		{"https://graph.microsoft.com/v1.0/users/42/drive/root", "users/42/drive"},
+		{"https://graph.microsoft.com/v1.0/subscriptions", ""},
+	}
+
+	for _, test := range tests {
+		u, _ := url.Parse(test.url)
+		if got := throttlingKey(&http.Request{URL: u}); got != test.want {
+			t.Errorf("throttlingKey(%q) = %q, want %q", test.url, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ThumbnailSet represents the thumbnails of a drive item in the supported sizes.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/thumbnailset?view=odsp-graph-online
+type ThumbnailSet struct {
+	Id     string     `json:"id"`
+	Small  *Thumbnail `json:"small"`
+	Medium *Thumbnail `json:"medium"`
+	Large  *Thumbnail `json:"large"`
+}
+
+// Thumbnail represents a thumbnail of a drive item. Its URL is only valid for a short time.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/thumbnail?view=odsp-graph-online
+type Thumbnail struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+}
+
+// GetThumbnail downloads the content of a thumbnail of a drive item, e.g. to display it in
+// a gallery. If the client has been created with WithThumbnailCache, the thumbnail is served
+// from the cache when the item has not changed since it was cached.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_thumbnails?view=odsp-graph-online#retrieve-thumbnail-content
+func (s *DriveItemsService) GetThumbnail(ctx context.Context, item *DriveItem, size ThumbnailSize) ([]byte, error) {
+	if item == nil || item.Id == "" {
+		return nil, errors.New("Please provide the item to get the thumbnail of.")
+	}
+
+	if !size.isValid() {
+		return nil, errors.New("Please provide a supported thumbnail size.")
+	}
+
+	cache := s.client.thumbnailCache
+	cacheKey := ""
+	if cache != nil && item.CTag != "" {
+		// The cTag changes with the content of the item, so a cached thumbnail never
+		// outlives the content it was made from.
+		cacheKey = item.Id + "\x00" + item.CTag + "\x00" + size.toString()
+		if data, ok := cache.get(cacheKey, s.client.now()); ok {
+			return data, nil
+		}
+	}
+
+	apiURL := "me/drive/items/" + url.PathEscape(item.Id) + "/thumbnails/0/" + size.toString()
+	if item.ParentReference != nil && item.ParentReference.DriveId != "" {
+		apiURL = "drives/" + url.PathEscape(item.ParentReference.DriveId) + "/items/" + url.PathEscape(item.Id) + "/thumbnails/0/" + size.toString()
+	}
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var thumbnail *Thumbnail
+	if err := s.client.Do(ctx, req, false, &thumbnail); err != nil {
+		return nil, err
+	}
+	if thumbnail == nil || thumbnail.URL == "" {
+		return nil, errors.New("The thumbnail has no URL to download it from.")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, "GET", s.client.downloadURL(thumbnail.URL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The URL of the thumbnail is pre-authenticated.
+	resp := s.client.receive(ctx, req, true)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	if resp.statusCode != 200 {
+		if err := responseError(resp.statusCode, resp.body); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unexpected response status %v", resp.statusCode)
+	}
+	data := resp.body
+
+	if cacheKey != "" {
+		// A failure to cache the thumbnail does not prevent it from being returned.
+		cache.put(cacheKey, data, s.client.now())
+	}
+
+	return data, nil
+}
+
+// ThumbnailCache is an on-disk cache of the thumbnails downloaded by GetThumbnail, keyed
+// by item ID, cTag and size. It can be shared by several clients.
+type ThumbnailCache struct {
+	// Dir is the directory the thumbnails are stored in. It is created when needed.
+	Dir string
+	// TTL is how long a thumbnail which is not viewed is kept in the cache. Zero means until
+	// it is evicted.
+	TTL time.Duration
+	// MaxBytes bounds the total size of the cached thumbnails; the least recently used
+	// ones are evicted first. Zero means unbounded.
+	MaxBytes int64
+
+	mu sync.Mutex
+}
+
+// WithThumbnailCache makes GetThumbnail serve thumbnails from the given cache, so gallery
+// applications do not download them again on every view.
+func WithThumbnailCache(cache *ThumbnailCache) ClientOption {
+	return func(c *Client) {
+		c.thumbnailCache = cache
+	}
+}
+
+// path returns the file of a cache key.
+func (cache *ThumbnailCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(cache.Dir, hex.EncodeToString(sum[:])+".thumbnail")
+}
+
+// get returns the cached thumbnail of a key, if it is still fresh at now.
+func (cache *ThumbnailCache) get(key string, now time.Time) ([]byte, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	filePath := cache.path(key)
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, false
+	}
+
+	// The modification time of a thumbnail is when it was last served.
+	if cache.TTL > 0 && now.Sub(fileInfo.ModTime()) > cache.TTL {
+		os.Remove(filePath)
+		return nil, false
+	}
+
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, false
+	}
+
+	os.Chtimes(filePath, now, now)
+
+	return data, true
+}
+
+// put stores the thumbnail of a key, as used at now, then evicts the least recently used
+// thumbnails beyond MaxBytes.
+func (cache *ThumbnailCache) put(key string, data []byte, now time.Time) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if err := os.MkdirAll(cache.Dir, 0755); err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(cache.Dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chtimes(tempFile.Name(), now, now); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tempFile.Name(), cache.path(key)); err != nil {
+		return err
+	}
+
+	return cache.evict()
+}
+
+// evict removes the least recently used thumbnails until the cache fits in MaxBytes.
+func (cache *ThumbnailCache) evict() error {
+	if cache.MaxBytes <= 0 {
+		return nil
+	}
+
+	fileInfos, err := ioutil.ReadDir(cache.Dir)
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		path     string
+		size     int64
+		lastUsed time.Time
+	}
+
+	var entries []entry
+	var total int64
+	for _, fileInfo := range fileInfos {
+		if !strings.HasSuffix(fileInfo.Name(), ".thumbnail") {
+			continue
+		}
+
+		entries = append(entries, entry{path: filepath.Join(cache.Dir, fileInfo.Name()), size: fileInfo.Size(), lastUsed: fileInfo.ModTime()})
+		total += fileInfo.Size()
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].lastUsed.Before(entries[j].lastUsed)
+	})
+
+	for _, e := range entries {
+		if total <= cache.MaxBytes {
+			break
+		}
+		if err := os.Remove(e.path); err != nil {
+			return err
+		}
+		total -= e.size
+	}
+
+	return nil
+}
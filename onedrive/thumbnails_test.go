@@ -0,0 +1,194 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_GetThumbnail(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/drive-1/items/item-1/thumbnails/0/medium", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"width": 176, "height": 176, "url": "%s%s/thumbnails/medium"}`, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/thumbnails/medium", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "thumbnail")
+	})
+
+	item := &DriveItem{Id: "item-1", ParentReference: &ParentReference{DriveId: "drive-1"}}
+	got, err := client.DriveItems.GetThumbnail(context.Background(), item, MediumThumbnail)
+	if err != nil {
+		t.Fatalf("DriveItems.GetThumbnail returned error: %v", err)
+	}
+	if string(got) != "thumbnail" {
+		t.Errorf("DriveItems.GetThumbnail returned %q, want %q", got, "thumbnail")
+	}
+}
+
+func TestDriveItemsService_GetThumbnail_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/item-1/thumbnails/0/small", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+
+	_, err := client.DriveItems.GetThumbnail(context.Background(), &DriveItem{Id: "item-1"}, SmallThumbnail)
+	if !isNotFound(err) {
+		t.Errorf("DriveItems.GetThumbnail returned error %v, want not found", err)
+	}
+}
+
+func TestDriveItemsService_GetThumbnail_withThumbnailCache(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "thumbnails")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	WithThumbnailCache(&ThumbnailCache{Dir: dir})(client)
+
+	downloads := 0
+	mux.HandleFunc("/me/drive/items/item-1/thumbnails/0/large", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"url": "%s%s/thumbnails/large"}`, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/thumbnails/large", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		fmt.Fprintf(w, "thumbnail-%d", downloads)
+	})
+
+	ctx := context.Background()
+	item := &DriveItem{Id: "item-1", CTag: "ctag-1"}
+	for i := 0; i < 2; i++ {
+		got, err := client.DriveItems.GetThumbnail(ctx, item, LargeThumbnail)
+		if err != nil {
+			t.Fatalf("DriveItems.GetThumbnail returned error: %v", err)
+		}
+		if string(got) != "thumbnail-1" {
+			t.Errorf("DriveItems.GetThumbnail returned %q, want %q", got, "thumbnail-1")
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("DriveItems.GetThumbnail downloaded %d thumbnails, want 1", downloads)
+	}
+
+	// A new cTag means the content changed.
+	item.CTag = "ctag-2"
+	got, err := client.DriveItems.GetThumbnail(ctx, item, LargeThumbnail)
+	if err != nil {
+		t.Fatalf("DriveItems.GetThumbnail returned error: %v", err)
+	}
+	if string(got) != "thumbnail-2" {
+		t.Errorf("DriveItems.GetThumbnail returned %q, want %q", got, "thumbnail-2")
+	}
+}
+
+func TestThumbnailCache_TTL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thumbnails")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := &ThumbnailCache{Dir: dir, TTL: time.Hour}
+	if err := cache.put("key", []byte("thumbnail"), time.Now()); err != nil {
+		t.Fatalf("ThumbnailCache.put returned error: %v", err)
+	}
+	if _, ok := cache.get("key", time.Now()); !ok {
+		t.Fatalf("ThumbnailCache.get did not return the thumbnail")
+	}
+
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(cache.path("key"), past, past); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.get("key", time.Now()); ok {
+		t.Errorf("ThumbnailCache.get returned an expired thumbnail")
+	}
+	if _, err := os.Stat(cache.path("key")); !os.IsNotExist(err) {
+		t.Errorf("ThumbnailCache.get did not remove the expired thumbnail")
+	}
+}
+
+func TestThumbnailCache_MaxBytes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thumbnails")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := &ThumbnailCache{Dir: dir, MaxBytes: 10}
+	for i, key := range []string{"a", "b"} {
+		if err := cache.put(key, []byte("12345"), time.Now()); err != nil {
+			t.Fatalf("ThumbnailCache.put returned error: %v", err)
+		}
+		lastUsed := time.Now().Add(time.Duration(i-10) * time.Minute)
+		if err := os.Chtimes(cache.path(key), lastUsed, lastUsed); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Viewing "a" makes "b" the least recently used thumbnail.
+	if _, ok := cache.get("a", time.Now()); !ok {
+		t.Fatalf("ThumbnailCache.get did not return the thumbnail")
+	}
+	if err := cache.put("c", []byte("12345"), time.Now()); err != nil {
+		t.Fatalf("ThumbnailCache.put returned error: %v", err)
+	}
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := cache.get(key, time.Now()); ok != want {
+			t.Errorf("ThumbnailCache.get(%q) found %v, want %v", key, ok, want)
+		}
+	}
+}
+
+func TestDriveItemsService_GetThumbnail_withEndpoints(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	endpoints, err := ParseEndpoints(serverURL+baseURLPath+"/gateway", serverURL+baseURLPath+"/content-proxy", "")
+	if err != nil {
+		t.Fatalf("ParseEndpoints returned error: %v", err)
+	}
+	WithEndpoints(endpoints)(client)
+
+	mux.HandleFunc("/gateway/me/drive/items/item-1/thumbnails/0/small", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"url": "https://public.example.com/thumbnails/small?token=x"}`)
+	})
+	mux.HandleFunc("/content-proxy/thumbnails/small", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "thumbnail")
+	})
+
+	got, err := client.DriveItems.GetThumbnail(context.Background(), &DriveItem{Id: "item-1"}, SmallThumbnail)
+	if err != nil {
+		t.Fatalf("DriveItems.GetThumbnail returned error: %v", err)
+	}
+	if string(got) != "thumbnail" {
+		t.Errorf("DriveItems.GetThumbnail returned %q, want %q", got, "thumbnail")
+	}
+	if got := len(client.Diagnostics().Requests); got != 2 {
+		t.Errorf("Diagnostics holds %d requests, want the thumbnail and its download", got)
+	}
+}
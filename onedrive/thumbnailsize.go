@@ -0,0 +1,24 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// ThumbnailSize indicates the size of a thumbnail of a drive item.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_thumbnails?view=odsp-graph-online#size-options
+type ThumbnailSize int
+
+const (
+	SmallThumbnail ThumbnailSize = iota
+	MediumThumbnail
+	LargeThumbnail
+)
+
+func (size ThumbnailSize) toString() string {
+	return [...]string{"small", "medium", "large"}[size]
+}
+
+func (size ThumbnailSize) isValid() bool {
+	return size >= SmallThumbnail && size <= LargeThumbnail
+}
@@ -0,0 +1,210 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The possible states of a file in a TransferJournal.
+const (
+	TransferInFlight  = "inFlight"
+	TransferCompleted = "completed"
+	TransferFailed    = "failed"
+)
+
+// The possible operations of a file in a TransferJournal.
+const (
+	TransferUpload   = "upload"
+	TransferDownload = "download"
+)
+
+// TransferEntry represents the last known state of the transfer of a file.
+type TransferEntry struct {
+	Operation string    `json:"operation"` // TransferUpload or TransferDownload.
+	LocalPath string    `json:"localPath"`
+	State     string    `json:"state"` // TransferInFlight, TransferCompleted or TransferFailed.
+	ItemId    string    `json:"itemId,omitempty"`
+	ETag      string    `json:"eTag,omitempty"` // ETag of the downloaded item.
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"modTime"` // Modification time of the uploaded file.
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// TransferJournal records the progress of the bulk transfers, UploadMany and DownloadMany,
+// into a local file, so a crashed migration of many files restarts from where it stopped:
+// the files whose transfer has been completed are skipped without checking the drive again,
+// as long as they have not changed since. The entries in flight when the process stopped
+// and the failures are transferred again.
+//
+// The file is only appended to, one JSON object per line, so recording the progress of
+// every file stays cheap. It is safe for concurrent use.
+type TransferJournal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]TransferEntry
+}
+
+// OpenTransferJournal opens the journal file at path, creating it if needed, and loads the
+// entries recorded by the previous runs. The journal must be closed once the transfers are
+// over.
+func OpenTransferJournal(path string) (*TransferJournal, error) {
+	if path == "" {
+		return nil, errors.New("Please provide the path of the journal file.")
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &TransferJournal{file: file, entries: make(map[string]TransferEntry)}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry TransferEntry
+		// The last line is truncated when the process has stopped while writing it.
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		j.entries[transferKey(entry.Operation, entry.LocalPath)] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	// The entries recorded next must not be appended to a truncated line.
+	if fileInfo, err := file.Stat(); err == nil && fileInfo.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, fileInfo.Size()-1); err == nil && last[0] != '\n' {
+			if _, err := file.Write([]byte{'\n'}); err != nil {
+				file.Close()
+				return nil, err
+			}
+		}
+	}
+
+	return j, nil
+}
+
+// Entry returns the last known state of the transfer of a local file.
+func (j *TransferJournal) Entry(operation string, localPath string) (TransferEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.entries[transferKey(operation, localPath)]
+	return entry, ok
+}
+
+// Entries returns the last known state of the transfer of every file, e.g. to report the
+// failures, sorted by local path.
+func (j *TransferJournal) Entries() []TransferEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := make([]TransferEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].LocalPath != entries[b].LocalPath {
+			return entries[a].LocalPath < entries[b].LocalPath
+		}
+		return entries[a].Operation < entries[b].Operation
+	})
+	return entries
+}
+
+// Close closes the journal file.
+func (j *TransferJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.file.Close()
+}
+
+// record appends an entry to the journal file.
+func (j *TransferJournal) record(entry TransferEntry) error {
+	entry.Time = time.Now()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	j.entries[transferKey(entry.Operation, entry.LocalPath)] = entry
+	return nil
+}
+
+// track records the transfer of a file as in flight, calls transfer, then records its
+// outcome. The ID of the item returned by transfer, if any, is recorded. A nil journal
+// records nothing.
+func (j *TransferJournal) track(entry TransferEntry, transfer func() (*DriveItem, error)) (*DriveItem, error) {
+	if j == nil {
+		return transfer()
+	}
+
+	entry.State = TransferInFlight
+	if err := j.record(entry); err != nil {
+		return nil, err
+	}
+
+	item, err := transfer()
+
+	entry.State = TransferCompleted
+	if item != nil {
+		entry.ItemId = item.Id
+	}
+	if err != nil {
+		entry.State, entry.Error = TransferFailed, err.Error()
+	}
+	if recordErr := j.record(entry); err == nil {
+		err = recordErr
+	}
+
+	return item, err
+}
+
+// uploaded returns the item a local file has been uploaded to, if the upload has been
+// completed and the file has not changed since.
+func (j *TransferJournal) uploaded(localPath string, fileInfo os.FileInfo) (*DriveItem, bool) {
+	entry, ok := j.Entry(TransferUpload, localPath)
+	if !ok || entry.State != TransferCompleted || entry.Size != fileInfo.Size() || !entry.ModTime.Equal(fileInfo.ModTime()) {
+		return nil, false
+	}
+
+	return &DriveItem{Id: entry.ItemId, Name: fileInfo.Name(), Size: entry.Size}, true
+}
+
+// downloaded reports whether an item has been downloaded to a local file, which has not
+// changed since, and the item has not changed either.
+func (j *TransferJournal) downloaded(item *DriveItem, localPath string) bool {
+	entry, ok := j.Entry(TransferDownload, localPath)
+	if !ok || entry.State != TransferCompleted || entry.ItemId != item.Id || entry.ETag == "" || entry.ETag != item.ETag {
+		return false
+	}
+
+	fileInfo, err := os.Stat(localPath)
+	return err == nil && fileInfo.Size() == entry.Size
+}
+
+func transferKey(operation string, localPath string) string {
+	return operation + "\x00" + localPath
+}
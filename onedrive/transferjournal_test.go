@@ -0,0 +1,167 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTransferJournal_UploadMany(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	aPath, bPath := filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")
+	ioutil.WriteFile(aPath, []byte("a"), 0644)
+	ioutil.WriteFile(bPath, []byte("b"), 0644)
+
+	uploads := make(map[string]int)
+	failB := true
+	mux.HandleFunc("/me/drive/items/folder-1:/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		name := filepath.Base(filepath.Dir(r.URL.Path))
+		name = name[:len(name)-1]
+		uploads[name]++
+		if name == "b.txt" && failB {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error": {"code": "serviceNotAvailable", "message": "Service not available"}}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id": "id-%s", "name": %q, "size": 1, "file": {}}`, name, name)
+	})
+
+	journalPath := filepath.Join(dir, "journal")
+	upload := func() []UploadManyResult {
+		journal, err := OpenTransferJournal(journalPath)
+		if err != nil {
+			t.Fatalf("OpenTransferJournal returned error: %v", err)
+		}
+		defer journal.Close()
+
+		results, _ := client.DriveItems.UploadMany(context.Background(), []string{aPath, bPath}, "folder-1", UploadManyOpts{Concurrency: 1, Journal: journal})
+		return results
+	}
+
+	results := upload()
+	if results[0].Err != nil || results[1].Err == nil {
+		t.Fatalf("The first DriveItems.UploadMany returned %+v, want only b.txt to fail", results)
+	}
+
+	failB = false
+	results = upload()
+	if !results[0].Skipped || results[0].Item.Id != "id-a.txt" {
+		t.Errorf("The second DriveItems.UploadMany returned %+v for a.txt, want it skipped", results[0])
+	}
+	if results[1].Err != nil || results[1].Skipped {
+		t.Errorf("The second DriveItems.UploadMany returned %+v for b.txt, want it uploaded", results[1])
+	}
+	if uploads["a.txt"] != 1 || uploads["b.txt"] != 2 {
+		t.Errorf("The files have been uploaded %v times, want a.txt once and b.txt twice", uploads)
+	}
+
+	// A change of the file makes it uploaded again.
+	ioutil.WriteFile(aPath, []byte("aa"), 0644)
+	upload()
+	if uploads["a.txt"] != 2 {
+		t.Errorf("The changed a.txt has been uploaded %d times, want 2", uploads["a.txt"])
+	}
+}
+
+func TestTransferJournal_DownloadMany(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	downloads := 0
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		fmt.Fprint(w, "content")
+	})
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	journal, err := OpenTransferJournal(filepath.Join(dir, "journal"))
+	if err != nil {
+		t.Fatalf("OpenTransferJournal returned error: %v", err)
+	}
+	defer journal.Close()
+
+	item := &DriveItem{Id: "1", Name: "a.txt", ETag: "v1", Size: 7, File: &DriveItemFile{}, DownloadURL: serverURL + baseURLPath + "/download/1"}
+	destinationDir := filepath.Join(dir, "out")
+
+	for run := 0; run < 2; run++ {
+		results, err := client.DriveItems.DownloadMany(context.Background(), []*DriveItem{item}, destinationDir, DownloadManyOpts{Journal: journal})
+		if err != nil || results[0].Err != nil {
+			t.Fatalf("DriveItems.DownloadMany returned %+v, %v", results, err)
+		}
+		if results[0].Skipped != (run == 1) {
+			t.Errorf("Run %d of DriveItems.DownloadMany returned Skipped %v", run, results[0].Skipped)
+		}
+	}
+
+	if downloads != 1 {
+		t.Errorf("The item has been downloaded %d times, want 1", downloads)
+	}
+
+	entry, ok := journal.Entry(TransferDownload, filepath.Join(destinationDir, "a.txt"))
+	if !ok || entry.State != TransferCompleted || entry.ItemId != "1" {
+		t.Errorf("The journal entry is %+v, want a completed download of item 1", entry)
+	}
+}
+
+func TestOpenTransferJournal_truncated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	journalPath := filepath.Join(dir, "journal")
+	ioutil.WriteFile(journalPath, []byte(`{"operation": "upload", "localPath": "a.txt", "state": "completed"}
+{"operation": "upload", "localPath": "a.txt", "state": "fai`), 0644)
+
+	journal, err := OpenTransferJournal(journalPath)
+	if err != nil {
+		t.Fatalf("OpenTransferJournal returned error: %v", err)
+	}
+	defer journal.Close()
+
+	if entries := journal.Entries(); len(entries) != 1 || entries[0].State != TransferCompleted {
+		t.Errorf("The journal has the entries %+v, want the completed upload only", entries)
+	}
+
+	if err := journal.record(TransferEntry{Operation: TransferUpload, LocalPath: "b.txt", State: TransferCompleted}); err != nil {
+		t.Fatalf("record returned error: %v", err)
+	}
+	journal.Close()
+
+	reopened, err := OpenTransferJournal(journalPath)
+	if err != nil {
+		t.Fatalf("OpenTransferJournal returned error: %v", err)
+	}
+	defer reopened.Close()
+
+	if _, ok := reopened.Entry(TransferUpload, "b.txt"); !ok {
+		t.Error("The entry recorded after a truncated line has been lost")
+	}
+}
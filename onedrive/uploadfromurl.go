@@ -0,0 +1,67 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// UploadFromURLRequest represents the information needed to upload a file from a URL.
+type UploadFromURLRequest struct {
+	SourceURL string `json:"@microsoft.graph.sourceUrl"`
+	Name      string `json:"name"`
+	File      Facet  `json:"file"`
+}
+
+// UploadFromURLResponse represents the JSON object returned by the OneDrive API after
+// starting an upload from a URL. Location is the URL of the monitor of the upload, see
+// DriveAsyncJobService.Monitor.
+type UploadFromURLResponse struct {
+	Location string `json:"location"`
+}
+
+// UploadFromURL makes OneDrive download the file at sourceURL into a folder of the default
+// drive of the authenticated user, so a big remote file does not pass through the client.
+// The download happens asynchronously: its progress is retrieved from the monitor URL of the
+// response, and the new item is the resource of the monitor once the download is over.
+//
+// This is only available on OneDrive personal; other drives answer with
+// ErrNotSupportedOnBusiness.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_upload_url?view=odsp-graph-online
+func (s *DriveItemsService) UploadFromURL(ctx context.Context, destinationParentFolderId string, fileName string, sourceURL string) (*UploadFromURLResponse, error) {
+	if destinationParentFolderId == "" {
+		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for this new item.")
+	}
+
+	if fileName == "" {
+		return nil, errors.New("Please provide the file name.")
+	}
+
+	if source, err := url.Parse(sourceURL); err != nil || (source.Scheme != "http" && source.Scheme != "https") || source.Host == "" {
+		return nil, errors.New("Please provide the HTTP or HTTPS URL of the file to upload.")
+	}
+
+	uploadRequest := &UploadFromURLRequest{
+		SourceURL: sourceURL,
+		Name:      fileName,
+	}
+
+	req, err := s.client.NewRequest("POST", "me/drive/items/"+url.PathEscape(destinationParentFolderId)+"/children", uploadRequest)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Prefer", "respond-async")
+
+	var response *UploadFromURLResponse
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, s.client.Drives.checkBusinessSupport(ctx, err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestDriveItemsService_UploadFromURL(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder-1/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got, want := r.Header.Get("Prefer"), "respond-async"; got != want {
+			t.Errorf("Prefer = %q, want %q", got, want)
+		}
+		want := `{"@microsoft.graph.sourceUrl":"https://example.com/video.mp4","name":"video.mp4","file":{}}`
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != want {
+			t.Errorf("The body is %s, want %s", body, want)
+		}
+
+		w.Header().Set("Location", "https://api.onedrive.com/v1.0/monitor/4A3407B5")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	response, err := client.DriveItems.UploadFromURL(context.Background(), "folder-1", "video.mp4", "https://example.com/video.mp4")
+	if err != nil {
+		t.Fatalf("DriveItems.UploadFromURL returned error: %v", err)
+	}
+	if want := "https://api.onedrive.com/v1.0/monitor/4A3407B5"; response.Location != want {
+		t.Errorf("DriveItems.UploadFromURL returned %+v, want location %q", response, want)
+	}
+
+	if _, err := client.DriveItems.UploadFromURL(context.Background(), "folder-1", "video.mp4", "ftp://example.com/video.mp4"); err == nil {
+		t.Error("DriveItems.UploadFromURL returned no error for a non-HTTP URL")
+	}
+}
+
+func TestDriveItemsService_UploadFromURL_businessDrive(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/folder-1/children", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"code": "notSupported", "message": "Upload from URL is not supported on this drive."}}`)
+	})
+	mux.HandleFunc("/me/drive", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "b!1", "driveType": "business"}`)
+	})
+
+	_, err := client.DriveItems.UploadFromURL(context.Background(), "folder-1", "video.mp4", "https://example.com/video.mp4")
+	if !errors.Is(err, ErrNotSupportedOnBusiness) {
+		t.Errorf("DriveItems.UploadFromURL returned error %v, want %v", err, ErrNotSupportedOnBusiness)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+)
+
+// UploadIfChanged uploads a local file to a path from the root of the default drive of the
+// authenticated user, e.g. "Reports/2024/summary.pdf", unless the item at that path already
+// has the same content. The remote item, uploaded or not, is returned along with whether
+// the file has been transferred.
+//
+// Missing parent folders are created. See UploadIfChangedItem about how the content is
+// compared.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online
+func (s *DriveItemsService) UploadIfChanged(ctx context.Context, localFilePath string, remotePath string) (*DriveItem, bool, error) {
+	if strings.Trim(remotePath, "/") == "" {
+		return nil, false, errors.New("Please provide the destination, i.e. the path of the item.")
+	}
+
+	file, fileInfo, err := openLocalFile(localFilePath)
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	item, err := s.GetByPath(ctx, remotePath)
+	if err != nil && !isNotFound(err) {
+		return nil, false, err
+	}
+
+	if item != nil {
+		changed, err := hasLocalFileChanged(s.client.fileHasher(), localFilePath, fileInfo, item)
+		if err != nil || !changed {
+			return item, false, err
+		}
+	}
+
+	item, err = s.UploadNewFileToPath(ctx, remotePath, file, UploadNewFileToPathOpts{
+		ConflictBehavior: "replace",
+		CreateParents:    true,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return item, true, nil
+}
+
+// UploadIfChangedItem replaces the content of a remote file by a local file, unless they
+// already have the same content. The remote item, uploaded or not, is returned along with
+// whether the file has been transferred. The item must have been retrieved with its
+// parentReference, e.g. by Get or List.
+//
+// The contents differ when the sizes differ. Otherwise, the hash of the local file is
+// compared with the one of the item; it is the QuickXorHash unless the client has been
+// created with WithHasher. When OneDrive reports no such hash for the item, the local file
+// is considered changed when it has been modified after the item.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-replace-an-existing-item
+func (s *DriveItemsService) UploadIfChangedItem(ctx context.Context, localFilePath string, item *DriveItem) (*DriveItem, bool, error) {
+	if item == nil || item.ParentReference == nil || item.ParentReference.Id == "" || item.Name == "" {
+		return nil, false, errors.New("Please provide the item to replace, with its name and parent reference.")
+	}
+
+	if item.File == nil {
+		return nil, false, errors.New("It's prohibited to replace a drive item which is not a file.")
+	}
+
+	file, fileInfo, err := openLocalFile(localFilePath)
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	changed, err := hasLocalFileChanged(s.client.fileHasher(), localFilePath, fileInfo, item)
+	if err != nil || !changed {
+		return item, false, err
+	}
+
+	uploaded, err := s.uploadFile(ctx, item.ParentReference.DriveId, item.ParentReference.Id, item.Name, file, fileInfo.Size(), "replace", nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return uploaded, true, nil
+}
+
+// openLocalFile opens a local file to be uploaded.
+func openLocalFile(localFilePath string) (*os.File, os.FileInfo, error) {
+	if localFilePath == "" {
+		return nil, nil, errors.New("Please provide the path to the file on local.")
+	}
+
+	file, err := os.Open(localFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if fileInfo.IsDir() {
+		file.Close()
+		return nil, nil, errors.New("Only file is allowed to be uploaded here.")
+	}
+
+	return file, fileInfo, nil
+}
+
+// hasLocalFileChanged reports whether the content of a local file differs from the content
+// of a remote file, see UploadIfChangedItem.
+func hasLocalFileChanged(hasher Hasher, localFilePath string, fileInfo os.FileInfo, item *DriveItem) (bool, error) {
+	if item.File == nil || item.Size != fileInfo.Size() {
+		return true, nil
+	}
+
+	remoteHash := hasher.RemoteHash(item.File.Hashes)
+	if remoteHash == "" {
+		return fileInfo.ModTime().After(item.LastModifiedDateTime), nil
+	}
+
+	localHash, err := hasher.HashFile(localFilePath)
+	if err != nil {
+		return false, err
+	}
+
+	return localHash != remoteHash, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_UploadIfChanged(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	localFilePath := filepath.Join(dir, "hello.txt")
+	if err := ioutil.WriteFile(localFilePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Cannot create the local file: %v", err)
+	}
+
+	localHash, err := QuickXorHashFile(localFilePath)
+	if err != nil {
+		t.Fatalf("QuickXorHashFile returned error: %v", err)
+	}
+
+	remoteHash := "AAAAAAAAAAAAAAAAAAAAAAAAAAA="
+	uploads := 0
+	mux.HandleFunc("/me/drive/root:/Notes/hello.txt:", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"id": "hello", "name": "hello.txt", "size": 5, "file": {"hashes": {"quickXorHash": %q}}}`, remoteHash)
+	})
+	mux.HandleFunc("/me/drive/root:/Notes/hello.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		uploads++
+		fmt.Fprintf(w, `{"id": "hello", "name": "hello.txt", "size": 5, "file": {"hashes": {"quickXorHash": %q}}}`, localHash)
+	})
+
+	ctx := context.Background()
+	item, uploaded, err := client.DriveItems.UploadIfChanged(ctx, localFilePath, "Notes/hello.txt")
+	if err != nil {
+		t.Fatalf("DriveItems.UploadIfChanged returned error: %v", err)
+	}
+	if !uploaded || uploads != 1 || item.File.Hashes.QuickXorHash != localHash {
+		t.Errorf("DriveItems.UploadIfChanged returned %+v, %v, want the uploaded item", item, uploaded)
+	}
+
+	remoteHash = localHash
+	item, uploaded, err = client.DriveItems.UploadIfChanged(ctx, localFilePath, "Notes/hello.txt")
+	if err != nil {
+		t.Fatalf("DriveItems.UploadIfChanged returned error: %v", err)
+	}
+	if uploaded || uploads != 1 || item.Id != "hello" {
+		t.Errorf("DriveItems.UploadIfChanged returned %+v, %v, want the unchanged item", item, uploaded)
+	}
+}
+
+func TestDriveItemsService_UploadIfChanged_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	localFilePath := filepath.Join(dir, "hello.txt")
+	if err := ioutil.WriteFile(localFilePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Cannot create the local file: %v", err)
+	}
+
+	mux.HandleFunc("/me/drive/root:/hello.txt:", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+	mux.HandleFunc("/me/drive/root:/hello.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id": "hello", "name": "hello.txt", "size": 5}`)
+	})
+
+	item, uploaded, err := client.DriveItems.UploadIfChanged(context.Background(), localFilePath, "hello.txt")
+	if err != nil {
+		t.Fatalf("DriveItems.UploadIfChanged returned error: %v", err)
+	}
+	if !uploaded || item.Id != "hello" {
+		t.Errorf("DriveItems.UploadIfChanged returned %+v, %v, want the new item", item, uploaded)
+	}
+}
+
+func TestDriveItemsService_UploadIfChangedItem(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	localFilePath := filepath.Join(dir, "hello.txt")
+	if err := ioutil.WriteFile(localFilePath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Cannot create the local file: %v", err)
+	}
+	modified := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(localFilePath, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	uploads := 0
+	mux.HandleFunc("/me/drives/drive-1/items/folder-1:/hello.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		if got := r.URL.Query().Get("@microsoft.graph.conflictBehavior"); got != "replace" {
+			t.Errorf("Request conflictBehavior = %q, want %q", got, "replace")
+		}
+		uploads++
+		fmt.Fprint(w, `{"id": "hello", "name": "hello.txt", "size": 5}`)
+	})
+
+	item := &DriveItem{
+		Id:                   "hello",
+		Name:                 "hello.txt",
+		Size:                 5,
+		LastModifiedDateTime: modified,
+		File:                 &DriveItemFile{},
+		ParentReference:      &ParentReference{Id: "folder-1", DriveId: "drive-1"},
+	}
+
+	// Without a hash, the modification times are compared.
+	ctx := context.Background()
+	if _, uploaded, err := client.DriveItems.UploadIfChangedItem(ctx, localFilePath, item); err != nil || uploaded {
+		t.Errorf("DriveItems.UploadIfChangedItem returned %v, %v, want no upload", uploaded, err)
+	}
+
+	item.Size = 4
+	if _, uploaded, err := client.DriveItems.UploadIfChangedItem(ctx, localFilePath, item); err != nil || !uploaded {
+		t.Errorf("DriveItems.UploadIfChangedItem returned %v, %v, want an upload", uploaded, err)
+	}
+	if uploads != 1 {
+		t.Errorf("DriveItems.UploadIfChangedItem uploaded %d times, want 1", uploads)
+	}
+}
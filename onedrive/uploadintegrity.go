@@ -0,0 +1,162 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// uploadDigest computes the hash of uploaded data, of the kind compared by the Hasher of the
+// client, to verify the hash OneDrive reports for the uploaded item. Custom hashers, which
+// only hash local files, are replaced by QuickXorHasher.
+type uploadDigest struct {
+	hasher Hasher
+	hash   hash.Hash
+	encode func([]byte) string
+}
+
+// newUploadDigest returns an uploadDigest for the Hasher of the client.
+func (c *Client) newUploadDigest() *uploadDigest {
+	if hasher, ok := c.fileHasher().(hexHasher); ok {
+		return &uploadDigest{
+			hasher: hasher,
+			hash:   hasher.newHash(),
+			encode: func(sum []byte) string { return strings.ToUpper(hex.EncodeToString(sum)) },
+		}
+	}
+
+	return &uploadDigest{
+		hasher: QuickXorHasher,
+		hash:   NewQuickXorHash(),
+		encode: base64.StdEncoding.EncodeToString,
+	}
+}
+
+// remoteHash returns the hash OneDrive reports for the item, or an empty string if it does
+// not report this kind of hash (yet).
+func (d *uploadDigest) remoteHash(item *DriveItem) string {
+	if item == nil || item.File == nil || item.File.Hashes == nil {
+		return ""
+	}
+	return d.hasher.RemoteHash(item.File.Hashes)
+}
+
+// verify returns ErrUploadCorrupted if OneDrive reports a hash of the item which differs
+// from the hash of the data, or ErrUploadUnverified if it reports no hash of this kind.
+func (d *uploadDigest) verify(item *DriveItem) error {
+	if item == nil {
+		return fmt.Errorf("%w: OneDrive did not report the uploaded item", ErrUploadUnverified)
+	}
+
+	remoteHash := d.remoteHash(item)
+	if remoteHash == "" {
+		return fmt.Errorf("%w: OneDrive did not report the hash of %q", ErrUploadUnverified, item.Name)
+	}
+
+	if localHash := d.encode(d.hash.Sum(nil)); localHash != remoteHash {
+		return fmt.Errorf("%w: the hash of %q is %s, but %s was uploaded", ErrUploadCorrupted, item.Name, remoteHash, localHash)
+	}
+
+	return nil
+}
+
+// hashingReaderAt hashes the bytes read from an io.ReaderAt in order, as the chunks of an
+// upload are read, so the data does not have to be read again to be verified.
+type hashingReaderAt struct {
+	reader io.ReaderAt
+	hash   hash.Hash
+	hashed int64 // The bytes before are hashed.
+	gap    bool  // Whether bytes after hashed have been skipped.
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *hashingReaderAt) ReadAt(p []byte, offset int64) (int, error) {
+	n, err := r.reader.ReadAt(p, offset)
+	if offset > r.hashed {
+		r.gap = true
+	} else if end := offset + int64(n); end > r.hashed {
+		r.hash.Write(p[r.hashed-offset : n])
+		r.hashed = end
+	}
+	return n, err
+}
+
+// rehash hashes the data of file again if it has not been read entirely and in order
+// through reader, e.g. because the upload was resumed.
+func (d *uploadDigest) rehash(reader *hashingReaderAt, file LargeFile) error {
+	if !reader.gap && reader.hashed == int64(file.Size) {
+		return nil
+	}
+
+	d.hash.Reset()
+	_, err := io.Copy(d.hash, io.NewSectionReader(file.Data, 0, int64(file.Size)))
+	return err
+}
+
+// verifyUpload verifies an uploaded item against digest. OneDrive may compute the hashes of
+// a file some time after the upload, so if awaitHash is not zero and the item lacks the
+// hash, the item is fetched again, with a backoff, until it has the hash. ErrUploadUnverified
+// is returned if it still lacks it after awaitHash. The item is returned as last fetched,
+// along with the error, if any, so the caller can delete it or check it again. Nothing is
+// verified in dry-run mode, as nothing has been uploaded.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/hashes?view=odsp-graph-online
+func (s *DriveItemsService) verifyUpload(ctx context.Context, driveId string, item *DriveItem, digest *uploadDigest, awaitHash time.Duration) (*DriveItem, error) {
+	if s.client.dryRunJournal != nil {
+		return item, nil
+	}
+
+	if awaitHash > 0 && item != nil && item.Id != "" && digest.remoteHash(item) == "" {
+		apiURL := "me/drive/items/" + url.PathEscape(item.Id)
+		if driveId != "" {
+			apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(item.Id)
+		}
+
+		deadline := s.client.now().Add(awaitHash)
+		delay := time.Second
+		for digest.remoteHash(item) == "" {
+			remaining := deadline.Sub(s.client.now())
+			if remaining <= 0 {
+				return item, fmt.Errorf("%w: OneDrive did not report the hash of %q within %v", ErrUploadUnverified, item.Name, awaitHash)
+			}
+			if delay > remaining {
+				delay = remaining
+			}
+			if err := s.client.sleep(ctx, delay); err != nil {
+				return item, err
+			}
+			if delay < 30*time.Second {
+				delay *= 2
+			}
+
+			req, err := s.client.NewRequest("GET", apiURL, nil)
+			if err != nil {
+				return item, err
+			}
+
+			var refreshed *DriveItem
+			if err := s.client.doItem(ctx, item.Id, req, &refreshed); err != nil {
+				return item, err
+			}
+			if refreshed != nil {
+				item = refreshed
+			}
+		}
+	}
+
+	if err := digest.verify(item); err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_UploadLargeFile_verifyIntegrity(t *testing.T) {
+	const data = "backed up data"
+	h := NewQuickXorHash()
+	h.Write([]byte(data))
+	localHash := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	tests := []struct {
+		remoteHash string
+		wantErr    bool
+	}{
+		{localHash, false},
+		{"AAAAAAAAAAAAAAAAAAAAAAAAAAA=", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		client, mux, serverURL, teardown := setup()
+
+		mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+		})
+		received := 0
+		mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			received += len(body)
+			if received == len(data) {
+				if tt.remoteHash == "" {
+					fmt.Fprint(w, `{"id":"1","name":"big.bin","file":{"hashes":{"sha1Hash":"0000"}}}`)
+					return
+				}
+				fmt.Fprintf(w, `{"id":"1","name":"big.bin","file":{"hashes":{"quickXorHash":%q}}}`, tt.remoteHash)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"nextExpectedRanges":["%d-"]}`, received)
+		})
+
+		file := LargeFile{Name: "big.bin", Size: uint64(len(data)), Data: strings.NewReader(data)}
+		item, err := client.DriveItems.UploadLargeFile(context.Background(), "folder-1", file, UploadLargeFileOpts{ChunkSize: 4, VerifyIntegrity: true})
+		if tt.wantErr {
+			wantErr := ErrUploadCorrupted
+			if tt.remoteHash == "" {
+				// Without AwaitHash, an item reported without the hash is not waited for.
+				wantErr = ErrUploadUnverified
+			}
+			if !errors.Is(err, wantErr) {
+				t.Errorf("DriveItems.UploadLargeFile returned error %v, want %v", err, wantErr)
+			}
+			// The item is returned, so it can be deleted or checked again.
+			if item == nil || item.Id != "1" {
+				t.Errorf("DriveItems.UploadLargeFile returned item %+v along with the error, want item 1", item)
+			}
+		} else if err != nil || item.Id != "1" {
+			t.Errorf("DriveItems.UploadLargeFile returned %+v, %v", item, err)
+		}
+
+		teardown()
+	}
+}
+
+func TestDriveItemsService_UploadNewFileWithOpts_verifyIntegrity(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithHasher(SHA256Hasher)(client)
+
+	dir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "local.txt")
+	if err := ioutil.WriteFile(localPath, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256([]byte("hello"))
+	remoteHash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	mux.HandleFunc("/me/drive/items/folder-1:/local.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":"1","name":"local.txt","file":{"hashes":{"sha256Hash":%q}}}`, remoteHash)
+	})
+
+	item, err := client.DriveItems.UploadNewFileWithOpts(context.Background(), "folder-1", localPath, UploadNewFileOpts{VerifyIntegrity: true})
+	if err != nil {
+		t.Fatalf("DriveItems.UploadNewFileWithOpts returned error: %v", err)
+	}
+	if item.Id != "1" {
+		t.Errorf("DriveItems.UploadNewFileWithOpts returned %+v", item)
+	}
+
+	remoteHash = strings.Repeat("0", 64)
+	item, err = client.DriveItems.UploadNewFileWithOpts(context.Background(), "folder-1", localPath, UploadNewFileOpts{VerifyIntegrity: true})
+	if !errors.Is(err, ErrUploadCorrupted) {
+		t.Errorf("DriveItems.UploadNewFileWithOpts returned error %v, want ErrUploadCorrupted", err)
+	}
+	if item == nil || item.Id != "1" {
+		t.Errorf("DriveItems.UploadNewFileWithOpts returned item %+v along with the error, want item 1", item)
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_awaitHash(t *testing.T) {
+	const data = "backed up data"
+	h := NewQuickXorHash()
+	h.Write([]byte(data))
+	localHash := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	tests := []struct {
+		hashAfter  int // The number of GETs after which the item has its hash.
+		wantSleeps []time.Duration
+		wantErr    error
+	}{
+		{2, []time.Duration{time.Second, 2 * time.Second}, nil},
+		{10, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 3 * time.Second}, ErrUploadUnverified},
+	}
+	for _, tt := range tests {
+		client, mux, serverURL, teardown := setup()
+
+		clock := &fakeClock{now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+		WithClock(clock)(client)
+		WithSleeper(clock)(client)
+
+		mux.HandleFunc("/me/drives/d/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1"}`, serverURL, baseURLPath)
+		})
+		mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"id":"1","name":"big.bin","file":{}}`)
+		})
+		gets := 0
+		mux.HandleFunc("/me/drives/d/items/1", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			gets++
+			if gets < tt.hashAfter {
+				fmt.Fprint(w, `{"id":"1","name":"big.bin","file":{}}`)
+				return
+			}
+			fmt.Fprintf(w, `{"id":"1","name":"big.bin","file":{"hashes":{"quickXorHash":%q}}}`, localHash)
+		})
+
+		file := LargeFile{Name: "big.bin", Size: uint64(len(data)), Data: strings.NewReader(data)}
+		item, err := client.DriveItems.UploadLargeFile(context.Background(), "folder-1", file, UploadLargeFileOpts{
+			DriveID:         "d",
+			VerifyIntegrity: true,
+			AwaitHash:       10 * time.Second,
+		})
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DriveItems.UploadLargeFile returned error %v, want %v", err, tt.wantErr)
+			}
+			if item == nil || item.Id != "1" {
+				t.Errorf("DriveItems.UploadLargeFile returned item %+v along with the error, want item 1", item)
+			}
+		} else if err != nil || item.File.Hashes == nil || item.File.Hashes.QuickXorHash != localHash {
+			t.Errorf("DriveItems.UploadLargeFile returned %+v, %v", item, err)
+		}
+		if !reflect.DeepEqual(clock.sleeps, tt.wantSleeps) {
+			t.Errorf("DriveItems.UploadLargeFile slept %v, want %v", clock.sleeps, tt.wantSleeps)
+		}
+
+		teardown()
+	}
+}
+
+func TestHashingReaderAt(t *testing.T) {
+	data := strings.NewReader("0123456789")
+	digest := (&Client{}).newUploadDigest()
+	reader := &hashingReaderAt{reader: data, hash: digest.hash}
+
+	p := make([]byte, 4)
+	reader.ReadAt(p, 0)
+	reader.ReadAt(p, 2)
+	if reader.hashed != 6 || reader.gap {
+		t.Errorf("After overlapping reads, hashed = %d, gap = %v, want 6, false", reader.hashed, reader.gap)
+	}
+
+	reader.ReadAt(p, 8)
+	if !reader.gap {
+		t.Errorf("A read after a skipped range did not set gap")
+	}
+
+	want := NewQuickXorHash()
+	want.Write([]byte("0123456789"))
+	file := LargeFile{Name: "digits", Size: 10, Data: data}
+	item := &DriveItem{File: &DriveItemFile{Hashes: &DriveItemHashes{QuickXorHash: base64.StdEncoding.EncodeToString(want.Sum(nil))}}}
+	if err := digest.rehash(reader, file); err != nil {
+		t.Fatalf("rehash returned error: %v", err)
+	}
+	if err := digest.verify(item); err != nil {
+		t.Errorf("verify returned error: %v", err)
+	}
+
+	for _, unverified := range []*DriveItem{nil, {}, {File: &DriveItemFile{Hashes: &DriveItemHashes{SHA1Hash: "0000"}}}} {
+		if err := digest.verify(unverified); !errors.Is(err, ErrUploadUnverified) {
+			t.Errorf("verify(%+v) returned error %v, want ErrUploadUnverified", unverified, err)
+		}
+	}
+}
+
+func TestDriveItemsService_UploadNewFileWithOpts_verifyIntegrityDryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	WithDryRun(&OperationJournal{})(client)
+
+	dir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "local.txt")
+	if err := ioutil.WriteFile(localPath, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/me/drive/items/folder-1:/local.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("A dry run uploaded the file")
+	})
+
+	if _, err := client.DriveItems.UploadNewFileWithOpts(context.Background(), "folder-1", localPath, UploadNewFileOpts{VerifyIntegrity: true}); err != nil {
+		t.Errorf("DriveItems.UploadNewFileWithOpts returned error %v in dry-run mode", err)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/h2non/filetype"
+)
+
+// UploadManyOpts represents the options for uploading files by UploadMany.
+type UploadManyOpts struct {
+	DriveID string
+	// Concurrency is the maximum number of files uploaded at the same time.
+	// Default is 8.
+	Concurrency int
+	// ConflictBehavior customizes the conflict resolution behavior. By default,
+	// existing item will be replaced. Possible values are "fail", "replace", or
+	// "rename".
+	ConflictBehavior string
+	// SkipExisting skips the files for which an item with the same name and size
+	// already exists in the destination folder.
+	SkipExisting bool
+	// ConflictResolver, if any, is called for every file whose name is already taken in
+	// the destination folder, and ConflictBehavior is ignored. The files it skips are
+	// reported as skipped, without item.
+	ConflictResolver ConflictResolver
+	// Journal, if any, records the progress of the uploads, so the files already uploaded
+	// by a previous run are skipped without checking the destination folder.
+	Journal *TransferJournal
+}
+
+// UploadManyResult represents the outcome of uploading one of the files by UploadMany.
+type UploadManyResult struct {
+	LocalPath string
+	// Item is the uploaded item, or the existing item when the file has been skipped. The
+	// item of a file skipped according to the journal only has its ID, name and size.
+	Item    *DriveItem
+	Skipped bool
+	Err     error
+}
+
+// UploadManyError is returned by UploadMany when some of the files could not be uploaded.
+type UploadManyError struct {
+	Failed []UploadManyResult
+}
+
+func (e *UploadManyError) Error() string {
+	if len(e.Failed) == 1 {
+		return fmt.Sprintf("1 file could not be uploaded: %s: %v", e.Failed[0].LocalPath, e.Failed[0].Err)
+	}
+	return fmt.Sprintf("%d files could not be uploaded, first %s: %v", len(e.Failed), e.Failed[0].LocalPath, e.Failed[0].Err)
+}
+
+// UploadMany uploads many local files into a folder of a drive of the authenticated user,
+// which is much faster than uploading them one by one when there are thousands of small
+// files.
+//
+// The existence of the files in the destination folder is checked with as few JSON batches
+// as possible, then the files are streamed by a bounded pool of workers sharing the
+// connections of the HTTP client. Files larger than 4 MiB are uploaded through upload
+// sessions.
+//
+// The outcome of every file is reported in the results, in the same order as the files.
+// When some of the files could not be uploaded, an *UploadManyError listing them is
+// returned along with the results.
+func (s *DriveItemsService) UploadMany(ctx context.Context, localFilePaths []string, destinationParentFolderId string, opts UploadManyOpts) ([]UploadManyResult, error) {
+	if destinationParentFolderId == "" {
+		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for the new items.")
+	}
+
+	results := make([]UploadManyResult, len(localFilePaths))
+	for i, localFilePath := range localFilePaths {
+		results[i].LocalPath = localFilePath
+	}
+
+	if err := s.checkExisting(ctx, results, destinationParentFolderId, opts); err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTransferConcurrency
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := &results[i]
+				if result.Err != nil || result.Skipped {
+					continue
+				}
+
+				entry := TransferEntry{Operation: TransferUpload, LocalPath: result.LocalPath}
+				if fileInfo, err := os.Stat(result.LocalPath); err == nil {
+					entry.Size, entry.ModTime = fileInfo.Size(), fileInfo.ModTime()
+				}
+				result.Item, result.Err = opts.Journal.track(entry, func() (*DriveItem, error) {
+					if opts.ConflictResolver == nil {
+						return s.uploadLocalFile(ctx, opts.DriveID, destinationParentFolderId, result.LocalPath, opts.ConflictBehavior)
+					}
+
+					conflict := Conflict{
+						Name:                      filepath.Base(result.LocalPath),
+						LocalPath:                 result.LocalPath,
+						DestinationParentFolderId: destinationParentFolderId,
+					}
+
+					var item *DriveItem
+					skipped, err := resolveConflict(opts.ConflictResolver, conflict, func(conflictBehavior string) error {
+						var err error
+						item, err = s.uploadLocalFile(ctx, opts.DriveID, destinationParentFolderId, result.LocalPath, conflictBehavior)
+						return err
+					})
+					result.Skipped = skipped
+					return item, err
+				})
+			}
+		}()
+	}
+
+	for i := range results {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	uploadManyError := &UploadManyError{}
+	for i := range results {
+		if results[i].Err == nil && results[i].Item == nil && !results[i].Skipped {
+			results[i].Err = ctx.Err()
+		}
+		if results[i].Err != nil {
+			uploadManyError.Failed = append(uploadManyError.Failed, results[i])
+		}
+	}
+
+	if len(uploadManyError.Failed) > 0 {
+		return results, uploadManyError
+	}
+
+	return results, nil
+}
+
+// checkExisting looks up the items with the same names as the local files in the
+// destination folder with JSON batches, and marks the files to be skipped.
+func (s *DriveItemsService) checkExisting(ctx context.Context, results []UploadManyResult, destinationParentFolderId string, opts UploadManyOpts) error {
+	var requests []batchRequest
+	var indexes []int
+	var sizes []int64
+	for i, result := range results {
+		fileInfo, err := os.Stat(result.LocalPath)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		if fileInfo.IsDir() {
+			results[i].Err = errors.New("Only file is allowed to be uploaded here.")
+			continue
+		}
+
+		if opts.Journal != nil {
+			if item, ok := opts.Journal.uploaded(result.LocalPath, fileInfo); ok {
+				results[i].Item, results[i].Skipped = item, true
+				continue
+			}
+		}
+
+		if !opts.SkipExisting {
+			continue
+		}
+
+		apiURL := itemPathURL(opts.DriveID, destinationParentFolderId, fileInfo.Name())
+		requests = append(requests, batchRequest{Method: "GET", URL: apiURL})
+		indexes = append(indexes, i)
+		sizes = append(sizes, fileInfo.Size())
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	responses, err := s.client.batch(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	for j, response := range responses {
+		var item *DriveItem
+		if err := response.decode(&item); err != nil {
+			// The file is uploaded when its existence is unknown.
+			continue
+		}
+
+		if item.File != nil && item.Size == sizes[j] {
+			results[indexes[j]].Item = item
+			results[indexes[j]].Skipped = true
+		}
+	}
+
+	return nil
+}
+
+// uploadLocalFile streams a local file into a folder of a drive of the authenticated user,
+// through an upload session when the file is larger than 4 MiB.
+func (s *DriveItemsService) uploadLocalFile(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string, conflictBehavior string) (*DriveItem, error) {
+	file, err := os.Open(localFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.uploadFile(ctx, driveId, destinationParentFolderId, fileInfo.Name(), file, fileInfo.Size(), conflictBehavior, nil)
+}
+
+// uploadFile streams an open file of known size into a folder of a drive of the authenticated
+// user under the given name, through an upload session when the file is larger than 4 MiB.
+// The file system times of the file, if any, are set on the new item.
+func (s *DriveItemsService) uploadFile(ctx context.Context, driveId string, destinationParentFolderId string, fileName string, file *os.File, size int64, conflictBehavior string, fileSystemInfo *FileSystemInfo) (*DriveItem, error) {
+	if size > 4*1024*1024 {
+		return s.UploadLargeFile(ctx, destinationParentFolderId, LargeFile{
+			Name: fileName,
+			Size: uint64(size),
+			Data: file,
+		}, UploadLargeFileOpts{DriveID: driveId, ConflictBehavior: conflictBehavior, FileSystemInfo: fileSystemInfo})
+	}
+
+	apiURL := itemPathURL(driveId, destinationParentFolderId, fileName) + "/content"
+	if conflictBehavior != "" {
+		apiURL += "?@microsoft.graph.conflictBehavior=" + conflictBehavior
+	}
+
+	item, err := s.uploadContent(ctx, apiURL, file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.setUploadedFileSystemInfo(ctx, driveId, item, fileSystemInfo)
+}
+
+// uploadContent streams the content of a file of known size, at most 4 MiB, to the given
+// content URL. The MIME type of the file is detected from its first bytes.
+func (s *DriveItemsService) uploadContent(ctx context.Context, apiURL string, file io.ReadSeeker, size int64) (*DriveItem, error) {
+	head := make([]byte, 261)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	fileType, _ := filetype.Match(head[:n])
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewFileUploadRequest(apiURL, fileType.MIME.Value, file)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = nil
+	}
+
+	var response *DriveItem
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.markCreated(response)
+
+	return response, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDriveItemsService_UploadMany(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "go-onedrive")
+	if err != nil {
+		t.Fatalf("Cannot create the temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	existingPath := filepath.Join(dir, "existing.txt")
+	newPath := filepath.Join(dir, "new.txt")
+	ioutil.WriteFile(existingPath, []byte("existing"), 0644)
+	ioutil.WriteFile(newPath, []byte("new"), 0644)
+
+	mux.HandleFunc("/$batch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		fmt.Fprintf(w, `{"responses": [
+			{"id": %q, "status": 200, "body": {"id": "existing", "name": "existing.txt", "size": 8, "file": {}}},
+			{"id": %q, "status": 404, "body": {"error": {"code": "itemNotFound", "message": "Item not found"}}}
+		]}`, payload.Requests[0].Id, payload.Requests[1].Id)
+	})
+
+	uploads := 0
+	mux.HandleFunc("/me/drive/items/folder-1:/new.txt:/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		uploads++
+		if r.ContentLength != 3 {
+			t.Errorf("Request content length: %d, want 3", r.ContentLength)
+		}
+
+		fmt.Fprint(w, `{"id": "new", "name": "new.txt", "size": 3, "file": {}}`)
+	})
+
+	ctx := context.Background()
+	missingPath := filepath.Join(dir, "missing.txt")
+	results, err := client.DriveItems.UploadMany(ctx, []string{existingPath, newPath, missingPath}, "folder-1", UploadManyOpts{SkipExisting: true})
+
+	uploadManyError, ok := err.(*UploadManyError)
+	if !ok || len(uploadManyError.Failed) != 1 || uploadManyError.Failed[0].LocalPath != missingPath {
+		t.Errorf("DriveItems.UploadMany returned error %v, want only the missing file to fail", err)
+	}
+
+	if !results[0].Skipped || results[0].Item.Id != "existing" {
+		t.Errorf("DriveItems.UploadMany returned %+v for the existing file, want it skipped", results[0])
+	}
+
+	if results[1].Err != nil || results[1].Item == nil || results[1].Item.Id != "new" {
+		t.Errorf("DriveItems.UploadMany returned %+v for the new file, want it uploaded", results[1])
+	}
+
+	if uploads != 1 {
+		t.Errorf("DriveItems.UploadMany uploaded %d files, want 1", uploads)
+	}
+}
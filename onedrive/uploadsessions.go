@@ -0,0 +1,213 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// UploadSessionRecord represents an upload session in flight, as kept by an
+// UploadSessionStore.
+type UploadSessionRecord struct {
+	UploadURL          string    `json:"uploadUrl"`
+	DriveId            string    `json:"driveId,omitempty"`
+	ParentFolderId     string    `json:"parentFolderId,omitempty"`
+	Name               string    `json:"name"`
+	Size               int64     `json:"size"`
+	ExpirationDateTime time.Time `json:"expirationDateTime"`
+	CreatedDateTime    time.Time `json:"createdDateTime"`
+}
+
+// UploadSessionStore keeps the records of the upload sessions in flight, e.g. in a file or
+// a database, see UploadLargeFileOpts.SessionStore. The records are identified by their
+// upload URL.
+type UploadSessionStore interface {
+	// List returns all the records.
+	List(ctx context.Context) ([]UploadSessionRecord, error)
+	// Save adds a record, or replaces the record with the same upload URL.
+	Save(ctx context.Context, record UploadSessionRecord) error
+	// Delete removes the record with the given upload URL, if any.
+	Delete(ctx context.Context, uploadURL string) error
+}
+
+// FileUploadSessionStore is an UploadSessionStore keeping the records in a local JSON file.
+// It is safe for concurrent use within a process.
+type FileUploadSessionStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// List implements UploadSessionStore.
+func (store *FileUploadSessionStore) List(ctx context.Context) ([]UploadSessionRecord, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return store.load()
+}
+
+// Save implements UploadSessionStore.
+func (store *FileUploadSessionStore) Save(ctx context.Context, record UploadSessionRecord) error {
+	if record.UploadURL == "" {
+		return errors.New("Please provide the upload URL of the session.")
+	}
+
+	return store.update(func(records []UploadSessionRecord) []UploadSessionRecord {
+		for i := range records {
+			if records[i].UploadURL == record.UploadURL {
+				records[i] = record
+				return records
+			}
+		}
+		return append(records, record)
+	})
+}
+
+// Delete implements UploadSessionStore.
+func (store *FileUploadSessionStore) Delete(ctx context.Context, uploadURL string) error {
+	return store.update(func(records []UploadSessionRecord) []UploadSessionRecord {
+		kept := records[:0]
+		for _, record := range records {
+			if record.UploadURL != uploadURL {
+				kept = append(kept, record)
+			}
+		}
+		return kept
+	})
+}
+
+func (store *FileUploadSessionStore) load() ([]UploadSessionRecord, error) {
+	data, err := ioutil.ReadFile(store.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []UploadSessionRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// update replaces the records of the file by the ones returned by change. The file is
+// replaced atomically, like by FileDeltaTokenStore.
+func (store *FileUploadSessionStore) update(change func([]UploadSessionRecord) []UploadSessionRecord) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	records, err := store.load()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(change(records), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(store.Path), filepath.Base(store.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), store.Path)
+}
+
+// CancelStaleUploadSessions cancels the upload sessions of a store which have expired or
+// were created more than olderThan ago, i.e. which have been abandoned, e.g. by a process
+// which has crashed, so the partial uploads do not linger in the destination folders. The
+// records of the sessions are removed from the store, and returned.
+//
+// The sessions which could not be cancelled are kept in the store, and the first error is
+// returned along with the cancelled sessions.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online#cancel-the-upload-session
+func (s *DriveItemsService) CancelStaleUploadSessions(ctx context.Context, store UploadSessionStore, olderThan time.Duration) ([]UploadSessionRecord, error) {
+	if store == nil {
+		return nil, errors.New("Please provide the store of the upload sessions.")
+	}
+
+	if olderThan < 0 {
+		return nil, errors.New("Please provide a duration which is not negative.")
+	}
+
+	records, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.client.now()
+	var cancelled []UploadSessionRecord
+	var firstErr error
+	for _, record := range records {
+		expired := !record.ExpirationDateTime.IsZero() && !record.ExpirationDateTime.After(now)
+		if !expired && record.CreatedDateTime.After(now.Add(-olderThan)) {
+			continue
+		}
+
+		err := s.cancelUploadSession(ctx, record.UploadURL)
+		if err == nil {
+			err = store.Delete(ctx, record.UploadURL)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return cancelled, ctx.Err()
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		cancelled = append(cancelled, record)
+	}
+
+	return cancelled, firstErr
+}
+
+// detachedContext carries the values of its context, without its deadline and cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// cancelUploadSession deletes an upload session. A session which is already gone is not an
+// error.
+func (s *DriveItemsService) cancelUploadSession(ctx context.Context, uploadURL string) error {
+	req, err := http.NewRequest("DELETE", s.client.uploadURL(uploadURL), nil)
+	if err != nil {
+		return err
+	}
+
+	// The upload URL is pre-authenticated.
+	err = s.client.Do(ctx, req, true, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_CancelStaleUploadSessions(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	WithClock(&fakeClock{now: now})(client)
+
+	dir, err := ioutil.TempDir("", "uploadsessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := &FileUploadSessionStore{Path: filepath.Join(dir, "sessions.json")}
+	ctx := context.Background()
+	uploadURL := func(name string) string {
+		return serverURL + baseURLPath + "/upload/" + name
+	}
+	records := []UploadSessionRecord{
+		{UploadURL: uploadURL("fresh"), Name: "fresh.bin", CreatedDateTime: now.Add(-time.Minute), ExpirationDateTime: now.Add(time.Hour)},
+		{UploadURL: uploadURL("old"), Name: "old.bin", CreatedDateTime: now.Add(-2 * time.Hour), ExpirationDateTime: now.Add(time.Hour)},
+		{UploadURL: uploadURL("expired"), Name: "expired.bin", CreatedDateTime: now.Add(-time.Minute), ExpirationDateTime: now.Add(-time.Second)},
+	}
+	for _, record := range records {
+		if err := store.Save(ctx, record); err != nil {
+			t.Fatalf("FileUploadSessionStore.Save returned error: %v", err)
+		}
+	}
+
+	var deleted []string
+	mux.HandleFunc("/upload/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/upload/"))
+		if strings.HasSuffix(r.URL.Path, "/expired") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"itemNotFound","message":"The upload session has expired."}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	cancelled, err := client.DriveItems.CancelStaleUploadSessions(ctx, store, time.Hour)
+	if err != nil {
+		t.Fatalf("DriveItems.CancelStaleUploadSessions returned error: %v", err)
+	}
+
+	sort.Strings(deleted)
+	if len(cancelled) != 2 || strings.Join(deleted, ",") != "expired,old" {
+		t.Errorf("DriveItems.CancelStaleUploadSessions cancelled %+v, deleted %v, want old and expired", cancelled, deleted)
+	}
+
+	remaining, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("FileUploadSessionStore.List returned error: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != "fresh.bin" {
+		t.Errorf("The store still holds %+v, want only fresh.bin", remaining)
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_sessionStore(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "uploadsessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := &FileUploadSessionStore{Path: filepath.Join(dir, "sessions.json")}
+	ctx := context.Background()
+
+	mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1","expirationDateTime":"2021-01-02T00:00:00Z"}`, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		records, err := store.List(ctx)
+		if err != nil || len(records) != 1 || records[0].Name != "big.bin" || records[0].ParentFolderId != "folder-1" {
+			t.Errorf("The store holds %+v during the upload (%v), want the session", records, err)
+		}
+		fmt.Fprint(w, `{"id":"1","name":"big.bin"}`)
+	})
+
+	file := LargeFile{Name: "big.bin", Size: 10, Data: strings.NewReader("0123456789")}
+	if _, err := client.DriveItems.UploadLargeFile(ctx, "folder-1", file, UploadLargeFileOpts{SessionStore: store}); err != nil {
+		t.Fatalf("DriveItems.UploadLargeFile returned error: %v", err)
+	}
+
+	if records, _ := store.List(ctx); len(records) != 0 {
+		t.Errorf("The store still holds %+v after the upload", records)
+	}
+}
+
+func TestDriveItemsService_UploadLargeFile_sessionNotDeleted(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "uploadsessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := &FileUploadSessionStore{Path: filepath.Join(dir, "sessions.json")}
+	ctx := context.Background()
+
+	mux.HandleFunc("/me/drive/items/folder-1:/big.bin:/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"uploadUrl":"%s%s/upload/1","expirationDateTime":"2021-01-02T00:00:00Z"}`, serverURL, baseURLPath)
+	})
+	mux.HandleFunc("/upload/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error": {"code": "generalException", "message": "An unspecified error has occurred."}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"big.bin"}`)
+	})
+
+	file := LargeFile{Name: "big.bin", Size: 10, Data: strings.NewReader("0123456789")}
+	_, err = client.DriveItems.UploadLargeFile(ctx, "folder-1", file, UploadLargeFileOpts{SessionStore: store})
+	if !isStatus(err, http.StatusInternalServerError) {
+		t.Errorf("DriveItems.UploadLargeFile returned error %v, want the error of the deletion", err)
+	}
+
+	if records, _ := store.List(ctx); len(records) != 1 {
+		t.Errorf("The store holds %+v after the failed deletion, want the session", records)
+	}
+}
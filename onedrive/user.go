@@ -4,8 +4,53 @@
 
 package onedrive
 
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// UsersService handles communication with the user profile related methods of the Microsoft Graph API.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/user?view=graph-rest-1.0
+type UsersService service
+
 // User represents an user in Microsoft Live.
 type User struct {
-	Id          string `json:"id"`
-	DisplayName string `json:"displayName"`
+	Id                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	UserPrincipalName string `json:"userPrincipalName"`
+}
+
+// Me gets the profile of the authenticated user.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/user-get?view=graph-rest-1.0
+func (s *UsersService) Me(ctx context.Context) (*User, error) {
+	return s.get(ctx, "me")
+}
+
+// Get the profile of a user by its ID or user principal name.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/user-get?view=graph-rest-1.0
+func (s *UsersService) Get(ctx context.Context, userId string) (*User, error) {
+	if userId == "" {
+		return nil, errors.New("Please provide the ID of the user.")
+	}
+
+	return s.get(ctx, "users/"+url.PathEscape(userId))
+}
+
+func (s *UsersService) get(ctx context.Context, apiURL string) (*User, error) {
+	req, err := s.client.NewRequest("GET", apiURL+"?$select=id,displayName,userPrincipalName", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *User
+	err = s.client.Do(ctx, req, false, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
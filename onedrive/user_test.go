@@ -0,0 +1,36 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUsersService_Me_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "0000000000000002", "displayName": "User Display Name", "userPrincipalName": "user@contoso.com"}`)
+	})
+
+	ctx := context.Background()
+	gotUser, err := client.Users.Me(ctx)
+	if err != nil {
+		t.Errorf("Users.Me returned error: %v", err)
+	}
+
+	wantUser := &User{Id: "0000000000000002", DisplayName: "User Display Name", UserPrincipalName: "user@contoso.com"}
+	if !reflect.DeepEqual(gotUser, wantUser) {
+		t.Errorf("Users.Me returned %+v, want %+v", gotUser, wantUser)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+)
+
+// WalkOpts represents the options for Walk.
+type WalkOpts struct {
+	// DriveID is the ID of the drive to walk. If it is empty, it means the default drive of
+	// the authenticated user.
+	DriveID string
+	// FolderID is the ID of the folder to walk. If it is empty, it means the whole drive.
+	FolderID string
+	// Progress, if set, is called after every page of the listing, so long walks of whole
+	// drives can report their progress.
+	Progress func(ScanProgress)
+}
+
+// ScanProgress represents the progress of a scan of the folders of a drive. The scan is
+// over once FoldersPending is zero.
+type ScanProgress struct {
+	// ItemsDiscovered is the number of items listed so far, files and folders.
+	ItemsDiscovered int
+	// FoldersListed is the number of folders whose children have all been listed.
+	FoldersListed int
+	// FoldersPending is the number of folders discovered whose children are still to be
+	// listed, including the one being listed.
+	FoldersPending int
+	// PagesFetched is the number of pages of listings received.
+	PagesFetched int
+	// MetadataBytes is the size of the listings received, in bytes.
+	MetadataBytes int64
+}
+
+// Walk lists the items of a drive, or of a folder and its subfolders, and calls fn for every
+// item as soon as it is decoded, with its path relative to the folder walked. The folders
+// are walked breadth first, and the pages of the listings are never held in memory as a
+// whole, see ListFunc.
+//
+// If fn returns ErrSkipFolder for a folder, its children are not listed. The walk stops at
+// the first other error returned by fn, which is then returned. Packages, such as OneNote
+// notebooks, are walked as files.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) Walk(ctx context.Context, opts WalkOpts, fn func(itemPath string, item *DriveItem) error) error {
+	if fn == nil {
+		return errors.New("Please provide the function to call for every item.")
+	}
+
+	return s.walk(ctx, opts, "", fn)
+}
+
+// ExportTreeOpts represents the options for ExportTree.
+type ExportTreeOpts struct {
+	// DriveID is the ID of the drive to export. If it is empty, it means the default drive
+	// of the authenticated user.
+	DriveID string
+	// FolderID is the ID of the folder to export. If it is empty, it means the whole drive.
+	FolderID string
+	// Progress, if set, is called after every page of the listing, see WalkOpts.
+	Progress func(ScanProgress)
+}
+
+// ExportedItem represents an item written by ExportTree.
+type ExportedItem struct {
+	// Path is the path of the item relative to the folder exported.
+	Path string     `json:"path"`
+	Item *DriveItem `json:"item"`
+}
+
+// ExportTree walks a drive, or a folder and its subfolders, see Walk, and writes every item
+// to w as an ExportedItem, one JSON object per line, e.g. to keep an inventory of a drive.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ExportTree(ctx context.Context, w io.Writer, opts ExportTreeOpts) error {
+	if w == nil {
+		return errors.New("Please provide the writer to export the tree to.")
+	}
+
+	encoder := json.NewEncoder(w)
+	walkOpts := WalkOpts{DriveID: opts.DriveID, FolderID: opts.FolderID, Progress: opts.Progress}
+	return s.walk(ctx, walkOpts, "", func(itemPath string, item *DriveItem) error {
+		return encoder.Encode(ExportedItem{Path: itemPath, Item: item})
+	})
+}
+
+// walk walks a drive like Walk, listing the children with the given $select, if any.
+func (s *DriveItemsService) walk(ctx context.Context, opts WalkOpts, selectProperties string, fn func(itemPath string, item *DriveItem) error) error {
+	drivePrefix := "me/drive/"
+	if opts.DriveID != "" {
+		drivePrefix = "me/drives/" + url.PathEscape(opts.DriveID) + "/"
+	}
+
+	type folder struct {
+		apiURL string
+		path   string
+	}
+
+	root := folder{apiURL: drivePrefix + "root"}
+	if opts.FolderID != "" {
+		root.apiURL = drivePrefix + "items/" + url.PathEscape(opts.FolderID)
+	}
+
+	var progress ScanProgress
+	folders := []folder{root}
+	for len(folders) > 0 {
+		current := folders[0]
+		folders = folders[1:]
+
+		apiURL := current.apiURL + "/children"
+		if selectProperties != "" {
+			apiURL += "?$select=" + selectProperties
+		}
+
+		for apiURL != "" {
+			req, err := s.client.NewRequest("GET", apiURL, nil)
+			if err != nil {
+				return err
+			}
+
+			var size int64
+			apiURL, size, err = s.client.streamSize(ctx, req, func(decode func(target interface{}) error) error {
+				var item *DriveItem
+				if err := decode(&item); err != nil {
+					return err
+				}
+				progress.ItemsDiscovered++
+
+				if item == nil {
+					return nil
+				}
+
+				itemPath := path.Join(current.path, item.Name)
+				err := fn(itemPath, item)
+				if item.Folder == nil || item.IsPackage() {
+					return err
+				}
+
+				if err == ErrSkipFolder {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				folders = append(folders, folder{apiURL: drivePrefix + "items/" + url.PathEscape(item.Id), path: itemPath})
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if opts.Progress != nil {
+				progress.PagesFetched++
+				progress.MetadataBytes += size
+				progress.FoldersPending = len(folders)
+				if apiURL == "" {
+					progress.FoldersListed++
+				} else {
+					progress.FoldersPending++
+				}
+				opts.Progress(progress)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func walkTestServer(mux *http.ServeMux) {
+	mux.HandleFunc("/me/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "docs", "name": "Documents", "folder": {}}, {"id": "tmp", "name": "Temp", "folder": {}}, {"id": "a", "name": "a.txt", "file": {}}]}`)
+	})
+	mux.HandleFunc("/me/drive/items/docs/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "b", "name": "b.txt", "file": {}}]}`)
+	})
+	mux.HandleFunc("/me/drive/items/tmp/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "c", "name": "c.txt", "file": {}}]}`)
+	})
+}
+
+func TestDriveItemsService_Walk(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	walkTestServer(mux)
+
+	var gotPaths []string
+	var got []ScanProgress
+	opts := WalkOpts{
+		Progress: func(progress ScanProgress) {
+			got = append(got, progress)
+		},
+	}
+	err := client.DriveItems.Walk(context.Background(), opts, func(itemPath string, item *DriveItem) error {
+		gotPaths = append(gotPaths, itemPath)
+		if item.Id == "tmp" {
+			return ErrSkipFolder
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.Walk returned error: %v", err)
+	}
+
+	if want := []string{"Documents", "Temp", "a.txt", "Documents/b.txt"}; !reflect.DeepEqual(gotPaths, want) {
+		t.Errorf("DriveItems.Walk walked %v, want %v", gotPaths, want)
+	}
+	if len(got) != 2 || got[1].ItemsDiscovered != 4 || got[1].FoldersListed != 2 || got[1].FoldersPending != 0 || got[1].PagesFetched != 2 {
+		t.Errorf("DriveItems.Walk reported %+v, want 2 pages of 4 items", got)
+	}
+}
+
+func TestDriveItemsService_Walk_noFunction(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	if err := client.DriveItems.Walk(context.Background(), WalkOpts{}, nil); err == nil {
+		t.Error("DriveItems.Walk returned no error without a function")
+	}
+}
+
+func TestDriveItemsService_ExportTree(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	walkTestServer(mux)
+
+	var pages int
+	var buffer bytes.Buffer
+	err := client.DriveItems.ExportTree(context.Background(), &buffer, ExportTreeOpts{
+		Progress: func(progress ScanProgress) {
+			pages = progress.PagesFetched
+		},
+	})
+	if err != nil {
+		t.Fatalf("DriveItems.ExportTree returned error: %v", err)
+	}
+
+	var gotPaths []string
+	decoder := json.NewDecoder(&buffer)
+	for decoder.More() {
+		var exported ExportedItem
+		if err := decoder.Decode(&exported); err != nil {
+			t.Fatalf("Decoding the exported tree returned error: %v", err)
+		}
+		gotPaths = append(gotPaths, exported.Path+"="+exported.Item.Id)
+	}
+
+	if want := []string{"Documents=docs", "Temp=tmp", "a.txt=a", "Documents/b.txt=b", "Temp/c.txt=c"}; !reflect.DeepEqual(gotPaths, want) {
+		t.Errorf("DriveItems.ExportTree exported %v, want %v", gotPaths, want)
+	}
+	if pages != 3 {
+		t.Errorf("DriveItems.ExportTree reported %d pages, want 3", pages)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FolderChangeType represents the kind of a FolderChange.
+type FolderChangeType int
+
+const (
+	// ItemCreated reports an item which has appeared in the folder, either created or moved in.
+	ItemCreated FolderChangeType = iota
+	// ItemModified reports an item of the folder whose content or metadata has changed.
+	ItemModified
+	// ItemDeleted reports an item which has been deleted from the folder or moved out of
+	// it.
+	ItemDeleted
+	// WatchFailed reports that the changes could not be looked up; the folder keeps being
+	// watched.
+	WatchFailed
+)
+
+// FolderChange represents a change of a child of a folder watched by a FolderWatcher.
+type FolderChange struct {
+	Type FolderChangeType
+	Item *DriveItem
+	// Err is the reason of a WatchFailed change.
+	Err error
+}
+
+// WatchFolderOpts represents the options for watching a folder by WatchFolder.
+type WatchFolderOpts struct {
+	// PollInterval is how long to wait between two lookups of the changes. Default is
+	// 1 minute.
+	PollInterval time.Duration
+	// Wake, if any, triggers a lookup of the changes as soon as it receives a value, e.g. on
+	// every change notification of a subscription to the folder received by the webhook
+	// package. PollInterval then only catches the notifications which were lost.
+	Wake <-chan struct{}
+}
+
+// WatchFolder watches the children of a folder in the default drive of the authenticated
+// user, and returns the watcher their changes are sent to, see FolderWatcher.Changes. The
+// current children are not reported; only what changes after WatchFolder returns is. The
+// folder is watched until ctx is done or the watcher is shut down.
+//
+// The changes are looked up with delta queries, see ListWithDeletions, periodically and
+// whenever opts.Wake receives a value, so application code does not depend on how it learns
+// that something changed. When the delta link expires, the folder is listed again and
+// compared with what was known of it.
+//
+// If folderId is empty, it means the children of the root of the default drive will be watched.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/scan-guidance?view=odsp-graph-online
+func (s *DriveItemsService) WatchFolder(ctx context.Context, folderId string, opts WatchFolderOpts) (*FolderWatcher, error) {
+	response, err := s.ListWithDeletions(ctx, folderId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Minute
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	watcher := &FolderWatcher{
+		service:   s,
+		folderId:  folderId,
+		deltaLink: response.DeltaLink,
+		children:  make(map[string]*DriveItem),
+		changes:   make(chan FolderChange),
+		cancel:    cancel,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	for _, item := range response.DriveItems {
+		if item.Deleted == nil {
+			watcher.children[item.Id] = item
+		}
+	}
+
+	go watcher.run(ctx, pollInterval, opts.Wake)
+
+	return watcher, nil
+}
+
+// FolderWatcher represents a folder watched by WatchFolder.
+type FolderWatcher struct {
+	service   *DriveItemsService
+	folderId  string
+	deltaLink string
+	children  map[string]*DriveItem // Last known state of the children, by ID.
+	changes   chan FolderChange
+
+	cancel   context.CancelFunc
+	stopOnce sync.Once
+	stop     chan struct{} // Closed by Shutdown.
+	done     chan struct{} // Closed once the changes are no longer looked up.
+}
+
+// Changes returns the channel the changes are sent to. The channel is closed once the
+// folder is no longer watched.
+func (w *FolderWatcher) Changes() <-chan FolderChange {
+	return w.changes
+}
+
+// Shutdown stops watching the folder once the changes being looked up, if any, have been
+// received from Changes. If ctx is done before, the watcher stops right away, and the error
+// of ctx is returned.
+func (w *FolderWatcher) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		<-w.done
+		return ctx.Err()
+	}
+}
+
+// run looks up the changes until ctx is done or the watcher is shut down.
+func (w *FolderWatcher) run(ctx context.Context, pollInterval time.Duration, wake <-chan struct{}) {
+	defer close(w.done)
+	defer close(w.changes)
+	defer w.cancel()
+
+	for {
+		if !w.wait(ctx, pollInterval, wake) {
+			return
+		}
+
+		for _, change := range w.lookUp(ctx) {
+			select {
+			case w.changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// wait waits for the poll interval to elapse or for a value from wake, and reports whether
+// the changes are still to be looked up.
+func (w *FolderWatcher) wait(ctx context.Context, pollInterval time.Duration, wake <-chan struct{}) bool {
+	sleepCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	slept := make(chan error, 1)
+	go func() {
+		slept <- w.service.client.sleep(sleepCtx, pollInterval)
+	}()
+
+	select {
+	case <-slept:
+	case <-wake:
+	case <-w.stop:
+		return false
+	case <-ctx.Done():
+	}
+	return ctx.Err() == nil
+}
+
+// lookUp returns the changes since the last lookup.
+func (w *FolderWatcher) lookUp(ctx context.Context) []FolderChange {
+	knownChildIds := make(map[string]bool, len(w.children))
+	for id := range w.children {
+		knownChildIds[id] = true
+	}
+
+	opts := ListWithDeletionsOpts{KnownChildIds: knownChildIds}
+	response, err := w.service.ListWithDeletionsWithOpts(ctx, w.folderId, w.deltaLink, opts)
+	if isStatus(err, http.StatusGone) {
+		return w.relist(ctx)
+	}
+	if err != nil {
+		return []FolderChange{{Type: WatchFailed, Err: err}}
+	}
+	w.deltaLink = response.DeltaLink
+
+	var changes []FolderChange
+	for _, item := range response.DriveItems {
+		if change, ok := w.apply(item); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// relist lists the folder again after the delta link has expired, and returns the
+// differences with the last known state of its children.
+func (w *FolderWatcher) relist(ctx context.Context) []FolderChange {
+	response, err := w.service.ListWithDeletions(ctx, w.folderId, "")
+	if err != nil {
+		return []FolderChange{{Type: WatchFailed, Err: err}}
+	}
+	w.deltaLink = response.DeltaLink
+
+	var changes []FolderChange
+	listed := make(map[string]bool)
+	for _, item := range response.DriveItems {
+		listed[item.Id] = true
+		if change, ok := w.apply(item); ok {
+			changes = append(changes, change)
+		}
+	}
+	for id, item := range w.children {
+		if !listed[id] {
+			delete(w.children, id)
+			changes = append(changes, FolderChange{Type: ItemDeleted, Item: item})
+		}
+	}
+	return changes
+}
+
+// apply records the new state of a child, and returns the change it represents, if any.
+func (w *FolderWatcher) apply(item *DriveItem) (FolderChange, bool) {
+	old, known := w.children[item.Id]
+	switch {
+	case item.Deleted != nil:
+		if !known {
+			return FolderChange{}, false
+		}
+		delete(w.children, item.Id)
+		return FolderChange{Type: ItemDeleted, Item: item}, true
+	case !known:
+		w.children[item.Id] = item
+		return FolderChange{Type: ItemCreated, Item: item}, true
+	case HasMetadataChanged(old, item):
+		w.children[item.Id] = item
+		return FolderChange{Type: ItemModified, Item: item}, true
+	}
+	return FolderChange{}, false
+}
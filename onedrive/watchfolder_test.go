@@ -0,0 +1,92 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDriveItemsService_WatchFolder(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	deltaURL := serverURL + baseURLPath + "/me/drive/items/folder-1/delta"
+	listings := 0
+	mux.HandleFunc("/me/drive/items/folder-1/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("token") {
+		case "":
+			listings++
+			if listings == 1 {
+				fmt.Fprintf(w, `{"value":[
+					{"id":"1","eTag":"a","parentReference":{"id":"folder-1"}},
+					{"id":"3","eTag":"a","parentReference":{"id":"folder-1"}},
+					{"id":"5","eTag":"a","parentReference":{"id":"folder-1"}}
+				],"@odata.deltaLink":"%s?token=1"}`, deltaURL)
+				return
+			}
+			// Item 1 has been moved out of the folder while the delta link was expired.
+			fmt.Fprintf(w, `{"value":[
+				{"id":"2","eTag":"a","parentReference":{"id":"folder-1"}}
+			],"@odata.deltaLink":"%s?token=3"}`, deltaURL)
+		case "1":
+			fmt.Fprintf(w, `{"value":[
+				{"id":"1","eTag":"b","parentReference":{"id":"folder-1"}},
+				{"id":"2","eTag":"a","parentReference":{"id":"folder-1"}},
+				{"id":"3","deleted":{"state":"deleted"},"parentReference":{"id":"folder-1"}},
+				{"id":"4","eTag":"a","parentReference":{"id":"folder-2"}},
+				{"id":"5","eTag":"b","parentReference":{"id":"folder-2"}}
+			],"@odata.deltaLink":"%s?token=2"}`, deltaURL)
+		case "2":
+			w.WriteHeader(http.StatusGone)
+			fmt.Fprint(w, `{"error":{"code":"resyncRequired","message":"Resync required."}}`)
+		default:
+			t.Errorf("Unexpected delta query %s", r.URL.RawQuery)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wake := make(chan struct{})
+	watcher, err := client.DriveItems.WatchFolder(ctx, "folder-1", WatchFolderOpts{PollInterval: time.Hour, Wake: wake})
+	if err != nil {
+		t.Fatalf("DriveItems.WatchFolder returned error: %v", err)
+	}
+
+	changes := watcher.Changes()
+	receive := func(want FolderChangeType, wantId string) {
+		t.Helper()
+		select {
+		case change := <-changes:
+			if change.Type != want || change.Item == nil || change.Item.Id != wantId {
+				t.Errorf("Received %+v, want change %d of item %s", change, want, wantId)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("No change received, want change %d of item %s", want, wantId)
+		}
+	}
+
+	wake <- struct{}{}
+	receive(ItemModified, "1")
+	receive(ItemCreated, "2")
+	receive(ItemDeleted, "3")
+	receive(ItemDeleted, "5")
+
+	wake <- struct{}{}
+	receive(ItemDeleted, "1")
+
+	if err := watcher.Shutdown(ctx); err != nil {
+		t.Errorf("FolderWatcher.Shutdown returned error: %v", err)
+	}
+	if _, ok := <-changes; ok {
+		t.Error("The changes are still sent after FolderWatcher.Shutdown")
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// EventType represents the kind of an Event emitted by a Manager.
+type EventType int
+
+const (
+	// ChangeEvent reports a change notification.
+	ChangeEvent EventType = iota
+	// ResyncRequiredEvent reports that change notifications may have been lost, either because
+	// Microsoft Graph missed delivering some or because it removed the subscription. The
+	// changes must be looked up again, e.g. with a delta query, and a removed subscription
+	// must be created again.
+	ResyncRequiredEvent
+	// ReauthorizationFailedEvent reports that a subscription could not be reauthorized, so
+	// Microsoft Graph is going to remove it.
+	ReauthorizationFailedEvent
+)
+
+// Event represents what happened to a subscription, as handled by a Manager.
+type Event struct {
+	Type         EventType
+	Notification Notification
+	// Err is the reason of a ReauthorizationFailedEvent.
+	Err error
+}
+
+// Manager handles the lifecycle notifications received by a Handler, so that the subscriptions
+// go on delivering change notifications. Subscriptions requiring a reauthorization are
+// reauthorized automatically, and the other notifications are emitted as events.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/webhooks-lifecycle
+type Manager struct {
+	subscriptions *onedrive.SubscriptionsService
+	handler       *Handler
+	events        chan Event
+
+	mu   sync.Mutex
+	done chan struct{} // Closed once Run has returned, nil until Run is called.
+}
+
+// NewManager returns a new Manager handling the notifications received by handler, and
+// reauthorizing the subscriptions with the client.
+func NewManager(client *onedrive.Client, handler *Handler) *Manager {
+	return &Manager{
+		subscriptions: client.Subscriptions,
+		handler:       handler,
+		events:        make(chan Event),
+	}
+}
+
+// Events returns the channel the events are emitted to. The channel is closed when Run returns.
+func (m *Manager) Events() <-chan Event {
+	return m.events
+}
+
+// Run handles the notifications until the handler is shut down or ctx is done. It must be
+// called once, while the events are received from Events.
+func (m *Manager) Run(ctx context.Context) error {
+	done := make(chan struct{})
+	m.mu.Lock()
+	m.done = done
+	m.mu.Unlock()
+
+	defer close(done)
+	defer close(m.events)
+
+	for {
+		select {
+		case notification, ok := <-m.handler.Notifications():
+			if !ok {
+				return nil
+			}
+
+			event, emit := m.handle(ctx, notification)
+			if !emit {
+				continue
+			}
+
+			select {
+			case m.events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Shutdown shuts the handler down, see Handler.Shutdown, then waits for Run to emit the
+// events of the notifications already queued and to return, so they are not lost. The
+// events must keep being received meanwhile. If ctx expires first, its error is returned.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	err := m.handler.Shutdown(ctx)
+
+	m.mu.Lock()
+	done := m.done
+	m.mu.Unlock()
+	if done == nil {
+		return err
+	}
+
+	select {
+	case <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handle processes a notification, and returns the event to emit for it, if any.
+func (m *Manager) handle(ctx context.Context, notification Notification) (Event, bool) {
+	switch notification.LifecycleEvent {
+	case "":
+		return Event{Type: ChangeEvent, Notification: notification}, true
+	case ReauthorizationRequired:
+		if err := m.subscriptions.Reauthorize(ctx, notification.SubscriptionId); err != nil {
+			return Event{Type: ReauthorizationFailedEvent, Notification: notification, Err: err}, true
+		}
+		return Event{}, false
+	case SubscriptionRemoved, Missed:
+		return Event{Type: ResyncRequiredEvent, Notification: notification}, true
+	}
+
+	// Lifecycle events introduced after this package are ignored.
+	return Event{}, false
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+func TestManager_Run(t *testing.T) {
+	var reauthorized []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Request method: %v, want POST", r.Method)
+		}
+
+		reauthorized = append(reauthorized, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := onedrive.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	handler := NewHandler("secret", 4)
+	manager := NewManager(client, handler)
+
+	body := `{"value": [
+		{"subscriptionId": "1", "clientState": "secret", "changeType": "updated"},
+		{"subscriptionId": "2", "clientState": "secret", "lifecycleEvent": "reauthorizationRequired"},
+		{"subscriptionId": "3", "clientState": "secret", "lifecycleEvent": "missed"},
+		{"subscriptionId": "4", "clientState": "secret", "lifecycleEvent": "subscriptionRemoved"}
+	]}`
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(body)))
+	handler.Shutdown(context.Background())
+
+	done := make(chan error)
+	go func() {
+		done <- manager.Run(context.Background())
+	}()
+
+	var events []Event
+	for event := range manager.Events() {
+		events = append(events, event)
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("Manager.Run returned error: %v", err)
+	}
+
+	if len(reauthorized) != 1 || reauthorized[0] != "/subscriptions/2/reauthorize" {
+		t.Errorf("Reauthorized subscriptions: %v, want only subscription 2", reauthorized)
+	}
+
+	want := []struct {
+		eventType      EventType
+		subscriptionId string
+	}{
+		{ChangeEvent, "1"},
+		{ResyncRequiredEvent, "3"},
+		{ResyncRequiredEvent, "4"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Manager emitted %d events, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		if events[i].Type != w.eventType || events[i].Notification.SubscriptionId != w.subscriptionId {
+			t.Errorf("Event %d: %+v, want type %v for subscription %v", i, events[i], w.eventType, w.subscriptionId)
+		}
+	}
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	var _ onedrive.Shutdowner = (*Manager)(nil)
+
+	handler := NewHandler("secret", 4)
+	manager := NewManager(onedrive.NewClient(nil), handler)
+
+	done := make(chan error)
+	go func() {
+		done <- manager.Run(context.Background())
+	}()
+
+	body := `{"value": [{"subscriptionId": "1", "clientState": "secret", "changeType": "updated"}]}`
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(body)))
+
+	shutdown := make(chan error)
+	go func() {
+		shutdown <- manager.Shutdown(context.Background())
+	}()
+
+	var events []Event
+	for event := range manager.Events() {
+		events = append(events, event)
+	}
+
+	if err := <-shutdown; err != nil {
+		t.Errorf("Manager.Shutdown returned error: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Manager.Run returned error: %v", err)
+	}
+	if len(events) != 1 || events[0].Notification.SubscriptionId != "1" {
+		t.Errorf("Manager emitted %+v, want the queued notification", events)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package webhook provides an http.Handler receiving the change notifications of the
+// subscriptions created with the SubscriptionsService of the onedrive package.
+//
+// The handler answers the validation requests sent by Microsoft Graph when a subscription
+// is created, discards the notifications which do not carry the expected client state, and
+// queues the others to a channel:
+//
+//	handler := webhook.NewHandler("secret client state", 100)
+//	go http.ListenAndServe(":8080", handler)
+//
+//	subscription, err := client.Subscriptions.Create(ctx, &onedrive.Subscription{
+//		Resource:           "me/drive/root",
+//		NotificationURL:    "https://example.com/",
+//		ClientState:        "secret client state",
+//		ExpirationDateTime: time.Now().Add(48 * time.Hour),
+//	})
+//
+//	for notification := range handler.Notifications() {
+//		// Look for the changes, e.g. with a delta query.
+//	}
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/using-webhooks?view=odsp-graph-online
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Notification represents a change notification delivered by Microsoft Graph.
+type Notification struct {
+	SubscriptionId                 string    `json:"subscriptionId"`
+	SubscriptionExpirationDateTime time.Time `json:"subscriptionExpirationDateTime"`
+	ClientState                    string    `json:"clientState"`
+	ChangeType                     string    `json:"changeType"`
+	Resource                       string    `json:"resource"`
+	TenantId                       string    `json:"tenantId"`
+	// LifecycleEvent is only set for lifecycle notifications, see Manager.
+	LifecycleEvent string `json:"lifecycleEvent,omitempty"`
+}
+
+// The lifecycle events Microsoft Graph notifies to the lifecycle notification URL of a subscription.
+const (
+	ReauthorizationRequired = "reauthorizationRequired"
+	SubscriptionRemoved     = "subscriptionRemoved"
+	Missed                  = "missed"
+)
+
+// Handler is an http.Handler receiving change notifications. It must be created with NewHandler.
+type Handler struct {
+	clientState   string
+	notifications chan Notification
+
+	mu       sync.RWMutex
+	closed   bool
+	done     chan struct{}
+	inFlight sync.WaitGroup
+}
+
+// NewHandler returns a new Handler accepting only the notifications carrying clientState,
+// and queueing up to bufferSize notifications not yet received from Notifications.
+func NewHandler(clientState string, bufferSize int) *Handler {
+	return &Handler{
+		clientState:   clientState,
+		notifications: make(chan Notification, bufferSize),
+		done:          make(chan struct{}),
+	}
+}
+
+// Notifications returns the channel the notifications are queued to. The channel is
+// closed by Shutdown.
+func (h *Handler) Notifications() <-chan Notification {
+	return h.notifications
+}
+
+// ServeHTTP answers the validation requests of Microsoft Graph and queues the change
+// notifications. When the queue is full, the request waits until there is room, or fails
+// with 503 Service Unavailable so that Microsoft Graph delivers the notifications again later.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.enter() {
+		http.Error(w, "The webhook is shutting down.", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.inFlight.Done()
+
+	if validationToken := r.URL.Query().Get("validationToken"); validationToken != "" {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(validationToken))
+		return
+	}
+
+	if r.Method != "POST" {
+		http.Error(w, "Only POST is allowed.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload struct {
+		Notifications []Notification `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "The notifications cannot be decoded.", http.StatusBadRequest)
+		return
+	}
+
+	for _, notification := range payload.Notifications {
+		if notification.ClientState != h.clientState {
+			// The notification does not come from a subscription of ours.
+			continue
+		}
+
+		select {
+		case h.notifications <- notification:
+		case <-r.Context().Done():
+			return
+		case <-h.done:
+			http.Error(w, "The webhook is shutting down.", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// enter registers a request in flight, unless the handler has been shut down.
+func (h *Handler) enter() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.closed {
+		return false
+	}
+
+	h.inFlight.Add(1)
+	return true
+}
+
+// Shutdown stops the handler from accepting notifications, waits for the requests in flight
+// to complete, then closes the notification channel. The notifications already queued can
+// still be received from the channel. If ctx expires first, the requests still waiting for
+// room in the queue are failed so that Microsoft Graph delivers them again later.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	h.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		h.inFlight.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+		close(h.done)
+		<-drained
+	}
+
+	close(h.notifications)
+	return err
+}
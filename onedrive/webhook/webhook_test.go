@@ -0,0 +1,100 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_validation(t *testing.T) {
+	handler := NewHandler("secret", 1)
+
+	req := httptest.NewRequest("POST", "/?validationToken=token%20value", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Errorf("Status code: %v, want %v", got, want)
+	}
+
+	if got, want := w.Header().Get("Content-Type"), "text/plain"; got != want {
+		t.Errorf("Content-Type: %v, want %v", got, want)
+	}
+
+	if got, want := w.Body.String(), "token value"; got != want {
+		t.Errorf("Body: %q, want %q", got, want)
+	}
+}
+
+func TestHandler_notifications(t *testing.T) {
+	handler := NewHandler("secret", 2)
+
+	body := `{"value": [
+		{"subscriptionId": "1", "clientState": "secret", "changeType": "updated", "resource": "me/drive/root"},
+		{"subscriptionId": "2", "clientState": "forged", "changeType": "updated", "resource": "me/drive/root"}
+	]}`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got, want := w.Code, http.StatusAccepted; got != want {
+		t.Errorf("Status code: %v, want %v", got, want)
+	}
+
+	if err := handler.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown returned error: %v", err)
+	}
+
+	var got []Notification
+	for notification := range handler.Notifications() {
+		got = append(got, notification)
+	}
+
+	if len(got) != 1 || got[0].SubscriptionId != "1" {
+		t.Errorf("Notifications: %+v, want only the one of subscription 1", got)
+	}
+}
+
+func TestHandler_Shutdown(t *testing.T) {
+	handler := NewHandler("secret", 0)
+
+	body := `{"value": [{"subscriptionId": "1", "clientState": "secret"}]}`
+	w := httptest.NewRecorder()
+	served := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader(body)))
+		close(served)
+	}()
+
+	// Nobody receives the notification, so the request stays in flight until the
+	// shutdown deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	time.Sleep(10 * time.Millisecond)
+	if err := handler.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	<-served
+	if got, want := w.Code, http.StatusServiceUnavailable; got != want {
+		t.Errorf("Status code: %v, want %v", got, want)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader(body)))
+	if got, want := w.Code, http.StatusServiceUnavailable; got != want {
+		t.Errorf("Status code after shutdown: %v, want %v", got, want)
+	}
+
+	if _, ok := <-handler.Notifications(); ok {
+		t.Errorf("Notifications channel is not closed after shutdown")
+	}
+}
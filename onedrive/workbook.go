@@ -0,0 +1,285 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// WorkbookService handles communication with the Excel workbook related methods of the
+// Microsoft Graph API, for the workbooks (.xlsx files) stored in a drive of the
+// authenticated user.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/excel?view=graph-rest-1.0
+type WorkbookService service
+
+// WorkbookNamedItem represents a name of a workbook, which usually refers to a range of
+// cells, e.g. "TotalSales" for "Sheet1!$B$2:$B$13".
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/nameditem?view=graph-rest-1.0
+type WorkbookNamedItem struct {
+	Name    string      `json:"name"`
+	Comment string      `json:"comment"`
+	Scope   string      `json:"scope"`
+	Type    string      `json:"type"`
+	Value   interface{} `json:"value"`
+	Visible bool        `json:"visible"`
+}
+
+// OneDriveWorkbookNamesResponse represents the JSON object containing the names of a
+// workbook returned by the Microsoft Graph API.
+type OneDriveWorkbookNamesResponse struct {
+	ODataContext string               `json:"@odata.context"`
+	Names        []*WorkbookNamedItem `json:"value"`
+}
+
+// WorkbookRange represents a range of cells of a worksheet. The values, texts, formulas and
+// number formats are given by row, then by column.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/range?view=graph-rest-1.0
+type WorkbookRange struct {
+	Address      string          `json:"address"`
+	AddressLocal string          `json:"addressLocal"`
+	CellCount    int             `json:"cellCount"`
+	ColumnCount  int             `json:"columnCount"`
+	ColumnIndex  int             `json:"columnIndex"`
+	RowCount     int             `json:"rowCount"`
+	RowIndex     int             `json:"rowIndex"`
+	Values       [][]interface{} `json:"values"`
+	Text         [][]string      `json:"text"`
+	Formulas     [][]interface{} `json:"formulas"`
+	NumberFormat [][]interface{} `json:"numberFormat"`
+}
+
+// WorkbookRangeFormatOpts represents the formatting applied to every cell of a range by
+// FormatRange and FormatNamedRange. The empty properties are left unchanged.
+type WorkbookRangeFormatOpts struct {
+	// NumberFormat is the Excel number format code, e.g. "0.00%" or "yyyy-mm-dd".
+	NumberFormat string
+	// FillColor is the background color, as an HTML color code, e.g. "#FFFF00", or a
+	// color name, e.g. "yellow".
+	FillColor string
+}
+
+// ListNames returns the names defined in a workbook.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/nameditem-list?view=graph-rest-1.0
+func (s *WorkbookService) ListNames(ctx context.Context, itemId string) (*OneDriveWorkbookNamesResponse, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the workbook.")
+	}
+
+	req, err := s.client.NewRequest("GET", workbookURL(itemId)+"/names", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *OneDriveWorkbookNamesResponse
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AddName defines a name in a workbook referring to a range of cells, e.g.
+// "=Sheet1!$A$1:$C$10", or to a formula.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/nameditem-add?view=graph-rest-1.0
+func (s *WorkbookService) AddName(ctx context.Context, itemId string, name string, reference string, comment string) (*WorkbookNamedItem, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the workbook.")
+	}
+
+	if name == "" || reference == "" {
+		return nil, errors.New("Please provide the name and what it refers to.")
+	}
+
+	body := map[string]string{
+		"name":      name,
+		"reference": reference,
+		"comment":   comment,
+	}
+
+	req, err := s.client.NewRequest("POST", workbookURL(itemId)+"/names/add", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *WorkbookNamedItem
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetNamedRange returns the range of cells a name of a workbook refers to, with the values
+// of the cells.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/nameditem-range?view=graph-rest-1.0
+func (s *WorkbookService) GetNamedRange(ctx context.Context, itemId string, name string) (*WorkbookRange, error) {
+	if itemId == "" || name == "" {
+		return nil, errors.New("Please provide the Item ID of the workbook and the name of the range.")
+	}
+
+	return s.getRange(ctx, namedRangeURL(itemId, name))
+}
+
+// UpdateNamedRange writes values into the range of cells a name of a workbook refers to,
+// e.g. to fill a template spreadsheet. The values are given by row, then by column, and
+// must have the dimensions of the range; nil values leave their cells unchanged.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/range-update?view=graph-rest-1.0
+func (s *WorkbookService) UpdateNamedRange(ctx context.Context, itemId string, name string, values [][]interface{}) (*WorkbookRange, error) {
+	if itemId == "" || name == "" {
+		return nil, errors.New("Please provide the Item ID of the workbook and the name of the range.")
+	}
+
+	if len(values) == 0 {
+		return nil, errors.New("Please provide the values of the range.")
+	}
+
+	return s.updateRange(ctx, namedRangeURL(itemId, name), map[string]interface{}{"values": values})
+}
+
+// GetRange returns a range of cells of a worksheet, addressed like "A1:C10", with the values
+// of the cells.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/range-get?view=graph-rest-1.0
+func (s *WorkbookService) GetRange(ctx context.Context, itemId string, worksheet string, address string) (*WorkbookRange, error) {
+	if itemId == "" || worksheet == "" || address == "" {
+		return nil, errors.New("Please provide the Item ID of the workbook, the worksheet and the address of the range.")
+	}
+
+	return s.getRange(ctx, worksheetRangeURL(itemId, worksheet, address))
+}
+
+// FormatRange applies a number format and a fill color to every cell of a range of a
+// worksheet, addressed like "A1:C10".
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/rangefill-update?view=graph-rest-1.0
+func (s *WorkbookService) FormatRange(ctx context.Context, itemId string, worksheet string, address string, opts WorkbookRangeFormatOpts) error {
+	if itemId == "" || worksheet == "" || address == "" {
+		return errors.New("Please provide the Item ID of the workbook, the worksheet and the address of the range.")
+	}
+
+	return s.formatRange(ctx, worksheetRangeURL(itemId, worksheet, address), opts)
+}
+
+// FormatNamedRange applies a number format and a fill color to every cell of the range a
+// name of a workbook refers to.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/rangefill-update?view=graph-rest-1.0
+func (s *WorkbookService) FormatNamedRange(ctx context.Context, itemId string, name string, opts WorkbookRangeFormatOpts) error {
+	if itemId == "" || name == "" {
+		return errors.New("Please provide the Item ID of the workbook and the name of the range.")
+	}
+
+	return s.formatRange(ctx, namedRangeURL(itemId, name), opts)
+}
+
+// formatRange applies the formatting to the range at rangeURL. The number formats of a range
+// have to be given cell by cell, so the dimensions of the range are retrieved first.
+func (s *WorkbookService) formatRange(ctx context.Context, rangeURL string, opts WorkbookRangeFormatOpts) error {
+	if opts.NumberFormat == "" && opts.FillColor == "" {
+		return errors.New("Please provide the number format or the fill color.")
+	}
+
+	if opts.NumberFormat != "" {
+		dimensions, err := s.getRange(ctx, rangeURL+"?$select=rowCount,columnCount")
+		if err != nil {
+			return err
+		}
+
+		numberFormat := make([][]interface{}, dimensions.RowCount)
+		for i := range numberFormat {
+			numberFormat[i] = make([]interface{}, dimensions.ColumnCount)
+			for j := range numberFormat[i] {
+				numberFormat[i][j] = opts.NumberFormat
+			}
+		}
+
+		if _, err := s.updateRange(ctx, rangeURL, map[string]interface{}{"numberFormat": numberFormat}); err != nil {
+			return err
+		}
+	}
+
+	if opts.FillColor != "" {
+		req, err := s.client.NewRequest("PATCH", rangeURL+"/format/fill", map[string]string{"color": opts.FillColor})
+		if err != nil {
+			return err
+		}
+
+		var fill map[string]interface{}
+		if err := s.client.Do(ctx, req, false, &fill); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getRange retrieves the range at rangeURL.
+func (s *WorkbookService) getRange(ctx context.Context, rangeURL string) (*WorkbookRange, error) {
+	req, err := s.client.NewRequest("GET", rangeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *WorkbookRange
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, errors.New("The range has not been returned.")
+	}
+
+	return response, nil
+}
+
+// updateRange updates the properties of the range at rangeURL.
+func (s *WorkbookService) updateRange(ctx context.Context, rangeURL string, properties map[string]interface{}) (*WorkbookRange, error) {
+	req, err := s.client.NewRequest("PATCH", rangeURL, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *WorkbookRange
+	err = s.client.Do(ctx, req, false, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// workbookURL returns the relative URL of the workbook of an item of the default drive.
+func workbookURL(itemId string) string {
+	return "me/drive/items/" + url.PathEscape(itemId) + "/workbook"
+}
+
+// namedRangeURL returns the relative URL of the range a name of a workbook refers to.
+func namedRangeURL(itemId string, name string) string {
+	return workbookURL(itemId) + "/names/" + url.PathEscape(name) + "/range"
+}
+
+// worksheetURL returns the relative URL of a worksheet of a workbook.
+func worksheetURL(itemId string, worksheet string) string {
+	return workbookURL(itemId) + "/worksheets/" + url.PathEscape(worksheet)
+}
+
+// worksheetRangeURL returns the relative URL of a range of a worksheet of a workbook.
+func worksheetRangeURL(itemId string, worksheet string, address string) string {
+	// Single quotes are escaped by doubling them in OData string literals.
+	address = strings.Replace(address, "'", "''", -1)
+	return worksheetURL(itemId, worksheet) + "/range(address='" + url.PathEscape(address) + "')"
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWorkbookService_AddName(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/workbook/names/add", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "Total" || body["reference"] != "=Sheet1!$B$2:$B$13" {
+			t.Errorf("The body is %v", body)
+		}
+		fmt.Fprint(w, `{"name":"Total","type":"Range","value":"Sheet1!$B$2:$B$13","visible":true}`)
+	})
+
+	name, err := client.Workbooks.AddName(context.Background(), "1", "Total", "=Sheet1!$B$2:$B$13", "")
+	if err != nil {
+		t.Fatalf("Workbooks.AddName returned error: %v", err)
+	}
+	if name.Name != "Total" || name.Type != "Range" || !name.Visible {
+		t.Errorf("Workbooks.AddName returned %+v", name)
+	}
+}
+
+func TestWorkbookService_UpdateNamedRange(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/workbook/names/Header/range", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		var body struct {
+			Values [][]interface{} `json:"values"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if want := [][]interface{}{{"Month", 1.0}}; !reflect.DeepEqual(body.Values, want) {
+			t.Errorf("The values are %v, want %v", body.Values, want)
+		}
+		fmt.Fprint(w, `{"address":"Sheet1!A1:B1","rowCount":1,"columnCount":2,"values":[["Month",1]]}`)
+	})
+
+	r, err := client.Workbooks.UpdateNamedRange(context.Background(), "1", "Header", [][]interface{}{{"Month", 1}})
+	if err != nil {
+		t.Fatalf("Workbooks.UpdateNamedRange returned error: %v", err)
+	}
+	if r.Address != "Sheet1!A1:B1" || r.ColumnCount != 2 {
+		t.Errorf("Workbooks.UpdateNamedRange returned %+v", r)
+	}
+}
+
+func TestWorkbookService_FormatRange(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	rangePath := "/me/drive/items/1/workbook/worksheets/Sheet 1/range(address='A1:B2')"
+	mux.HandleFunc(rangePath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"rowCount":2,"columnCount":2}`)
+		case "PATCH":
+			var body struct {
+				NumberFormat [][]string `json:"numberFormat"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if want := [][]string{{"0.00%", "0.00%"}, {"0.00%", "0.00%"}}; !reflect.DeepEqual(body.NumberFormat, want) {
+				t.Errorf("The number formats are %v, want %v", body.NumberFormat, want)
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+	filled := false
+	mux.HandleFunc(rangePath+"/format/fill", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["color"] != "#FFFF00" {
+			t.Errorf("The body is %v", body)
+		}
+		filled = true
+		fmt.Fprint(w, `{"color":"#FFFF00"}`)
+	})
+
+	err := client.Workbooks.FormatRange(context.Background(), "1", "Sheet 1", "A1:B2", WorkbookRangeFormatOpts{NumberFormat: "0.00%", FillColor: "#FFFF00"})
+	if err != nil {
+		t.Fatalf("Workbooks.FormatRange returned error: %v", err)
+	}
+	if !filled {
+		t.Error("Workbooks.FormatRange did not fill the range")
+	}
+
+	if err := client.Workbooks.FormatRange(context.Background(), "1", "Sheet 1", "A1:B2", WorkbookRangeFormatOpts{}); err == nil {
+		t.Error("Workbooks.FormatRange returned no error without formatting")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ExportCSVOpts represents the options for exporting a worksheet by ExportCSV.
+type ExportCSVOpts struct {
+	// RowsPerRequest is the number of rows retrieved by each request, so large worksheets
+	// are read in pages rather than in a single response. Default is 1000.
+	RowsPerRequest int
+	// RawValues exports the values of the cells, e.g. 44197 for a date, instead of their
+	// text as displayed by Excel, e.g. "1/1/2021".
+	RawValues bool
+	// Comma is the field delimiter. Default is ','.
+	Comma rune
+}
+
+// ExportCSV writes the used range of a worksheet of a workbook to w as CSV, one record per
+// row, e.g. to ingest spreadsheets stored in OneDrive into an ETL pipeline. The rows are
+// retrieved and written page by page, so large worksheets are streamed.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/worksheet-usedrange?view=graph-rest-1.0
+func (s *WorkbookService) ExportCSV(ctx context.Context, itemId string, worksheet string, w io.Writer, opts ExportCSVOpts) error {
+	if itemId == "" || worksheet == "" {
+		return errors.New("Please provide the Item ID of the workbook and the worksheet.")
+	}
+
+	if w == nil {
+		return errors.New("Please provide the writer of the CSV.")
+	}
+
+	rowsPerRequest := opts.RowsPerRequest
+	if rowsPerRequest <= 0 {
+		rowsPerRequest = 1000
+	}
+
+	usedRange, err := s.getRange(ctx, worksheetURL(itemId, worksheet)+"/usedRange(valuesOnly=true)?$select=address,rowIndex,columnIndex,rowCount,columnCount")
+	if err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		csvWriter.Comma = opts.Comma
+	}
+
+	selectProperty := "text"
+	if opts.RawValues {
+		selectProperty = "values"
+	}
+
+	firstColumn := columnName(usedRange.ColumnIndex)
+	lastColumn := columnName(usedRange.ColumnIndex + usedRange.ColumnCount - 1)
+	for row := 0; row < usedRange.RowCount && usedRange.ColumnCount > 0; row += rowsPerRequest {
+		rowCount := usedRange.RowCount - row
+		if rowCount > rowsPerRequest {
+			rowCount = rowsPerRequest
+		}
+
+		address := fmt.Sprintf("%s%d:%s%d", firstColumn, usedRange.RowIndex+row+1, lastColumn, usedRange.RowIndex+row+rowCount)
+		page, err := s.getRange(ctx, worksheetRangeURL(itemId, worksheet, address)+"?$select="+selectProperty)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < rowCount; i++ {
+			record := make([]string, usedRange.ColumnCount)
+			for j := range record {
+				if opts.RawValues {
+					if i < len(page.Values) && j < len(page.Values[i]) {
+						record[j] = csvValue(page.Values[i][j])
+					}
+				} else if i < len(page.Text) && j < len(page.Text[i]) {
+					record[j] = page.Text[i][j]
+				}
+			}
+
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		}
+
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// columnName returns the name of a column of a worksheet from its zero-based index, e.g.
+// "A" for 0 and "AA" for 26.
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// csvValue formats the value of a cell as returned by the Workbook API.
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWorkbookService_ExportCSV(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/workbook/worksheets/Data/usedRange(valuesOnly=true)", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"address":"Data!B2:C4","rowIndex":1,"columnIndex":1,"rowCount":3,"columnCount":2}`)
+	})
+	var addresses []string
+	mux.HandleFunc("/me/drive/items/1/workbook/worksheets/Data/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("$select"), "text"; got != want {
+			t.Errorf("$select = %q, want %q", got, want)
+		}
+		addresses = append(addresses, r.URL.Path)
+		switch r.URL.Path {
+		case "/me/drive/items/1/workbook/worksheets/Data/range(address='B2:C3')":
+			fmt.Fprint(w, `{"text":[["Name","Amount"],["Smith, J.","1,000"]]}`)
+		case "/me/drive/items/1/workbook/worksheets/Data/range(address='B4:C4')":
+			fmt.Fprint(w, `{"text":[["Doe",""]]}`)
+		default:
+			t.Errorf("Unexpected range %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+
+	var buf bytes.Buffer
+	err := client.Workbooks.ExportCSV(context.Background(), "1", "Data", &buf, ExportCSVOpts{RowsPerRequest: 2})
+	if err != nil {
+		t.Fatalf("Workbooks.ExportCSV returned error: %v", err)
+	}
+
+	want := "Name,Amount\n\"Smith, J.\",\"1,000\"\nDoe,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Workbooks.ExportCSV wrote %q, want %q", got, want)
+	}
+	if len(addresses) != 2 {
+		t.Errorf("Workbooks.ExportCSV requested %v", addresses)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for index, want := range tests {
+		if got := columnName(index); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}